@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	slicev1 "github.com/niczy/gitslice/proto/slice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// streamCheckoutThreshold is the total file size above which checkouts are
+// fetched over StreamCheckoutSlice instead of a single CheckoutSlice response.
+const streamCheckoutThreshold = 2 << 20
+
+// fetchCheckout requests a slice checkout. It first fetches the manifest and
+// picks StreamCheckoutSlice when forced or when the files add up to more than
+// streamCheckoutThreshold. The follow-up request names the manifest's commit so
+// both describe the same snapshot even if the slice head moves in between.
+func fetchCheckout(ctx context.Context, client slicev1.SliceServiceClient, req *slicev1.CheckoutRequest, forceStream bool) (*slicev1.CheckoutResponse, error) {
+	if !forceStream {
+		resp, err := client.CheckoutSlice(ctx, &slicev1.CheckoutRequest{SliceId: req.SliceId, CommitHash: req.CommitHash, ManifestOnly: true})
+		switch {
+		case status.Code(err) == codes.ResourceExhausted:
+			// Even the manifest is too large for one message
+		case err != nil:
+			return nil, err
+		default:
+			if commitHash := resp.GetManifest().GetCommitHash(); commitHash != "" {
+				req = &slicev1.CheckoutRequest{SliceId: req.SliceId, CommitHash: commitHash}
+			}
+			if checkoutSize(resp.GetManifest()) <= streamCheckoutThreshold {
+				return client.CheckoutSlice(ctx, req)
+			}
+		}
+	}
+
+	stream, err := client.StreamCheckoutSlice(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return assembleCheckout(stream.Recv)
+}
+
+// checkoutSize adds up the sizes of the files in a manifest.
+func checkoutSize(manifest *slicev1.SliceManifest) int64 {
+	var total int64
+	for _, fm := range manifest.GetFileMetadata() {
+		total += fm.Size
+	}
+	return total
+}
+
+// assembleCheckout rebuilds a checkout response from streamed chunks. The
+// manifest arrives first; file contents may be split across several chunks
+// that share a file ID.
+func assembleCheckout(recv func() (*slicev1.CheckoutChunk, error)) (*slicev1.CheckoutResponse, error) {
+	resp := &slicev1.CheckoutResponse{}
+	files := make(map[string]*slicev1.FileContent)
+
+	for {
+		chunk, err := recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch c := chunk.Chunk.(type) {
+		case *slicev1.CheckoutChunk_Manifest:
+			resp.Manifest = c.Manifest
+		case *slicev1.CheckoutChunk_File:
+			if resp.Manifest == nil {
+				return nil, fmt.Errorf("received file %s before manifest", c.File.FileId)
+			}
+			file, ok := files[c.File.FileId]
+			if !ok {
+				file = &slicev1.FileContent{FileId: c.File.FileId}
+				files[c.File.FileId] = file
+				resp.Files = append(resp.Files, file)
+			}
+			file.Content = append(file.Content, c.File.Content...)
+		}
+	}
+
+	if resp.Manifest == nil {
+		return nil, errors.New("checkout stream ended without a manifest")
+	}
+	return resp, nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	slicev1 "github.com/niczy/gitslice/proto/slice"
+	"google.golang.org/grpc"
+)
+
+func TestAssembleCheckoutJoinsFileChunks(t *testing.T) {
+	chunks := []*slicev1.CheckoutChunk{
+		{Chunk: &slicev1.CheckoutChunk_Manifest{Manifest: &slicev1.SliceManifest{CommitHash: "abc"}}},
+		{Chunk: &slicev1.CheckoutChunk_File{File: &slicev1.FileContent{FileId: "big.bin", Content: []byte("hello ")}}},
+		{Chunk: &slicev1.CheckoutChunk_File{File: &slicev1.FileContent{FileId: "big.bin", Content: []byte("world")}}},
+		{Chunk: &slicev1.CheckoutChunk_File{File: &slicev1.FileContent{FileId: "small.txt", Content: []byte("x")}}},
+	}
+
+	next := 0
+	recv := func() (*slicev1.CheckoutChunk, error) {
+		if next == len(chunks) {
+			return nil, io.EOF
+		}
+		next++
+		return chunks[next-1], nil
+	}
+
+	resp, err := assembleCheckout(recv)
+	if err != nil {
+		t.Fatalf("assembleCheckout returned error: %v", err)
+	}
+	if resp.Manifest.CommitHash != "abc" {
+		t.Fatalf("unexpected manifest: %+v", resp.Manifest)
+	}
+	if len(resp.Files) != 2 || string(resp.Files[0].Content) != "hello world" || string(resp.Files[1].Content) != "x" {
+		t.Fatalf("unexpected files: %+v", resp.Files)
+	}
+}
+
+func TestAssembleCheckoutRequiresManifest(t *testing.T) {
+	recv := func() (*slicev1.CheckoutChunk, error) { return nil, io.EOF }
+	if _, err := assembleCheckout(recv); err == nil {
+		t.Fatalf("expected error for stream without manifest")
+	}
+}
+
+// checkoutClient serves a fixed manifest and records which checkout RPCs the
+// CLI used.
+type checkoutClient struct {
+	slicev1.SliceServiceClient
+	manifest *slicev1.SliceManifest
+	calls    []string
+	requests []*slicev1.CheckoutRequest
+}
+
+func (c *checkoutClient) CheckoutSlice(ctx context.Context, req *slicev1.CheckoutRequest, opts ...grpc.CallOption) (*slicev1.CheckoutResponse, error) {
+	c.requests = append(c.requests, req)
+	call := "unary"
+	if req.ManifestOnly {
+		call = "manifest"
+	}
+	c.calls = append(c.calls, call)
+	return &slicev1.CheckoutResponse{Manifest: c.manifest}, nil
+}
+
+func (c *checkoutClient) StreamCheckoutSlice(ctx context.Context, req *slicev1.CheckoutRequest, opts ...grpc.CallOption) (slicev1.SliceService_StreamCheckoutSliceClient, error) {
+	c.requests = append(c.requests, req)
+	c.calls = append(c.calls, "stream")
+	return &manifestStream{manifest: c.manifest}, nil
+}
+
+type manifestStream struct {
+	grpc.ClientStream
+	manifest *slicev1.SliceManifest
+	sent     bool
+}
+
+func (s *manifestStream) Recv() (*slicev1.CheckoutChunk, error) {
+	if s.sent {
+		return nil, io.EOF
+	}
+	s.sent = true
+	return &slicev1.CheckoutChunk{Chunk: &slicev1.CheckoutChunk_Manifest{Manifest: s.manifest}}, nil
+}
+
+func TestFetchCheckoutPicksTransportFromManifestSize(t *testing.T) {
+	ctx := context.Background()
+	cases := []struct {
+		name  string
+		size  int64
+		force bool
+		want  string
+	}{
+		{"small checkout", 1 << 10, false, "manifest,unary"},
+		{"large checkout", streamCheckoutThreshold + 1, false, "manifest,stream"},
+		{"forced stream", 1 << 10, true, "stream"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &checkoutClient{manifest: &slicev1.SliceManifest{
+				CommitHash:   "abc123",
+				FileMetadata: []*slicev1.FileMetadata{{FileId: "a", Path: "a", Size: tc.size}},
+			}}
+			if _, err := fetchCheckout(ctx, client, &slicev1.CheckoutRequest{SliceId: "s", CommitHash: "HEAD"}, tc.force); err != nil {
+				t.Fatalf("fetchCheckout returned error: %v", err)
+			}
+			if got := strings.Join(client.calls, ","); got != tc.want {
+				t.Fatalf("expected calls %s, got %s", tc.want, got)
+			}
+			// The contents come from the commit the manifest described
+			if last := client.requests[len(client.requests)-1]; !tc.force && last.CommitHash != "abc123" {
+				t.Fatalf("expected the follow-up request to pin commit abc123, got %q", last.CommitHash)
+			}
+		})
+	}
+}
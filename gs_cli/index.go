@@ -0,0 +1,207 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/niczy/gitslice/internal/objects"
+)
+
+// WorkingIndex records the files written by the last checkout so the working
+// directory can be compared against it. It is stored as JSON in .gs/index.
+type WorkingIndex struct {
+	SliceID    string       `json:"slice_id"`
+	CommitHash string       `json:"commit_hash"`
+	Entries    []IndexEntry `json:"entries"`
+}
+
+// IndexEntry describes one checked-out file. Size and mtime let status skip
+// rehashing files that have not been touched.
+type IndexEntry struct {
+	Path    string `json:"path"`
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"mtime"`
+	Hash    string `json:"hash"`
+}
+
+// WorkingChanges lists working-directory paths that differ from the index.
+type WorkingChanges struct {
+	Added    []string
+	Modified []string
+	Deleted  []string
+}
+
+func indexPath(root string) string {
+	return filepath.Join(root, ".gs", "index")
+}
+
+// loadIndex reads .gs/index. A missing index yields an empty one, so every
+// file in a freshly initialized directory shows up as added.
+func loadIndex(root string) (*WorkingIndex, error) {
+	data, err := os.ReadFile(indexPath(root))
+	if errors.Is(err, os.ErrNotExist) {
+		return &WorkingIndex{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var idx WorkingIndex
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, err
+	}
+	return &idx, nil
+}
+
+func writeIndex(root string, idx *WorkingIndex) error {
+	sort.Slice(idx.Entries, func(i, j int) bool { return idx.Entries[i].Path < idx.Entries[j].Path })
+
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(root, ".gs"), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(indexPath(root), data, 0o644)
+}
+
+// newIndexEntry stats a checked-out file and records it with its blob hash.
+func newIndexEntry(root, path string, content []byte) (IndexEntry, error) {
+	info, err := os.Stat(filepath.Join(root, filepath.FromSlash(path)))
+	if err != nil {
+		return IndexEntry{}, err
+	}
+	return IndexEntry{
+		Path:    path,
+		Size:    info.Size(),
+		ModTime: info.ModTime().UnixNano(),
+		Hash:    objects.HashBlob(content),
+	}, nil
+}
+
+// detectChanges walks the working directory, skipping .gs, and compares each
+// file against the index.
+func detectChanges(root string, idx *WorkingIndex) (*WorkingChanges, error) {
+	indexed := make(map[string]IndexEntry, len(idx.Entries))
+	for _, entry := range idx.Entries {
+		indexed[entry.Path] = entry
+	}
+
+	changes := &WorkingChanges{}
+	seen := make(map[string]bool)
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".gs" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		seen[rel] = true
+
+		entry, ok := indexed[rel]
+		if !ok {
+			changes.Added = append(changes.Added, rel)
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.Size() == entry.Size && info.ModTime().UnixNano() == entry.ModTime {
+			return nil
+		}
+
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		if objects.HashBlob(data) != entry.Hash {
+			changes.Modified = append(changes.Modified, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range idx.Entries {
+		if !seen[entry.Path] {
+			changes.Deleted = append(changes.Deleted, entry.Path)
+		}
+	}
+
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Modified)
+	sort.Strings(changes.Deleted)
+	return changes, nil
+}
+
+// Clean reports whether the working directory matches the index.
+func (c *WorkingChanges) Clean() bool {
+	return len(c.Added) == 0 && len(c.Modified) == 0 && len(c.Deleted) == 0
+}
+
+// Paths returns every changed path in sorted order.
+func (c *WorkingChanges) Paths() []string {
+	paths := make([]string, 0, len(c.Added)+len(c.Modified)+len(c.Deleted))
+	paths = append(paths, c.Added...)
+	paths = append(paths, c.Modified...)
+	paths = append(paths, c.Deleted...)
+	sort.Strings(paths)
+	return paths
+}
+
+// removeStale deletes files recorded in idx that are not in keep, pruning any
+// directories this leaves empty. Files edited since they were indexed are
+// left in place and returned as skipped so local work is never discarded.
+func removeStale(root string, idx *WorkingIndex, keep map[string]bool) (removed, skipped []string, err error) {
+	for _, entry := range idx.Entries {
+		if keep[entry.Path] {
+			continue
+		}
+		full := filepath.Join(root, filepath.FromSlash(entry.Path))
+		data, err := os.ReadFile(full)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return removed, skipped, err
+		}
+		if objects.HashBlob(data) != entry.Hash {
+			skipped = append(skipped, entry.Path)
+			continue
+		}
+		if err := os.Remove(full); err != nil {
+			return removed, skipped, err
+		}
+		removed = append(removed, entry.Path)
+
+		// Removing a non-empty directory fails, which ends the walk upwards
+		for dir := filepath.Dir(full); dir != filepath.Clean(root); dir = filepath.Dir(dir) {
+			if os.Remove(dir) != nil {
+				break
+			}
+		}
+	}
+	sort.Strings(removed)
+	sort.Strings(skipped)
+	return removed, skipped, nil
+}
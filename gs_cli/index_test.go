@@ -0,0 +1,117 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectChangesAgainstIndex(t *testing.T) {
+	root := t.TempDir()
+	write := func(path, content string) {
+		t.Helper()
+		full := filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+
+	write("keep.txt", "keep")
+	write("src/edit.go", "v1")
+	write("gone.txt", "gone")
+
+	index := &WorkingIndex{SliceID: "slice-1", CommitHash: "abc"}
+	for path, content := range map[string]string{"keep.txt": "keep", "src/edit.go": "v1", "gone.txt": "gone"} {
+		entry, err := newIndexEntry(root, path, []byte(content))
+		if err != nil {
+			t.Fatalf("newIndexEntry failed: %v", err)
+		}
+		index.Entries = append(index.Entries, entry)
+	}
+	if err := writeIndex(root, index); err != nil {
+		t.Fatalf("writeIndex failed: %v", err)
+	}
+
+	loaded, err := loadIndex(root)
+	if err != nil {
+		t.Fatalf("loadIndex failed: %v", err)
+	}
+	changes, err := detectChanges(root, loaded)
+	if err != nil {
+		t.Fatalf("detectChanges failed: %v", err)
+	}
+	if !changes.Clean() {
+		t.Fatalf("expected clean working directory, got %+v", changes)
+	}
+
+	write("src/edit.go", "v2")
+	write("new/file.txt", "new")
+	if err := os.Remove(filepath.Join(root, "gone.txt")); err != nil {
+		t.Fatalf("failed to remove file: %v", err)
+	}
+
+	changes, err = detectChanges(root, loaded)
+	if err != nil {
+		t.Fatalf("detectChanges failed: %v", err)
+	}
+	if len(changes.Added) != 1 || changes.Added[0] != "new/file.txt" {
+		t.Fatalf("unexpected added files: %v", changes.Added)
+	}
+	if len(changes.Modified) != 1 || changes.Modified[0] != "src/edit.go" {
+		t.Fatalf("unexpected modified files: %v", changes.Modified)
+	}
+	if len(changes.Deleted) != 1 || changes.Deleted[0] != "gone.txt" {
+		t.Fatalf("unexpected deleted files: %v", changes.Deleted)
+	}
+}
+
+func TestLoadIndexMissingIsEmpty(t *testing.T) {
+	idx, err := loadIndex(t.TempDir())
+	if err != nil || len(idx.Entries) != 0 {
+		t.Fatalf("expected empty index, got %+v (%v)", idx, err)
+	}
+}
+
+func TestRemoveStaleKeepsLocalEdits(t *testing.T) {
+	root := t.TempDir()
+	index := &WorkingIndex{}
+	for path, content := range map[string]string{"keep.txt": "keep", "old/gone.txt": "gone", "edited.txt": "v1"} {
+		full := filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+		entry, err := newIndexEntry(root, path, []byte(content))
+		if err != nil {
+			t.Fatalf("newIndexEntry failed: %v", err)
+		}
+		index.Entries = append(index.Entries, entry)
+	}
+	if err := os.WriteFile(filepath.Join(root, "edited.txt"), []byte("v2"), 0o644); err != nil {
+		t.Fatalf("failed to edit file: %v", err)
+	}
+
+	removed, skipped, err := removeStale(root, index, map[string]bool{"keep.txt": true})
+	if err != nil {
+		t.Fatalf("removeStale failed: %v", err)
+	}
+	if len(removed) != 1 || removed[0] != "old/gone.txt" {
+		t.Fatalf("unexpected removed files: %v", removed)
+	}
+	if len(skipped) != 1 || skipped[0] != "edited.txt" {
+		t.Fatalf("unexpected skipped files: %v", skipped)
+	}
+	if _, err := os.Stat(filepath.Join(root, "old")); !os.IsNotExist(err) {
+		t.Fatalf("expected empty directory to be pruned, stat returned: %v", err)
+	}
+	for _, path := range []string{"keep.txt", "edited.txt"} {
+		if _, err := os.Stat(filepath.Join(root, path)); err != nil {
+			t.Fatalf("expected %s to remain: %v", path, err)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -15,7 +16,9 @@ import (
 	adminv1 "github.com/niczy/gitslice/proto/admin"
 	slicev1 "github.com/niczy/gitslice/proto/slice"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -136,6 +139,18 @@ func handleSliceCommand(ctx context.Context, cli *CLI, args []string) {
 		handleSliceOwners(ctx, cli, args[1:])
 	case "checkout":
 		handleSliceCheckout(ctx, cli, args[1:])
+	case "tree":
+		handleSliceTree(ctx, cli, args[1:])
+	case "reparent":
+		handleSliceReparent(ctx, cli, args[1:])
+	case "fold":
+		handleSliceFold(ctx, cli, args[1:])
+	case "update":
+		handleSliceUpdate(ctx, cli, args[1:])
+	case "archive":
+		handleSliceArchive(ctx, cli, args[1:])
+	case "delete":
+		handleSliceDelete(ctx, cli, args[1:])
 	default:
 		log.Printf("Unknown slice command: %s", args[0])
 		printSliceHelp()
@@ -144,7 +159,7 @@ func handleSliceCommand(ctx context.Context, cli *CLI, args []string) {
 
 func handleSliceCreate(ctx context.Context, cli *CLI, args []string) {
 	if len(args) < 1 {
-		log.Println("Usage: gs slice create <slice-id> [--files \"file1,file2\"] [--description \"desc\"]")
+		log.Println("Usage: gs slice create <slice-id> [--files \"file1,file2\"] [--description \"desc\"] [--required-approvals N] [--owners-only] [--required-checks \"ci,lint\"] [--include \"services/payments/,**/*.proto\"] [--exclude \"**/*_test.go\"]")
 		return
 	}
 
@@ -154,6 +169,11 @@ func handleSliceCreate(ctx context.Context, cli *CLI, args []string) {
 	fs := flag.NewFlagSet("slice create", flag.ExitOnError)
 	files := fs.String("files", "", "Comma-separated list of files")
 	description := fs.String("description", "", "Slice description")
+	requiredApprovals := fs.Int("required-approvals", 0, "Approvals a changeset needs before it can merge")
+	ownersOnly := fs.Bool("owners-only", false, "Only count approvals from slice owners")
+	requiredChecks := fs.String("required-checks", "", "Comma-separated status checks that must succeed before merging")
+	include := fs.String("include", "", "Comma-separated glob patterns or directory prefixes the slice covers")
+	exclude := fs.String("exclude", "", "Comma-separated glob patterns or directory prefixes left out of the slice")
 	fs.Parse(args[1:])
 
 	// Build file list
@@ -166,14 +186,23 @@ func handleSliceCreate(ctx context.Context, cli *CLI, args []string) {
 		}
 	}
 
+	checkList := splitList(*requiredChecks)
+	includeList := splitList(*include)
+	excludeList := splitList(*exclude)
+
 	// Create slice via admin service
 	req := &adminv1.CreateSliceRequest{
-		SliceId:     sliceID,
-		Name:        sliceID,
-		Description: *description,
-		Files:       fileList,
-		Owners:      []string{"user"}, // TODO: Get from auth context
-		CreatedBy:   "user",           // TODO: Get from auth context
+		SliceId:            sliceID,
+		Name:               sliceID,
+		Description:        *description,
+		Files:              fileList,
+		Owners:             []string{"user"}, // TODO: Get from auth context
+		CreatedBy:          "user",           // TODO: Get from auth context
+		RequiredApprovals:  int32(*requiredApprovals),
+		OwnerApprovalsOnly: *ownersOnly,
+		RequiredChecks:     checkList,
+		IncludePatterns:    includeList,
+		ExcludePatterns:    excludeList,
 	}
 
 	resp, err := cli.adminClient.CreateSlice(ctx, req)
@@ -186,6 +215,30 @@ func handleSliceCreate(ctx context.Context, cli *CLI, args []string) {
 	if len(fileList) > 0 {
 		fmt.Printf("Files: %d\n", len(fileList))
 	}
+	if len(checkList) > 0 {
+		fmt.Printf("Required checks: %s\n", strings.Join(checkList, ", "))
+	}
+	if len(includeList) > 0 {
+		fmt.Printf("Include: %s\n", strings.Join(includeList, ", "))
+	}
+	if len(excludeList) > 0 {
+		fmt.Printf("Exclude: %s\n", strings.Join(excludeList, ", "))
+	}
+	for _, overlap := range resp.Overlaps {
+		fmt.Printf("Overlaps slice %s: %s with %s\n", overlap.SliceId, overlap.Pattern, overlap.OtherPattern)
+	}
+}
+
+// splitList splits a comma-separated flag value, trimming each item.
+func splitList(value string) []string {
+	var items []string
+	if value == "" {
+		return items
+	}
+	for _, item := range strings.Split(value, ",") {
+		items = append(items, strings.TrimSpace(item))
+	}
+	return items
 }
 
 func handleSliceList(ctx context.Context, cli *CLI, args []string) {
@@ -218,7 +271,11 @@ func handleSliceList(ctx context.Context, cli *CLI, args []string) {
 
 	fmt.Printf("\nFound %d slice(s):\n", len(resp.Slices))
 	for _, slice := range resp.Slices {
-		fmt.Printf("- %s (commit: %s, files: %d)\n", slice.SliceId, slice.LatestCommitHash, slice.ModifiedFilesCount)
+		archived := ""
+		if slice.Archived {
+			archived = " [archived]"
+		}
+		fmt.Printf("- %s (commit: %s, files: %d)%s\n", slice.SliceId, slice.LatestCommitHash, slice.ModifiedFilesCount, archived)
 		if *detailed {
 			fmt.Printf("  Last modified: %s\n", time.Unix(slice.LastModified, 0).Format(time.RFC3339))
 		}
@@ -281,9 +338,172 @@ func handleSliceOwners(ctx context.Context, cli *CLI, args []string) {
 	log.Printf("Owners for slice %s: not implemented yet", sliceID)
 }
 
+func handleSliceTree(ctx context.Context, cli *CLI, args []string) {
+	var sliceID string
+	if len(args) > 0 {
+		sliceID = args[0]
+	} else {
+		root, err := cli.sliceClient.GetRootSlice(ctx, &slicev1.GetRootSliceRequest{})
+		if err != nil {
+			log.Fatalf("Failed to get root slice: %v", err)
+		}
+		sliceID = root.SliceId
+	}
+
+	ancestry, err := cli.sliceClient.GetSliceAncestry(ctx, &slicev1.GetSliceAncestryRequest{SliceId: sliceID})
+	if err != nil {
+		log.Fatalf("Failed to get slice ancestry: %v", err)
+	}
+	children, err := cli.sliceClient.ListSliceChildren(ctx, &slicev1.ListSliceChildrenRequest{SliceId: sliceID, Recursive: true})
+	if err != nil {
+		log.Fatalf("Failed to list child slices: %v", err)
+	}
+
+	if len(ancestry.Ancestors) > 0 {
+		path := sliceID
+		for _, ancestor := range ancestry.Ancestors {
+			path = ancestor.SliceId + " / " + path
+		}
+		fmt.Printf("Path: %s\n", path)
+	}
+	renderSliceTree(os.Stdout, sliceID, children.Children)
+}
+
+func handleSliceReparent(ctx context.Context, cli *CLI, args []string) {
+	if len(args) < 2 {
+		log.Println("Usage: gs slice reparent <slice-id> <parent-slice-id>")
+		return
+	}
+
+	resp, err := cli.sliceClient.ReparentSlice(ctx, &slicev1.ReparentSliceRequest{SliceId: args[0], ParentSliceId: args[1]})
+	if err != nil {
+		log.Fatalf("Failed to reparent slice: %v", err)
+	}
+
+	fmt.Printf("Moved slice %s from %s to %s\n", resp.SliceId, resp.PreviousParentSliceId, resp.ParentSliceId)
+}
+
+func handleSliceFold(ctx context.Context, cli *CLI, args []string) {
+	if len(args) < 1 {
+		log.Println("Usage: gs slice fold <slice-id> [--author <name>]")
+		return
+	}
+
+	sliceID := args[0]
+
+	fs := flag.NewFlagSet("fold", flag.ExitOnError)
+	author := fs.String("author", "user", "Who is folding the slice")
+	fs.Parse(args[1:])
+
+	resp, err := cli.sliceClient.FoldSlice(ctx, &slicev1.FoldSliceRequest{SliceId: sliceID, Author: *author})
+	if err != nil {
+		log.Fatalf("Failed to fold slice: %v", err)
+	}
+
+	fmt.Printf("Folded slice %s into %s\n", sliceID, resp.ParentSliceId)
+	fmt.Printf("Commit: %s\n", resp.CommitHash)
+	fmt.Printf("Commits folded: %d\n", resp.CommitsFolded)
+	fmt.Printf("Files moved to %s: %d\n", resp.ParentSliceId, len(resp.Files))
+	for _, file := range resp.Files {
+		fmt.Printf("  %s\n", file)
+	}
+	for _, child := range resp.ReparentedSlices {
+		fmt.Printf("Moved slice %s under %s\n", child, resp.ParentSliceId)
+	}
+}
+
+func handleSliceUpdate(ctx context.Context, cli *CLI, args []string) {
+	if len(args) < 1 {
+		log.Println("Usage: gs slice update <slice-id> [--name <name>] [--description \"desc\"] [--add-files \"file1,file2\"] [--remove-files \"file1\"] [--add-owners \"alice\"] [--remove-owners \"bob\"]")
+		return
+	}
+
+	sliceID := args[0]
+
+	fs := flag.NewFlagSet("slice update", flag.ExitOnError)
+	name := fs.String("name", "", "New name for the slice")
+	description := fs.String("description", "", "New description for the slice")
+	addFiles := fs.String("add-files", "", "Comma-separated files to add")
+	removeFiles := fs.String("remove-files", "", "Comma-separated files to hand back to the parent slice")
+	addOwners := fs.String("add-owners", "", "Comma-separated owners to add")
+	removeOwners := fs.String("remove-owners", "", "Comma-separated owners to remove")
+	fs.Parse(args[1:])
+
+	req := &adminv1.UpdateSliceRequest{
+		SliceId:      sliceID,
+		AddFiles:     splitList(*addFiles),
+		RemoveFiles:  splitList(*removeFiles),
+		AddOwners:    splitList(*addOwners),
+		RemoveOwners: splitList(*removeOwners),
+	}
+	// Only flags given on the command line change the name or description
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "name":
+			req.Name = name
+		case "description":
+			req.Description = description
+		}
+	})
+
+	resp, err := cli.adminClient.UpdateSlice(ctx, req)
+	if err != nil {
+		log.Fatalf("Failed to update slice: %v", err)
+	}
+
+	fmt.Printf("Updated slice: %s\n", resp.SliceId)
+	fmt.Printf("Name: %s\n", resp.Name)
+	fmt.Printf("Description: %s\n", resp.Description)
+	fmt.Printf("Files: %s\n", strings.Join(resp.Files, ", "))
+	fmt.Printf("Owners: %s\n", strings.Join(resp.Owners, ", "))
+	if resp.HeirSliceId != "" {
+		fmt.Printf("Removed files passed to %s\n", resp.HeirSliceId)
+	}
+}
+
+func handleSliceArchive(ctx context.Context, cli *CLI, args []string) {
+	if len(args) < 1 {
+		log.Println("Usage: gs slice archive <slice-id>")
+		return
+	}
+
+	resp, err := cli.adminClient.ArchiveSlice(ctx, &adminv1.ArchiveSliceRequest{SliceId: args[0]})
+	if err != nil {
+		log.Fatalf("Failed to archive slice: %v", err)
+	}
+
+	fmt.Printf("Archived slice: %s\n", resp.SliceId)
+	printHandoff(resp.HeirSliceId, resp.ReassignedFiles, resp.ReparentedSlices)
+}
+
+func handleSliceDelete(ctx context.Context, cli *CLI, args []string) {
+	if len(args) < 1 {
+		log.Println("Usage: gs slice delete <slice-id>")
+		return
+	}
+
+	resp, err := cli.adminClient.DeleteSlice(ctx, &adminv1.DeleteSliceRequest{SliceId: args[0]})
+	if err != nil {
+		log.Fatalf("Failed to delete slice: %v", err)
+	}
+
+	fmt.Printf("Deleted slice: %s\n", resp.SliceId)
+	printHandoff(resp.HeirSliceId, resp.ReassignedFiles, resp.ReparentedSlices)
+}
+
+func printHandoff(heir string, files, children []string) {
+	fmt.Printf("Files reassigned to %s: %d\n", heir, len(files))
+	for _, file := range files {
+		fmt.Printf("  %s\n", file)
+	}
+	for _, child := range children {
+		fmt.Printf("Moved slice %s under %s\n", child, heir)
+	}
+}
+
 func handleSliceCheckout(ctx context.Context, cli *CLI, args []string) {
 	if len(args) < 1 {
-		log.Println("Usage: gs slice checkout <slice-id> [--commit <commit-hash>]")
+		log.Println("Usage: gs slice checkout <slice-id> [--commit <commit-hash>] [--stream]")
 		return
 	}
 
@@ -292,15 +512,16 @@ func handleSliceCheckout(ctx context.Context, cli *CLI, args []string) {
 	// Parse flags
 	fs := flag.NewFlagSet("slice checkout", flag.ExitOnError)
 	commitHash := fs.String("commit", "HEAD", "Commit hash to checkout")
+	stream := fs.Bool("stream", false, "Always use the streaming checkout")
 	fs.Parse(args[1:])
 
-	// Call slice service
+	// Call slice service; large slices are fetched over the streaming RPC
 	req := &slicev1.CheckoutRequest{
 		SliceId:    sliceID,
 		CommitHash: *commitHash,
 	}
 
-	resp, err := cli.sliceClient.CheckoutSlice(ctx, req)
+	resp, err := fetchCheckout(ctx, cli.sliceClient, req, *stream)
 	if err != nil {
 		log.Fatalf("Failed to checkout slice: %v", err)
 	}
@@ -310,11 +531,19 @@ func handleSliceCheckout(ctx context.Context, cli *CLI, args []string) {
 		log.Printf("Warning: unable to initialize cache: %v", err)
 	}
 
+	previous, err := loadIndex(".")
+	if err != nil {
+		log.Printf("Warning: unable to read .gs/index: %v", err)
+		previous = &WorkingIndex{}
+	}
+
 	fileContents := make(map[string][]byte)
 	for _, file := range resp.Files {
 		fileContents[file.FileId] = file.Content
 	}
 
+	index := &WorkingIndex{SliceID: sliceID, CommitHash: resp.Manifest.CommitHash}
+
 	var cachedHits int
 	for _, fm := range resp.Manifest.FileMetadata {
 		var content []byte
@@ -349,14 +578,43 @@ func handleSliceCheckout(ctx context.Context, cli *CLI, args []string) {
 
 			if err := os.WriteFile(targetPath, content, 0o644); err != nil {
 				log.Printf("Failed to write file %s: %v", fm.Path, err)
+				continue
 			}
+
+			entry, err := newIndexEntry(".", filepath.ToSlash(filepath.Clean(fm.Path)), content)
+			if err != nil {
+				log.Printf("Failed to index %s: %v", fm.Path, err)
+				continue
+			}
+			index.Entries = append(index.Entries, entry)
 		}
 	}
 
+	// Files from the previous checkout that are not in this commit go away
+	inCommit := make(map[string]bool, len(resp.Manifest.FileMetadata))
+	for _, fm := range resp.Manifest.FileMetadata {
+		inCommit[filepath.ToSlash(filepath.Clean(fm.Path))] = true
+	}
+	removed, skipped, err := removeStale(".", previous, inCommit)
+	if err != nil {
+		log.Printf("Failed to remove files from the previous checkout: %v", err)
+	}
+	for _, path := range skipped {
+		log.Printf("Warning: keeping %s, which has local changes and is not in this commit", path)
+	}
+
+	// Record what was checked out so status can detect local modifications
+	if err := writeIndex(".", index); err != nil {
+		log.Printf("Failed to write .gs/index: %v", err)
+	}
+
 	// Display checkout results
 	fmt.Printf("Checked out slice: %s\n", sliceID)
 	fmt.Printf("Commit: %s\n", resp.Manifest.CommitHash)
 	fmt.Printf("Files: %d\n", len(resp.Files))
+	if len(removed) > 0 {
+		fmt.Printf("Removed: %d\n", len(removed))
+	}
 
 	if len(resp.Manifest.FileMetadata) > 0 {
 		fmt.Println("\nFiles in slice:")
@@ -385,6 +643,18 @@ func handleChangesetCommand(ctx context.Context, cli *CLI, args []string) {
 		handleChangesetMerge(ctx, cli, args[1:])
 	case "rebase":
 		handleChangesetRebase(ctx, cli, args[1:])
+	case "enqueue":
+		handleChangesetEnqueue(ctx, cli, args[1:])
+	case "approve":
+		handleChangesetVote(ctx, cli, args[1:], true)
+	case "reject":
+		handleChangesetVote(ctx, cli, args[1:], false)
+	case "abandon":
+		handleChangesetAbandon(ctx, cli, args[1:])
+	case "check":
+		handleChangesetCheck(ctx, cli, args[1:])
+	case "comment":
+		handleChangesetComment(ctx, cli, args[1:])
 	case "list":
 		handleChangesetList(ctx, cli, args[1:])
 	default:
@@ -405,6 +675,8 @@ func handleChangesetCreate(ctx context.Context, cli *CLI, args []string) {
 	base := fs.String("base", "", "Base commit hash")
 	files := fs.String("files", "", "Comma-separated file list")
 	author := fs.String("author", "user", "Author of the changeset")
+	stream := fs.Bool("stream", false, "Always upload over the streaming RPC")
+	parent := fs.String("parent", "", "Stack the changeset on another open changeset")
 	fs.Parse(args)
 
 	modifiedFiles := []string{}
@@ -415,29 +687,73 @@ func handleChangesetCreate(ctx context.Context, cli *CLI, args []string) {
 	}
 	modifiedFiles = append(modifiedFiles, fs.Args()...)
 
-	req := &slicev1.CreateChangesetRequest{
-		SliceId:        sliceID,
-		BaseCommitHash: *base,
-		ModifiedFiles:  modifiedFiles,
-		Author:         *author,
-		Message:        *message,
+	index, err := loadIndex(".")
+	if err != nil {
+		log.Fatalf("Failed to read .gs/index: %v", err)
+	}
+	// Default the base to the commit the working directory was checked out at
+	if *base == "" {
+		*base = index.CommitHash
+	}
+
+	// Without explicit files, pick up everything that differs from .gs/index
+	if len(modifiedFiles) == 0 {
+		changes, err := detectChanges(".", index)
+		if err != nil {
+			log.Fatalf("Failed to scan working directory: %v", err)
+		}
+		if changes.Clean() {
+			log.Println("No local changes to include in a changeset")
+			return
+		}
+		modifiedFiles = changes.Paths()
+	}
+
+	// Hash local file contents and only upload blobs the server is missing
+	upload, err := collectChangeset(".", modifiedFiles)
+	if err != nil {
+		log.Fatalf("Failed to read modified files: %v", err)
+	}
+	skipped, err := upload.skipKnownBlobs(ctx, cli.sliceClient)
+	if err != nil {
+		log.Fatalf("Failed to check existing objects: %v", err)
+	}
+
+	meta := &slicev1.ChangesetMetadata{
+		SliceId:           sliceID,
+		BaseCommitHash:    *base,
+		Author:            *author,
+		Message:           *message,
+		ParentChangesetId: *parent,
 	}
 
-	resp, err := cli.sliceClient.CreateChangeset(ctx, req)
+	resp, err := upload.send(ctx, cli.sliceClient, meta, *stream)
 	if err != nil {
 		log.Fatalf("Failed to create changeset: %v", err)
 	}
 
 	fmt.Printf("Created changeset %s (hash: %s)\n", resp.ChangesetId, resp.ChangesetHash)
+	fmt.Printf("Uploaded %d objects (%d blobs already on server)\n", len(upload.blobs)+len(upload.trees), skipped)
 	fmt.Printf("Status: %s\n", resp.Status.String())
 }
 
 func handleChangesetReview(ctx context.Context, cli *CLI, args []string) {
 	if len(args) < 1 {
-		log.Println("Usage: gs changeset review <changeset-id>")
+		log.Println("Usage: gs changeset review <changeset-id> [--diff] [--stat] [--side-by-side] [--external]")
 		return
 	}
 
+	fs := flag.NewFlagSet("changeset review", flag.ExitOnError)
+	showDiff := fs.Bool("diff", false, "Show a unified diff")
+	showStat := fs.Bool("stat", false, "Show a per-file diffstat")
+	sideBySide := fs.Bool("side-by-side", false, "Show the diff in two columns")
+	width := fs.Int("width", 160, "Total width for --side-by-side output")
+	contextLines := fs.Int("context", 3, "Unchanged lines shown around each change; 0 shows only the changes")
+	colorMode := fs.String("color", "auto", "Colorize output: auto, always or never")
+	external := fs.Bool("external", false, "Pipe the diff to an external difftool")
+	tool := fs.String("tool", "", "Difftool command for --external (defaults to $"+diffToolEnv+")")
+	fs.Parse(args[1:])
+
 	req := &slicev1.ReviewChangesetRequest{ChangesetId: args[0]}
 	resp, err := cli.sliceClient.ReviewChangeset(ctx, req)
 	if err != nil {
@@ -448,6 +764,74 @@ func handleChangesetReview(ctx context.Context, cli *CLI, args []string) {
 	fmt.Printf("Status: %s\n", resp.ReviewStatus.String())
 	if resp.Diff != nil {
 		fmt.Printf("Files changed: %d\n", resp.Diff.FilesAdded+resp.Diff.FilesModified+resp.Diff.FilesDeleted)
+		fmt.Printf("Lines: +%d -%d\n", resp.Diff.LinesAdded, resp.Diff.LinesRemoved)
+	}
+	for _, vote := range resp.Changeset.GetVotes() {
+		printVote(vote)
+	}
+	for _, check := range resp.Changeset.GetChecks() {
+		printCheck(check)
+	}
+	for _, warning := range resp.Warnings {
+		fmt.Printf("Warning: %s\n", warning)
+	}
+
+	// Comments are extra context; the review still shows without them
+	var threads []*commentThread
+	if commentsResp, err := cli.sliceClient.ListComments(ctx, &slicev1.ListCommentsRequest{ChangesetId: args[0]}); err != nil {
+		log.Printf("Warning: unable to load comments: %v", err)
+	} else {
+		threads = groupThreads(commentsResp.Comments)
+	}
+
+	// Unified diffs show file comments inline; everything else is listed here
+	inline := *showDiff && !*sideBySide && !*external
+	var listed []*commentThread
+	for _, thread := range threads {
+		if !inline || thread.root.Path == "" {
+			listed = append(listed, thread)
+		}
+	}
+	if len(listed) > 0 {
+		fmt.Println("Comments:")
+		for _, thread := range listed {
+			fmt.Printf("  %s\n", threadLocation(thread))
+			writeThread(os.Stdout, "  ", thread, false)
+		}
+	}
+
+	if !*showDiff && !*showStat && !*sideBySide && !*external {
+		return
+	}
+
+	color, err := useColor(*colorMode)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	// Always send the flag: zero asks for hunks without context, not the default
+	contextCount := int32(*contextLines)
+	diffResp, err := cli.sliceClient.GetChangesetDiff(ctx, &slicev1.ChangesetDiffRequest{
+		ChangesetId:  args[0],
+		ContextLines: &contextCount,
+	})
+	if err != nil {
+		log.Fatalf("Failed to get changeset diff: %v", err)
+	}
+
+	fmt.Println()
+	if *showStat {
+		renderStat(os.Stdout, diffResp.Files, color)
+	}
+	switch {
+	case *external:
+		if err := runExternalDiff(*tool, diffResp.Files); err != nil {
+			log.Fatalf("External difftool failed: %v", err)
+		}
+	case *sideBySide:
+		renderSideBySide(os.Stdout, diffResp.Files, *width, color)
+	case *showDiff:
+		renderUnified(os.Stdout, diffResp.Files, threads, color)
 	}
 }
 
@@ -474,13 +858,258 @@ func handleChangesetMerge(ctx context.Context, cli *CLI, args []string) {
 	}
 }
 
+func handleChangesetEnqueue(ctx context.Context, cli *CLI, args []string) {
+	if len(args) < 1 {
+		log.Println("Usage: gs changeset enqueue <changeset-id> [--watch] [--author name]")
+		return
+	}
+
+	fs := flag.NewFlagSet("changeset enqueue", flag.ExitOnError)
+	watch := fs.Bool("watch", false, "Wait for the queued merge to finish")
+	author := fs.String("author", "user", "Who is queueing the merge")
+	fs.Parse(args[1:])
+
+	req := &slicev1.EnqueueMergeRequest{ChangesetId: args[0], RequestedBy: *author}
+	resp, err := cli.sliceClient.EnqueueMerge(ctx, req)
+	switch {
+	case status.Code(err) == codes.AlreadyExists && *watch:
+		fmt.Printf("Changeset %s is already queued\n", args[0])
+	case err != nil:
+		log.Fatalf("Failed to enqueue changeset: %v", err)
+	default:
+		fmt.Printf("Queued changeset %s for slice %s at position %d\n", resp.ChangesetId, resp.SliceId, resp.Position)
+	}
+	if !*watch {
+		return
+	}
+
+	// The merge may wait behind others, so watching outlives the command timeout
+	stream, err := cli.sliceClient.WatchMergeQueue(context.WithoutCancel(ctx), &slicev1.WatchMergeQueueRequest{ChangesetId: args[0]})
+	if err != nil {
+		log.Fatalf("Failed to watch merge queue: %v", err)
+	}
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			log.Fatalf("Failed to watch merge queue: %v", err)
+		}
+
+		switch event.State {
+		case slicev1.MergeQueueState_MERGE_QUEUE_STATE_QUEUED:
+			fmt.Printf("Position: %d\n", event.Position)
+		case slicev1.MergeQueueState_MERGE_QUEUE_STATE_MERGING:
+			fmt.Println("Merging...")
+		case slicev1.MergeQueueState_MERGE_QUEUE_STATE_MERGED:
+			fmt.Println("Merge status: MERGED")
+			if event.NewCommitHash != "" {
+				fmt.Printf("New commit: %s\n", event.NewCommitHash)
+			}
+		case slicev1.MergeQueueState_MERGE_QUEUE_STATE_FAILED:
+			if len(event.Conflicts) > 0 {
+				fmt.Println("Conflicts detected:")
+				for _, path := range event.Conflicts {
+					fmt.Printf("- %s\n", path)
+				}
+			}
+			log.Fatalf("Queued merge failed: %s", event.Message)
+		}
+	}
+}
+
+func handleChangesetVote(ctx context.Context, cli *CLI, args []string, approve bool) {
+	command := "reject"
+	if approve {
+		command = "approve"
+	}
+	if len(args) < 1 {
+		log.Printf("Usage: gs changeset %s <changeset-id> [--comment \"text\"] [--reviewer name]", command)
+		return
+	}
+
+	fs := flag.NewFlagSet("changeset "+command, flag.ExitOnError)
+	comment := fs.String("comment", "", "Review comment")
+	reviewer := fs.String("reviewer", "user", "Reviewer casting the vote")
+	fs.Parse(args[1:])
+
+	req := &slicev1.ChangesetVoteRequest{ChangesetId: args[0], Reviewer: *reviewer, Comment: *comment}
+	var resp *slicev1.ChangesetVoteResponse
+	var err error
+	if approve {
+		resp, err = cli.sliceClient.ApproveChangeset(ctx, req)
+	} else {
+		resp, err = cli.sliceClient.RejectChangeset(ctx, req)
+	}
+	if err != nil {
+		log.Fatalf("Failed to %s changeset: %v", command, err)
+	}
+
+	fmt.Printf("Changeset: %s\n", resp.Changeset.GetChangesetId())
+	fmt.Printf("Status: %s\n", resp.Changeset.GetStatus().String())
+	fmt.Printf("Approvals: %d of %d required\n", resp.Approvals, resp.RequiredApprovals)
+	for _, vote := range resp.Changeset.GetVotes() {
+		printVote(vote)
+	}
+}
+
+func printVote(vote *slicev1.ReviewVote) {
+	verdict := "rejected"
+	if vote.Approved {
+		verdict = "approved"
+	}
+	if vote.Comment != "" {
+		fmt.Printf("  %s %s: %s\n", vote.Reviewer, verdict, vote.Comment)
+		return
+	}
+	fmt.Printf("  %s %s\n", vote.Reviewer, verdict)
+}
+
+func handleChangesetAbandon(ctx context.Context, cli *CLI, args []string) {
+	if len(args) < 1 {
+		log.Println("Usage: gs changeset abandon <changeset-id> [--reason \"text\"]")
+		return
+	}
+
+	fs := flag.NewFlagSet("changeset abandon", flag.ExitOnError)
+	reason := fs.String("reason", "", "Why the changeset is being abandoned")
+	fs.Parse(args[1:])
+
+	req := &slicev1.AbandonChangesetRequest{ChangesetId: args[0], Reason: *reason}
+	resp, err := cli.sliceClient.AbandonChangeset(ctx, req)
+	if err != nil {
+		log.Fatalf("Failed to abandon changeset: %v", err)
+	}
+
+	fmt.Printf("Changeset: %s\n", resp.Changeset.GetChangesetId())
+	fmt.Printf("Status: %s\n", resp.Changeset.GetStatus().String())
+	if resp.Changeset.GetAbandonReason() != "" {
+		fmt.Printf("Reason: %s\n", resp.Changeset.GetAbandonReason())
+	}
+}
+
+func handleChangesetCheck(ctx context.Context, cli *CLI, args []string) {
+	if len(args) < 1 {
+		log.Println("Usage: gs changeset check <changeset-id> --name <check> --state pending|success|failure [--description \"text\"] [--url link]")
+		return
+	}
+
+	fs := flag.NewFlagSet("changeset check", flag.ExitOnError)
+	name := fs.String("name", "", "Name of the status check, such as ci")
+	stateName := fs.String("state", "", "Check state: pending, success or failure")
+	description := fs.String("description", "", "Short summary of the result")
+	url := fs.String("url", "", "Link to the check's details")
+	fs.Parse(args[1:])
+
+	var state slicev1.CheckState
+	switch strings.ToLower(*stateName) {
+	case "pending":
+		state = slicev1.CheckState_CHECK_STATE_PENDING
+	case "success":
+		state = slicev1.CheckState_CHECK_STATE_SUCCESS
+	case "failure":
+		state = slicev1.CheckState_CHECK_STATE_FAILURE
+	default:
+		log.Fatalf("Unknown check state %q: use pending, success or failure", *stateName)
+	}
+
+	resp, err := cli.sliceClient.SetChangesetCheck(ctx, &slicev1.SetChangesetCheckRequest{
+		ChangesetId: args[0],
+		Name:        *name,
+		State:       state,
+		Description: *description,
+		TargetUrl:   *url,
+	})
+	if err != nil {
+		log.Fatalf("Failed to set changeset check: %v", err)
+	}
+
+	fmt.Printf("Changeset: %s\n", resp.Changeset.GetChangesetId())
+	for _, check := range resp.Changeset.GetChecks() {
+		printCheck(check)
+	}
+	if len(resp.PendingRequiredChecks) > 0 {
+		fmt.Printf("Waiting on required checks: %s\n", strings.Join(resp.PendingRequiredChecks, ", "))
+	} else {
+		fmt.Println("All required checks passed")
+	}
+}
+
+func printCheck(check *slicev1.StatusCheck) {
+	state := strings.ToLower(strings.TrimPrefix(check.State.String(), "CHECK_STATE_"))
+	line := fmt.Sprintf("  check %s: %s", check.Name, state)
+	if check.Description != "" {
+		line += " - " + check.Description
+	}
+	if check.TargetUrl != "" {
+		line += " (" + check.TargetUrl + ")"
+	}
+	fmt.Println(line)
+}
+
+func handleChangesetComment(ctx context.Context, cli *CLI, args []string) {
+	if len(args) < 1 {
+		log.Println("Usage: gs changeset comment <changeset-id> --message \"text\" [--file path] [--line N] [--reply comment-id] [--author name]")
+		log.Println("       gs changeset comment <changeset-id> --resolve <comment-id> [--author name]")
+		return
+	}
+
+	fs := flag.NewFlagSet("changeset comment", flag.ExitOnError)
+	message := fs.String("message", "", "Comment text")
+	file := fs.String("file", "", "File the comment is about")
+	line := fs.Int("line", 0, "Line in the changed file the comment is about")
+	reply := fs.String("reply", "", "Comment to reply to")
+	resolve := fs.String("resolve", "", "Resolve the thread containing this comment")
+	author := fs.String("author", "user", "Author of the comment")
+	fs.Parse(args[1:])
+
+	switch {
+	case *resolve != "":
+		resp, err := cli.sliceClient.ResolveComment(ctx, &slicev1.ResolveCommentRequest{CommentId: *resolve, ResolvedBy: *author})
+		if err != nil {
+			log.Fatalf("Failed to resolve comment: %v", err)
+		}
+		fmt.Printf("Resolved comment %s\n", resp.Comment.CommentId)
+	case *message != "":
+		resp, err := cli.sliceClient.AddComment(ctx, &slicev1.AddCommentRequest{
+			ChangesetId: args[0],
+			Path:        *file,
+			Line:        int32(*line),
+			ParentId:    *reply,
+			Author:      *author,
+			Body:        *message,
+		})
+		if err != nil {
+			log.Fatalf("Failed to add comment: %v", err)
+		}
+		fmt.Printf("Added comment %s on %s\n", resp.Comment.CommentId, threadLocation(&commentThread{root: resp.Comment}))
+	default:
+		resp, err := cli.sliceClient.ListComments(ctx, &slicev1.ListCommentsRequest{ChangesetId: args[0]})
+		if err != nil {
+			log.Fatalf("Failed to list comments: %v", err)
+		}
+		threads := groupThreads(resp.Comments)
+		fmt.Printf("Found %d comment thread(s) on changeset %s\n", len(threads), args[0])
+		for _, thread := range threads {
+			fmt.Printf("  %s\n", threadLocation(thread))
+			writeThread(os.Stdout, "  ", thread, false)
+		}
+	}
+}
+
 func handleChangesetRebase(ctx context.Context, cli *CLI, args []string) {
 	if len(args) < 1 {
-		log.Println("Usage: gs changeset rebase <changeset-id>")
+		log.Println("Usage: gs changeset rebase <changeset-id> [--markers] [--restack]")
 		return
 	}
 
-	req := &slicev1.RebaseChangesetRequest{ChangesetId: args[0]}
+	fs := flag.NewFlagSet("changeset rebase", flag.ExitOnError)
+	markers := fs.Bool("markers", false, "Write conflicted files with conflict markers into the working directory")
+	restack := fs.Bool("restack", false, "Also rebase the changesets stacked on this one")
+	fs.Parse(args[1:])
+
+	req := &slicev1.RebaseChangesetRequest{ChangesetId: args[0], Restack: *restack}
 	resp, err := cli.sliceClient.RebaseChangeset(ctx, req)
 	if err != nil {
 		log.Fatalf("Failed to rebase changeset: %v", err)
@@ -488,6 +1117,41 @@ func handleChangesetRebase(ctx context.Context, cli *CLI, args []string) {
 
 	fmt.Printf("Rebase status: %s\n", resp.Status.String())
 	fmt.Printf("New base commit: %s\n", resp.NewBaseCommitHash)
+	fmt.Printf("Slice commits applied: %d\n", len(resp.SliceCommitsToApply))
+	for _, commit := range resp.SliceCommitsToApply {
+		fmt.Printf("  - %s\n", commit)
+	}
+	if len(resp.Conflicts) > 0 {
+		fmt.Println("Conflicts:")
+		for _, conflict := range resp.Conflicts {
+			fmt.Printf("  - %s\n", conflict.FileId)
+		}
+	}
+
+	switch {
+	case len(resp.Restacked) > 0:
+		fmt.Println("Restacked:")
+		for _, result := range resp.Restacked {
+			fmt.Printf("  - %s on %s: %s\n", result.ChangesetId, result.ParentChangesetId, result.Status.String())
+			for _, conflict := range result.Conflicts {
+				fmt.Printf("      conflict: %s\n", conflict)
+			}
+		}
+	case len(resp.StackedChangesets) > 0 && !*restack:
+		fmt.Printf("Stacked on %s: %s\n", args[0], strings.Join(resp.StackedChangesets, ", "))
+		fmt.Printf("Run 'gs changeset rebase %s --restack' to move them onto the new revision.\n", args[0])
+	}
+
+	if *markers && len(resp.ConflictedFiles) > 0 {
+		written, err := writeConflictMarkers(".", resp.ConflictedFiles)
+		if err != nil {
+			log.Fatalf("Failed to write conflict markers: %v", err)
+		}
+		fmt.Println("Wrote conflict markers to:")
+		for _, p := range written {
+			fmt.Printf("  - %s\n", p)
+		}
+	}
 }
 
 func handleChangesetList(ctx context.Context, cli *CLI, args []string) {
@@ -500,7 +1164,9 @@ func handleChangesetList(ctx context.Context, cli *CLI, args []string) {
 	fs := flag.NewFlagSet("changeset list", flag.ExitOnError)
 	limit := fs.Int("limit", 20, "Maximum results")
 	status := &stringFlag{}
-	fs.Var(status, "status", "Filter by status (pending, approved, rejected, merged)")
+	fs.Var(status, "status", "Filter by status (pending, approved, rejected, merged, abandoned)")
+	all := fs.Bool("all", false, "Include abandoned changesets")
+	stack := fs.Bool("stack", false, "Show stacked changesets as a tree")
 	fs.Parse(args)
 
 	statusFilter := slicev1.ChangesetStatus(-1)
@@ -514,6 +1180,8 @@ func handleChangesetList(ctx context.Context, cli *CLI, args []string) {
 			statusFilter = slicev1.ChangesetStatus_MERGED
 		case "pending":
 			statusFilter = slicev1.ChangesetStatus_PENDING
+		case "abandoned":
+			statusFilter = slicev1.ChangesetStatus_ABANDONED
 		default:
 			log.Printf("Unknown status filter: %s", status.value)
 			return
@@ -521,9 +1189,10 @@ func handleChangesetList(ctx context.Context, cli *CLI, args []string) {
 	}
 
 	req := &slicev1.ListChangesetsRequest{
-		SliceId:      sliceID,
-		StatusFilter: statusFilter,
-		Limit:        int32(*limit),
+		SliceId:          sliceID,
+		StatusFilter:     statusFilter,
+		Limit:            int32(*limit),
+		IncludeAbandoned: *all,
 	}
 
 	resp, err := cli.sliceClient.ListChangesets(ctx, req)
@@ -536,6 +1205,10 @@ func handleChangesetList(ctx context.Context, cli *CLI, args []string) {
 	})
 
 	fmt.Printf("Found %d changeset(s) for slice %s\n", len(resp.Changesets), sliceID)
+	if *stack {
+		renderStack(os.Stdout, resp.Changesets)
+		return
+	}
 	for _, cs := range resp.Changesets {
 		fmt.Printf("- %s [%s] %s\n", cs.ChangesetId, cs.Status.String(), cs.Message)
 	}
@@ -568,7 +1241,31 @@ func handleStatus(ctx context.Context, cli *CLI) {
 	fmt.Printf("Head: %s\n", resp.LatestCommitHash)
 	fmt.Printf("Modified files: %d\n", len(resp.ModifiedFiles))
 	fmt.Printf("Last modified: %s\n", time.Unix(resp.LastModified, 0).Format(time.RFC3339))
-	fmt.Printf("Working directory: Clean\n")
+
+	index, err := loadIndex(".")
+	if err != nil {
+		log.Fatalf("Failed to read .gs/index: %v", err)
+	}
+	changes, err := detectChanges(".", index)
+	if err != nil {
+		log.Fatalf("Failed to scan working directory: %v", err)
+	}
+
+	if changes.Clean() {
+		fmt.Printf("Working directory: Clean\n")
+		return
+	}
+
+	fmt.Printf("Working directory: %d changed files\n", len(changes.Paths()))
+	for _, path := range changes.Added {
+		fmt.Printf("  added:    %s\n", path)
+	}
+	for _, path := range changes.Modified {
+		fmt.Printf("  modified: %s\n", path)
+	}
+	for _, path := range changes.Deleted {
+		fmt.Printf("  deleted:  %s\n", path)
+	}
 }
 
 func handleInit(ctx context.Context, cli *CLI, args []string) {
@@ -766,7 +1463,7 @@ func handleRootSlice(ctx context.Context, cli *CLI) {
 
 func handleForkSlice(ctx context.Context, cli *CLI, args []string) {
 	if len(args) < 2 {
-		log.Println("Usage: gs fork <new-slice-id> <folder-path>")
+		log.Println("Usage: gs fork <new-slice-id> <folder-path> [--parent <slice-id>] [--name <name>] [--description <desc>] [--author <name>]")
 		return
 	}
 
@@ -777,6 +1474,7 @@ func handleForkSlice(ctx context.Context, cli *CLI, args []string) {
 	parentID := fs.String("parent", "", "Parent slice ID")
 	name := fs.String("name", newSliceID, "Name of the new slice")
 	description := fs.String("description", "Forked slice", "Description of the new slice")
+	author := fs.String("author", "user", "Who is creating the slice")
 	fs.Parse(args[2:])
 
 	parentSliceID := *parentID
@@ -796,6 +1494,7 @@ func handleForkSlice(ctx context.Context, cli *CLI, args []string) {
 		NewSliceId:    newSliceID,
 		Name:          *name,
 		Description:   *description,
+		CreatedBy:     *author,
 	}
 
 	resp, err := cli.sliceClient.CreateSliceFromFolder(ctx, req)
@@ -805,6 +1504,11 @@ func handleForkSlice(ctx context.Context, cli *CLI, args []string) {
 
 	fmt.Printf("Created slice: %s\n", resp.SliceId)
 	fmt.Printf("Status: %s\n", resp.Status)
+	fmt.Printf("Commit: %s\n", resp.CommitHash)
+	fmt.Printf("Files moved from %s: %d\n", parentSliceID, len(resp.Files))
+	for _, file := range resp.Files {
+		fmt.Printf("  %s\n", file)
+	}
 }
 
 func readSliceIDFromConfig() (string, error) {
@@ -839,6 +1543,12 @@ func printSliceHelp() {
 	fmt.Println("  info      Show slice information")
 	fmt.Println("  status    Show slice status")
 	fmt.Println("  owners    Show slice owners")
+	fmt.Println("  tree      Show a slice's parents and the slices under it")
+	fmt.Println("  reparent  Move a slice under a different parent")
+	fmt.Println("  fold      Fold a slice back into its parent")
+	fmt.Println("  update    Rename a slice or change its files and owners")
+	fmt.Println("  archive   Archive a slice, passing its files to its parent")
+	fmt.Println("  delete    Delete a slice, passing its files to its parent")
 }
 
 func printChangesetHelp() {
@@ -848,6 +1558,12 @@ func printChangesetHelp() {
 	fmt.Println("  review    Review a changeset")
 	fmt.Println("  merge     Merge a changeset into the slice")
 	fmt.Println("  rebase    Rebase a changeset onto the latest slice head")
+	fmt.Println("  enqueue   Queue an approved changeset to be rebased and merged in turn")
+	fmt.Println("  approve   Approve a changeset as a reviewer")
+	fmt.Println("  reject    Reject a changeset as a reviewer")
+	fmt.Println("  abandon   Abandon a changeset that will not be merged")
+	fmt.Println("  check     Report the state of a status check on a changeset")
+	fmt.Println("  comment   Comment on a changeset, or list and resolve its comments")
 	fmt.Println("  list      List changesets for the current slice")
 }
 
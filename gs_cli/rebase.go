@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	slicev1 "github.com/niczy/gitslice/proto/slice"
+)
+
+// writeConflictMarkers writes the line-merged contents of conflicted files into
+// the working directory so the conflicts can be resolved in place. It returns
+// the paths it wrote.
+func writeConflictMarkers(root string, files []*slicev1.ConflictedFile) ([]string, error) {
+	var written []string
+	for _, file := range files {
+		clean, err := cleanRepoPath(file.Path)
+		if err != nil {
+			return written, err
+		}
+		target := filepath.Join(root, filepath.FromSlash(clean))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return written, fmt.Errorf("failed to create directory for %s: %w", clean, err)
+		}
+		if err := os.WriteFile(target, file.Content, 0o644); err != nil {
+			return written, fmt.Errorf("failed to write %s: %w", clean, err)
+		}
+		written = append(written, clean)
+	}
+	return written, nil
+}
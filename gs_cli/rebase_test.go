@@ -0,0 +1,30 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	slicev1 "github.com/niczy/gitslice/proto/slice"
+)
+
+func TestWriteConflictMarkers(t *testing.T) {
+	root := t.TempDir()
+	content := "<<<<<<< changeset cs-1\nmine\n=======\ntheirs\n>>>>>>> slice s\n"
+
+	written, err := writeConflictMarkers(root, []*slicev1.ConflictedFile{{Path: "docs/notes.txt", Content: []byte(content)}})
+	if err != nil {
+		t.Fatalf("writeConflictMarkers returned error: %v", err)
+	}
+	if len(written) != 1 || written[0] != "docs/notes.txt" {
+		t.Fatalf("unexpected written paths: %v", written)
+	}
+	data, err := os.ReadFile(filepath.Join(root, "docs", "notes.txt"))
+	if err != nil || string(data) != content {
+		t.Fatalf("expected markers in working file, got %q (%v)", data, err)
+	}
+
+	if _, err := writeConflictMarkers(root, []*slicev1.ConflictedFile{{Path: "../escape.txt"}}); err == nil {
+		t.Fatalf("expected paths outside the working directory to be rejected")
+	}
+}
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/niczy/gitslice/internal/diff"
+	slicev1 "github.com/niczy/gitslice/proto/slice"
+)
+
+const (
+	colorReset = "\033[0m"
+	colorBold  = "\033[1m"
+	colorRed   = "\033[31m"
+	colorGreen = "\033[32m"
+	colorCyan  = "\033[36m"
+)
+
+// diffToolEnv names the external difftool used by `gs changeset review --external`.
+const diffToolEnv = "GITSLICE_DIFF_TOOL"
+
+// useColor resolves a --color setting of auto, always or never. Auto enables
+// color only when stdout is a terminal and NO_COLOR is unset.
+func useColor(mode string) (bool, error) {
+	switch mode {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto", "":
+		if os.Getenv("NO_COLOR") != "" {
+			return false, nil
+		}
+		info, err := os.Stdout.Stat()
+		if err != nil {
+			return false, nil
+		}
+		return info.Mode()&os.ModeCharDevice != 0, nil
+	default:
+		return false, fmt.Errorf("invalid --color value %q (want auto, always or never)", mode)
+	}
+}
+
+func paint(s, color string, enabled bool) string {
+	if !enabled || s == "" {
+		return s
+	}
+	return color + s + colorReset
+}
+
+// fileHeaderNames returns the ---/+++ names for a file diff, using /dev/null
+// for the missing side of additions and deletions.
+func fileHeaderNames(file *slicev1.FileDiff) (string, string) {
+	oldName, newName := "a/"+file.Path, "b/"+file.Path
+	switch file.ChangeType {
+	case slicev1.FileChangeType_FILE_CHANGE_TYPE_ADDED:
+		oldName = "/dev/null"
+	case slicev1.FileChangeType_FILE_CHANGE_TYPE_DELETED:
+		newName = "/dev/null"
+	}
+	return oldName, newName
+}
+
+func hunkHeader(h *slicev1.DiffHunk) string {
+	return diff.Hunk{
+		OldStart: int(h.OldStart),
+		OldLines: int(h.OldLines),
+		NewStart: int(h.NewStart),
+		NewLines: int(h.NewLines),
+	}.Header()
+}
+
+// commentThread is a review comment together with the replies to it.
+type commentThread struct {
+	root    *slicev1.ReviewComment
+	replies []*slicev1.ReviewComment
+}
+
+// groupThreads gathers replies under the comment that opened their thread,
+// keeping threads in the order they were started.
+func groupThreads(comments []*slicev1.ReviewComment) []*commentThread {
+	var threads []*commentThread
+	byID := make(map[string]*commentThread)
+	for _, comment := range comments {
+		if comment.ParentId == "" {
+			thread := &commentThread{root: comment}
+			byID[comment.CommentId] = thread
+			threads = append(threads, thread)
+		}
+	}
+	for _, comment := range comments {
+		if thread, ok := byID[comment.ParentId]; ok {
+			thread.replies = append(thread.replies, comment)
+		}
+	}
+	return threads
+}
+
+// threadLocation describes where a thread is anchored.
+func threadLocation(thread *commentThread) string {
+	switch {
+	case thread.root.Path == "":
+		return "changeset"
+	case thread.root.Line == 0:
+		return thread.root.Path
+	default:
+		return fmt.Sprintf("%s:%d", thread.root.Path, thread.root.Line)
+	}
+}
+
+// writeThread writes a comment thread, replies indented under the first comment.
+func writeThread(w io.Writer, indent string, thread *commentThread, color bool) {
+	state := ""
+	if thread.root.Resolved {
+		state = fmt.Sprintf(" (resolved by %s)", thread.root.ResolvedBy)
+	}
+	fmt.Fprintln(w, paint(fmt.Sprintf("%s> [%s] %s: %s%s", indent, thread.root.CommentId, thread.root.Author, thread.root.Body, state), colorCyan, color))
+	for _, reply := range thread.replies {
+		fmt.Fprintln(w, paint(fmt.Sprintf("%s>   %s: %s", indent, reply.Author, reply.Body), colorCyan, color))
+	}
+}
+
+// renderUnified writes the files as a unified diff, with each comment thread
+// shown under the line it is anchored to.
+func renderUnified(w io.Writer, files []*slicev1.FileDiff, threads []*commentThread, color bool) {
+	for _, file := range files {
+		oldName, newName := fileHeaderNames(file)
+		fmt.Fprintln(w, paint(fmt.Sprintf("diff --gs a/%s b/%s", file.Path, file.Path), colorBold, color))
+
+		byLine := make(map[int32][]*commentThread)
+		for _, thread := range threads {
+			if thread.root.Path == file.Path {
+				byLine[thread.root.Line] = append(byLine[thread.root.Line], thread)
+			}
+		}
+		writeThreadsAt := func(line int32) {
+			for _, thread := range byLine[line] {
+				writeThread(w, "    ", thread, color)
+			}
+			delete(byLine, line)
+		}
+
+		if file.Binary {
+			fmt.Fprintf(w, "Binary files %s and %s differ\n", oldName, newName)
+		} else if file.TooLarge {
+			fmt.Fprintf(w, "Files %s and %s are too large to diff\n", oldName, newName)
+		} else {
+			fmt.Fprintln(w, paint("--- "+oldName, colorBold, color))
+			fmt.Fprintln(w, paint("+++ "+newName, colorBold, color))
+		}
+		writeThreadsAt(0)
+
+		if !file.Binary {
+			for _, hunk := range file.Hunks {
+				fmt.Fprintln(w, paint(hunkHeader(hunk), colorCyan, color))
+				newNum := hunk.NewStart
+				for _, line := range hunk.Lines {
+					switch {
+					case strings.HasPrefix(line, "+"):
+						fmt.Fprintln(w, paint(line, colorGreen, color))
+					case strings.HasPrefix(line, "-"):
+						fmt.Fprintln(w, paint(line, colorRed, color))
+						continue
+					default:
+						fmt.Fprintln(w, line)
+					}
+					writeThreadsAt(newNum)
+					newNum++
+				}
+			}
+		}
+
+		// Threads on lines outside the hunks go after the file's diff
+		var rest []int32
+		for line := range byLine {
+			rest = append(rest, line)
+		}
+		sort.Slice(rest, func(i, j int) bool { return rest[i] < rest[j] })
+		for _, line := range rest {
+			for _, thread := range byLine[line] {
+				fmt.Fprintf(w, "    line %d:\n", line)
+				writeThread(w, "    ", thread, color)
+			}
+		}
+	}
+}
+
+// renderStat writes a diffstat: one line per file with a +/- histogram scaled
+// to barWidth, followed by a totals line.
+func renderStat(w io.Writer, files []*slicev1.FileDiff, color bool) {
+	const barWidth = 40
+
+	nameWidth := 0
+	var maxChanges int64
+	for _, file := range files {
+		if len(file.Path) > nameWidth {
+			nameWidth = len(file.Path)
+		}
+		if changes := file.LinesAdded + file.LinesRemoved; changes > maxChanges {
+			maxChanges = changes
+		}
+	}
+
+	var added, removed int64
+	for _, file := range files {
+		added += file.LinesAdded
+		removed += file.LinesRemoved
+
+		if file.Binary {
+			fmt.Fprintf(w, " %-*s | Bin\n", nameWidth, file.Path)
+			continue
+		}
+		if file.TooLarge {
+			fmt.Fprintf(w, " %-*s | Large\n", nameWidth, file.Path)
+			continue
+		}
+
+		plus, minus := file.LinesAdded, file.LinesRemoved
+		if maxChanges > barWidth {
+			plus = (plus*barWidth + maxChanges - 1) / maxChanges
+			minus = (minus*barWidth + maxChanges - 1) / maxChanges
+		}
+		bar := paint(strings.Repeat("+", int(plus)), colorGreen, color) + paint(strings.Repeat("-", int(minus)), colorRed, color)
+		fmt.Fprintf(w, " %-*s | %d %s\n", nameWidth, file.Path, file.LinesAdded+file.LinesRemoved, bar)
+	}
+
+	fmt.Fprintf(w, " %d files changed, %d insertions(+), %d deletions(-)\n", len(files), added, removed)
+}
+
+// renderSideBySide writes each hunk as two columns, pairing removed lines with
+// the lines that replaced them.
+func renderSideBySide(w io.Writer, files []*slicev1.FileDiff, width int, color bool) {
+	// Each side gets a 5-column line number and the gutter takes 3 columns.
+	column := (width - 3) / 2
+	textWidth := column - 5
+	if textWidth < 10 {
+		textWidth = 10
+	}
+
+	row := func(oldNum int32, oldText string, marker string, newNum int32, newText string) {
+		left := fmt.Sprintf("%4s %s", lineNumber(oldNum), padRight(truncate(oldText, textWidth), textWidth))
+		right := fmt.Sprintf("%4s %s", lineNumber(newNum), truncate(newText, textWidth))
+		switch marker {
+		case "|":
+			left, right = paint(left, colorRed, color), paint(right, colorGreen, color)
+		case "<":
+			left = paint(left, colorRed, color)
+		case ">":
+			right = paint(right, colorGreen, color)
+		}
+		fmt.Fprintf(w, "%s %s %s\n", left, marker, strings.TrimRight(right, " "))
+	}
+
+	for _, file := range files {
+		fmt.Fprintln(w, paint(fmt.Sprintf("==> %s (%s)", file.Path, changeLabel(file.ChangeType)), colorBold, color))
+		if file.Binary {
+			fmt.Fprintln(w, "Binary files differ")
+			continue
+		}
+		if file.TooLarge {
+			fmt.Fprintln(w, "Files are too large to diff")
+			continue
+		}
+
+		for _, hunk := range file.Hunks {
+			fmt.Fprintln(w, paint(hunkHeader(hunk), colorCyan, color))
+			oldNum, newNum := hunk.OldStart, hunk.NewStart
+			var removed, inserted []string
+
+			flush := func() {
+				for i := 0; i < len(removed) || i < len(inserted); i++ {
+					switch {
+					case i < len(removed) && i < len(inserted):
+						row(oldNum, removed[i], "|", newNum, inserted[i])
+						oldNum++
+						newNum++
+					case i < len(removed):
+						row(oldNum, removed[i], "<", 0, "")
+						oldNum++
+					default:
+						row(0, "", ">", newNum, inserted[i])
+						newNum++
+					}
+				}
+				removed, inserted = nil, nil
+			}
+
+			for _, line := range hunk.Lines {
+				if line == "" {
+					continue
+				}
+				switch line[0] {
+				case '-':
+					removed = append(removed, line[1:])
+				case '+':
+					inserted = append(inserted, line[1:])
+				default:
+					flush()
+					row(oldNum, line[1:], " ", newNum, line[1:])
+					oldNum++
+					newNum++
+				}
+			}
+			flush()
+		}
+	}
+}
+
+func changeLabel(t slicev1.FileChangeType) string {
+	switch t {
+	case slicev1.FileChangeType_FILE_CHANGE_TYPE_ADDED:
+		return "added"
+	case slicev1.FileChangeType_FILE_CHANGE_TYPE_DELETED:
+		return "deleted"
+	default:
+		return "modified"
+	}
+}
+
+func lineNumber(n int32) string {
+	if n == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+func truncate(s string, width int) string {
+	s = strings.ReplaceAll(s, "\t", "    ")
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+func padRight(s string, width int) string {
+	if n := len([]rune(s)); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
+
+// runExternalDiff pipes a plain unified diff to the configured difftool.
+func runExternalDiff(tool string, files []*slicev1.FileDiff) error {
+	if tool == "" {
+		tool = os.Getenv(diffToolEnv)
+	}
+	if tool == "" {
+		return fmt.Errorf("no difftool configured; pass --tool or set %s", diffToolEnv)
+	}
+
+	var buf bytes.Buffer
+	renderUnified(&buf, files, nil, false)
+
+	cmd := exec.Command("sh", "-c", tool)
+	cmd.Stdin = &buf
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
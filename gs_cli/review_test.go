@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	slicev1 "github.com/niczy/gitslice/proto/slice"
+)
+
+func sampleFileDiffs() []*slicev1.FileDiff {
+	return []*slicev1.FileDiff{
+		{
+			Path:         "main.go",
+			ChangeType:   slicev1.FileChangeType_FILE_CHANGE_TYPE_MODIFIED,
+			LinesAdded:   1,
+			LinesRemoved: 1,
+			Hunks: []*slicev1.DiffHunk{{
+				OldStart: 1, OldLines: 3, NewStart: 1, NewLines: 3,
+				Lines: []string{" package main", "-var x = 1", "+var x = 2", " "},
+			}},
+		},
+		{
+			Path:       "new.txt",
+			ChangeType: slicev1.FileChangeType_FILE_CHANGE_TYPE_ADDED,
+			LinesAdded: 1,
+			Hunks: []*slicev1.DiffHunk{{
+				OldStart: 0, OldLines: 0, NewStart: 1, NewLines: 1,
+				Lines: []string{"+hello"},
+			}},
+		},
+	}
+}
+
+func TestRenderUnified(t *testing.T) {
+	var buf bytes.Buffer
+	renderUnified(&buf, sampleFileDiffs(), nil, false)
+
+	out := buf.String()
+	for _, want := range []string{
+		"--- a/main.go\n+++ b/main.go\n@@ -1,3 +1,3 @@\n package main\n-var x = 1\n+var x = 2\n",
+		"--- /dev/null\n+++ b/new.txt\n@@ -0,0 +1 @@\n+hello\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected unified output to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	renderUnified(&buf, sampleFileDiffs(), nil, true)
+	if !strings.Contains(buf.String(), colorGreen+"+var x = 2"+colorReset) {
+		t.Fatalf("expected colored insertion, got:\n%s", buf.String())
+	}
+}
+
+func TestRenderUnifiedShowsCommentThreads(t *testing.T) {
+	threads := groupThreads([]*slicev1.ReviewComment{
+		{CommentId: "c1", Path: "main.go", Line: 2, Author: "bob", Body: "why 2?"},
+		{CommentId: "c2", Path: "main.go", Author: "carol", Body: "needs a test", Resolved: true, ResolvedBy: "alice"},
+		{CommentId: "c3", Path: "main.go", Line: 2, ParentId: "c1", Author: "alice", Body: "see the spec"},
+		{CommentId: "c4", Path: "new.txt", Line: 9, Author: "bob", Body: "outside the hunk"},
+	})
+	if len(threads) != 3 || len(threads[0].replies) != 1 {
+		t.Fatalf("expected replies grouped under their thread, got %+v", threads)
+	}
+
+	var buf bytes.Buffer
+	renderUnified(&buf, sampleFileDiffs(), threads, false)
+
+	out := buf.String()
+	for _, want := range []string{
+		"+++ b/main.go\n    > [c2] carol: needs a test (resolved by alice)\n@@",
+		"+var x = 2\n    > [c1] bob: why 2?\n    >   alice: see the spec\n ",
+		"+hello\n    line 9:\n    > [c4] bob: outside the hunk\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderStat(t *testing.T) {
+	var buf bytes.Buffer
+	renderStat(&buf, sampleFileDiffs(), false)
+
+	out := buf.String()
+	if !strings.Contains(out, " main.go | 2 +-\n") || !strings.Contains(out, " new.txt | 1 +\n") {
+		t.Fatalf("unexpected stat output:\n%s", out)
+	}
+	if !strings.Contains(out, "2 files changed, 2 insertions(+), 1 deletions(-)") {
+		t.Fatalf("unexpected stat totals:\n%s", out)
+	}
+}
+
+func TestRenderReportsFilesTooLargeToDiff(t *testing.T) {
+	files := []*slicev1.FileDiff{{Path: "data.csv", ChangeType: slicev1.FileChangeType_FILE_CHANGE_TYPE_MODIFIED, TooLarge: true}}
+
+	var unified, stat bytes.Buffer
+	renderUnified(&unified, files, nil, false)
+	renderStat(&stat, files, false)
+	if !strings.Contains(unified.String(), "Files a/data.csv and b/data.csv are too large to diff") {
+		t.Fatalf("unexpected unified output:\n%s", unified.String())
+	}
+	if !strings.Contains(stat.String(), " data.csv | Large\n") {
+		t.Fatalf("unexpected stat output:\n%s", stat.String())
+	}
+}
+
+func TestRenderSideBySidePairsChangedLines(t *testing.T) {
+	var buf bytes.Buffer
+	renderSideBySide(&buf, sampleFileDiffs()[:1], 60, false)
+
+	var changed string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.Contains(line, " | ") {
+			changed = line
+		}
+	}
+	if !strings.Contains(changed, "var x = 1") || !strings.Contains(changed, "var x = 2") {
+		t.Fatalf("expected old and new lines on one row, got:\n%s", buf.String())
+	}
+}
+
+func TestUseColorModes(t *testing.T) {
+	if on, _ := useColor("always"); !on {
+		t.Fatalf("expected always to enable color")
+	}
+	if on, _ := useColor("never"); on {
+		t.Fatalf("expected never to disable color")
+	}
+	if _, err := useColor("sometimes"); err == nil {
+		t.Fatalf("expected invalid mode to be rejected")
+	}
+}
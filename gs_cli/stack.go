@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	slicev1 "github.com/niczy/gitslice/proto/slice"
+)
+
+// renderStack writes changesets as a tree, each stacked changeset indented
+// under its parent. Changesets whose parent is not listed start their own tree
+// and name the parent they sit on.
+func renderStack(w io.Writer, changesets []*slicev1.ChangesetInfo) {
+	listed := make(map[string]bool, len(changesets))
+	for _, cs := range changesets {
+		listed[cs.ChangesetId] = true
+	}
+
+	children := make(map[string][]*slicev1.ChangesetInfo)
+	var roots []*slicev1.ChangesetInfo
+	for _, cs := range changesets {
+		if cs.ParentChangesetId != "" && listed[cs.ParentChangesetId] {
+			children[cs.ParentChangesetId] = append(children[cs.ParentChangesetId], cs)
+		} else {
+			roots = append(roots, cs)
+		}
+	}
+	oldestFirst := func(list []*slicev1.ChangesetInfo) {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].CreatedAt < list[j].CreatedAt })
+	}
+	oldestFirst(roots)
+
+	var walk func(cs *slicev1.ChangesetInfo, prefix string)
+	walk = func(cs *slicev1.ChangesetInfo, prefix string) {
+		kids := children[cs.ChangesetId]
+		oldestFirst(kids)
+		for i, child := range kids {
+			branch, indent := "├── ", "│   "
+			if i == len(kids)-1 {
+				branch, indent = "└── ", "    "
+			}
+			fmt.Fprintf(w, "%s%s%s\n", prefix, branch, stackLine(child))
+			walk(child, prefix+indent)
+		}
+	}
+
+	for _, root := range roots {
+		line := stackLine(root)
+		if root.ParentChangesetId != "" {
+			line += fmt.Sprintf(" (on %s)", root.ParentChangesetId)
+		}
+		fmt.Fprintln(w, line)
+		walk(root, "")
+	}
+}
+
+func stackLine(cs *slicev1.ChangesetInfo) string {
+	return fmt.Sprintf("%s [%s] %s", cs.ChangesetId, cs.Status.String(), cs.Message)
+}
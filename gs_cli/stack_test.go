@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	slicev1 "github.com/niczy/gitslice/proto/slice"
+)
+
+func TestRenderStack(t *testing.T) {
+	changesets := []*slicev1.ChangesetInfo{
+		{ChangesetId: "cs-4", ParentChangesetId: "cs-2", Message: "second child", CreatedAt: 4},
+		{ChangesetId: "cs-3", ParentChangesetId: "cs-2", Message: "first child", CreatedAt: 3},
+		{ChangesetId: "cs-5", ParentChangesetId: "cs-3", Message: "grandchild", CreatedAt: 5},
+		{ChangesetId: "cs-2", Message: "base", CreatedAt: 2},
+		{ChangesetId: "cs-6", ParentChangesetId: "cs-1", Message: "orphan", CreatedAt: 6, Status: slicev1.ChangesetStatus_APPROVED},
+	}
+
+	var buf bytes.Buffer
+	renderStack(&buf, changesets)
+
+	want := "cs-2 [PENDING] base\n" +
+		"├── cs-3 [PENDING] first child\n" +
+		"│   └── cs-5 [PENDING] grandchild\n" +
+		"└── cs-4 [PENDING] second child\n" +
+		"cs-6 [APPROVED] orphan (on cs-1)\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected stack:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	slicev1 "github.com/niczy/gitslice/proto/slice"
+)
+
+// renderSliceTree writes the slices under sliceID as a tree, in the depth
+// first order ListSliceChildren returns them.
+func renderSliceTree(w io.Writer, sliceID string, nodes []*slicev1.SliceNode) {
+	children := make(map[string][]*slicev1.SliceNode)
+	for _, node := range nodes {
+		children[node.ParentSliceId] = append(children[node.ParentSliceId], node)
+	}
+
+	var walk func(parentID, prefix string)
+	walk = func(parentID, prefix string) {
+		kids := children[parentID]
+		for i, child := range kids {
+			branch, indent := "├── ", "│   "
+			if i == len(kids)-1 {
+				branch, indent = "└── ", "    "
+			}
+			fmt.Fprintf(w, "%s%s%s\n", prefix, branch, sliceTreeLine(child))
+			walk(child.SliceId, prefix+indent)
+		}
+	}
+
+	fmt.Fprintln(w, sliceID)
+	walk(sliceID, "")
+}
+
+func sliceTreeLine(node *slicev1.SliceNode) string {
+	files := "files"
+	if node.FileCount == 1 {
+		files = "file"
+	}
+	return fmt.Sprintf("%s (%d %s)", node.SliceId, node.FileCount, files)
+}
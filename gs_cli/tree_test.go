@@ -0,0 +1,29 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	slicev1 "github.com/niczy/gitslice/proto/slice"
+)
+
+func TestRenderSliceTree(t *testing.T) {
+	nodes := []*slicev1.SliceNode{
+		{SliceId: "app", ParentSliceId: "root", Depth: 1, FileCount: 2},
+		{SliceId: "util", ParentSliceId: "app", Depth: 2, FileCount: 1},
+		{SliceId: "web", ParentSliceId: "app", Depth: 2, FileCount: 0},
+		{SliceId: "docs", ParentSliceId: "root", Depth: 1, FileCount: 4},
+	}
+
+	var buf bytes.Buffer
+	renderSliceTree(&buf, "root", nodes)
+
+	want := "root\n" +
+		"├── app (2 files)\n" +
+		"│   ├── util (1 file)\n" +
+		"│   └── web (0 files)\n" +
+		"└── docs (4 files)\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected tree:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/niczy/gitslice/internal/objects"
+	slicev1 "github.com/niczy/gitslice/proto/slice"
+)
+
+// streamUploadThreshold is the total object size above which changesets are
+// sent over StreamCreateChangeset instead of a single CreateChangeset request.
+const streamUploadThreshold = 2 << 20
+
+// uploadPartSize bounds the object bytes carried by a single ChangesetChunk so
+// large files stay below gRPC's default 4MB message limit.
+const uploadPartSize = 1 << 20
+
+// changesetUpload holds the objects describing local modifications.
+type changesetUpload struct {
+	blobs         []*slicev1.Object
+	trees         []*slicev1.Object
+	modifiedFiles []string
+}
+
+// collectChangeset hashes the given working-directory files into blob and
+// tree objects. Paths that no longer exist on disk are recorded as deletions
+// by leaving them out of the tree.
+func collectChangeset(root string, paths []string) (*changesetUpload, error) {
+	upload := &changesetUpload{}
+	snapshot := make(map[string]string)
+	seen := make(map[string]bool)
+
+	for _, p := range paths {
+		clean, err := cleanRepoPath(p)
+		if err != nil {
+			return nil, err
+		}
+		if seen[clean] {
+			continue
+		}
+		seen[clean] = true
+		upload.modifiedFiles = append(upload.modifiedFiles, clean)
+
+		data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(clean)))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", clean, err)
+		}
+
+		hash := objects.HashBlob(data)
+		snapshot[clean] = hash
+		upload.blobs = append(upload.blobs, &slicev1.Object{Type: slicev1.ObjectType_BLOB, Hash: hash, Data: data})
+	}
+
+	// A changeset that only deletes files still sends its (empty) tree, so
+	// the server can tell the deletions from a changeset without content
+	if len(upload.modifiedFiles) == 0 {
+		return upload, nil
+	}
+
+	_, trees, err := objects.BuildSnapshot(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	for _, tree := range trees {
+		upload.trees = append(upload.trees, &slicev1.Object{Type: slicev1.ObjectType_TREE, Hash: tree.Hash, Data: tree.Data})
+	}
+	return upload, nil
+}
+
+// cleanRepoPath normalizes a path relative to the working directory root.
+func cleanRepoPath(p string) (string, error) {
+	clean := path.Clean(strings.TrimPrefix(filepath.ToSlash(p), "/"))
+	if clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("invalid path %q", p)
+	}
+	return clean, nil
+}
+
+// skipKnownBlobs drops blobs the server already stores. Trees are always sent
+// so the server can identify the changeset's root tree.
+func (u *changesetUpload) skipKnownBlobs(ctx context.Context, client slicev1.SliceServiceClient) (int, error) {
+	if len(u.blobs) == 0 {
+		return 0, nil
+	}
+
+	hashes := make([]string, 0, len(u.blobs))
+	for _, blob := range u.blobs {
+		hashes = append(hashes, blob.Hash)
+	}
+	resp, err := client.FindMissingObjects(ctx, &slicev1.FindMissingObjectsRequest{Hashes: hashes})
+	if err != nil {
+		return 0, err
+	}
+
+	missing := make(map[string]bool, len(resp.MissingHashes))
+	for _, hash := range resp.MissingHashes {
+		missing[hash] = true
+	}
+
+	var kept []*slicev1.Object
+	for _, blob := range u.blobs {
+		if missing[blob.Hash] {
+			kept = append(kept, blob)
+			delete(missing, blob.Hash)
+		}
+	}
+	skipped := len(u.blobs) - len(kept)
+	u.blobs = kept
+	return skipped, nil
+}
+
+func (u *changesetUpload) objects() []*slicev1.Object {
+	return append(append([]*slicev1.Object{}, u.blobs...), u.trees...)
+}
+
+func (u *changesetUpload) size() int {
+	total := 0
+	for _, obj := range u.objects() {
+		total += len(obj.Data)
+	}
+	return total
+}
+
+// send creates the changeset, streaming the objects when the upload is large.
+func (u *changesetUpload) send(ctx context.Context, client slicev1.SliceServiceClient, meta *slicev1.ChangesetMetadata, forceStream bool) (*slicev1.CreateChangesetResponse, error) {
+	meta.ModifiedFiles = u.modifiedFiles
+	if !forceStream && u.size() <= streamUploadThreshold {
+		return client.CreateChangeset(ctx, &slicev1.CreateChangesetRequest{
+			SliceId:           meta.SliceId,
+			BaseCommitHash:    meta.BaseCommitHash,
+			Objects:           u.objects(),
+			ModifiedFiles:     meta.ModifiedFiles,
+			Author:            meta.Author,
+			Message:           meta.Message,
+			ParentChangesetId: meta.ParentChangesetId,
+		})
+	}
+
+	stream, err := client.StreamCreateChangeset(ctx)
+	if err != nil {
+		return nil, err
+	}
+	chunks := []*slicev1.ChangesetChunk{{Chunk: &slicev1.ChangesetChunk_Metadata{Metadata: meta}}}
+	for _, obj := range u.objects() {
+		for _, part := range splitObject(obj, uploadPartSize) {
+			chunks = append(chunks, &slicev1.ChangesetChunk{Chunk: &slicev1.ChangesetChunk_Object{Object: part}})
+		}
+	}
+	for _, chunk := range chunks {
+		// io.EOF means the server ended the stream; its status comes from CloseAndRecv
+		if err := stream.Send(chunk); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
+// splitObject cuts an object into parts of at most size bytes. Every part but
+// the last is marked as having more to follow.
+func splitObject(obj *slicev1.Object, size int) []*slicev1.Object {
+	if len(obj.Data) <= size {
+		return []*slicev1.Object{obj}
+	}
+	var parts []*slicev1.Object
+	for offset := 0; offset < len(obj.Data); offset += size {
+		end := offset + size
+		if end > len(obj.Data) {
+			end = len(obj.Data)
+		}
+		parts = append(parts, &slicev1.Object{
+			Type:   obj.Type,
+			Hash:   obj.Hash,
+			Data:   obj.Data[offset:end],
+			Offset: int64(offset),
+			More:   end < len(obj.Data),
+		})
+	}
+	return parts
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/niczy/gitslice/internal/objects"
+	slicev1 "github.com/niczy/gitslice/proto/slice"
+)
+
+func TestCollectChangesetHashesFilesAndRecordsDeletions(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "pkg"), 0o755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "pkg", "a.go"), []byte("package pkg\n"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	upload, err := collectChangeset(root, []string{"pkg/a.go", "./pkg/a.go", "removed.txt"})
+	if err != nil {
+		t.Fatalf("collectChangeset returned error: %v", err)
+	}
+
+	if len(upload.modifiedFiles) != 2 || upload.modifiedFiles[0] != "pkg/a.go" || upload.modifiedFiles[1] != "removed.txt" {
+		t.Fatalf("unexpected modified files: %v", upload.modifiedFiles)
+	}
+	if len(upload.blobs) != 1 || upload.blobs[0].Hash != objects.HashBlob([]byte("package pkg\n")) {
+		t.Fatalf("unexpected blobs: %+v", upload.blobs)
+	}
+	if len(upload.trees) != 2 {
+		t.Fatalf("expected root and pkg trees, got %d", len(upload.trees))
+	}
+
+	deletions, err := collectChangeset(root, []string{"removed.txt"})
+	if err != nil {
+		t.Fatalf("collectChangeset returned error: %v", err)
+	}
+	if len(deletions.blobs) != 0 || len(deletions.trees) != 1 || len(deletions.trees[0].Data) != 0 {
+		t.Fatalf("expected a deletion-only upload to carry just an empty tree, got %+v", deletions.trees)
+	}
+
+	if _, err := collectChangeset(root, []string{"../outside.txt"}); err == nil {
+		t.Fatalf("expected paths outside the working directory to be rejected")
+	}
+}
+
+func TestSplitObjectMarksParts(t *testing.T) {
+	data := make([]byte, 5<<20)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	obj := &slicev1.Object{Type: slicev1.ObjectType_BLOB, Hash: objects.HashBlob(data), Data: data}
+
+	parts := splitObject(obj, uploadPartSize)
+	if len(parts) != 5 {
+		t.Fatalf("expected 5 parts, got %d", len(parts))
+	}
+	var joined []byte
+	for i, part := range parts {
+		if part.Hash != obj.Hash || part.Type != obj.Type {
+			t.Fatalf("part %d lost the object's identity: %+v", i, part)
+		}
+		if part.Offset != int64(len(joined)) {
+			t.Fatalf("part %d starts at %d, want %d", i, part.Offset, len(joined))
+		}
+		if part.More != (i < len(parts)-1) {
+			t.Fatalf("part %d has more=%v", i, part.More)
+		}
+		if len(part.Data) > uploadPartSize {
+			t.Fatalf("part %d carries %d bytes", i, len(part.Data))
+		}
+		joined = append(joined, part.Data...)
+	}
+	if string(joined) != string(data) {
+		t.Fatalf("parts do not reassemble into the object")
+	}
+
+	small := &slicev1.Object{Type: slicev1.ObjectType_BLOB, Hash: "h", Data: []byte("small")}
+	if parts := splitObject(small, uploadPartSize); len(parts) != 1 || parts[0] != small {
+		t.Fatalf("expected small objects to be sent whole, got %+v", parts)
+	}
+}
@@ -0,0 +1,388 @@
+// Package diff computes line-level differences between two versions of a file
+// using the Myers algorithm and renders them as unified hunks.
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Op identifies how a line changed between the old and new text.
+type Op int
+
+const (
+	OpEqual Op = iota
+	OpDelete
+	OpInsert
+)
+
+// Edit is one line of an edit script. OldLine and NewLine are 1-based line
+// numbers; the side a line does not appear on is zero.
+type Edit struct {
+	Op      Op
+	Text    string
+	OldLine int
+	NewLine int
+}
+
+// Hunk is a run of edits with surrounding context, as in a unified diff.
+type Hunk struct {
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+	Edits    []Edit
+}
+
+// binarySniffLen mirrors git's heuristic of looking for NUL bytes near the
+// start of a file to decide whether it is binary.
+const binarySniffLen = 8000
+
+// IsBinary reports whether content looks like binary data.
+func IsBinary(content []byte) bool {
+	if len(content) > binarySniffLen {
+		content = content[:binarySniffLen]
+	}
+	return bytes.IndexByte(content, 0) >= 0
+}
+
+// SplitLines splits text into lines without their trailing newlines. A final
+// line without a newline is kept; an empty text has no lines.
+func SplitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.Split(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Lines diffs two texts line by line.
+func Lines(oldText, newText string) []Edit {
+	return Diff(SplitLines(oldText), SplitLines(newText))
+}
+
+// Diff returns a shortest edit script turning a into b. Deletions are listed
+// before insertions within each changed region.
+func Diff(a, b []string) []Edit {
+	// Trim the common prefix and suffix; Myers only needs to see the middle.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var edits []Edit
+	for i := 0; i < prefix; i++ {
+		edits = append(edits, Edit{Op: OpEqual, Text: a[i], OldLine: i + 1, NewLine: i + 1})
+	}
+	for _, e := range myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]) {
+		if e.OldLine > 0 {
+			e.OldLine += prefix
+		}
+		if e.NewLine > 0 {
+			e.NewLine += prefix
+		}
+		edits = append(edits, e)
+	}
+	for i := suffix; i > 0; i-- {
+		edits = append(edits, Edit{Op: OpEqual, Text: a[len(a)-i], OldLine: len(a) - i + 1, NewLine: len(b) - i + 1})
+	}
+	return edits
+}
+
+// myers implements the linear-space variant of "An O(ND) Difference Algorithm
+// and Its Variations": it finds the middle snake of an optimal path by
+// searching from both ends, then recurses on either side of it. Only two
+// frontiers and a changed flag per line are kept, so memory is O(N+M).
+func myers(a, b []string) []Edit {
+	n, m := len(a), len(b)
+	if n == 0 && m == 0 {
+		return nil
+	}
+
+	size := (n+m+1)/2 + 1
+	s := &myersState{
+		a:       a,
+		b:       b,
+		deleted: make([]bool, n),
+		added:   make([]bool, m),
+		forward: make([]int, 2*size+1),
+		reverse: make([]int, 2*size+1),
+		offset:  size,
+	}
+	s.compare(0, n, 0, m)
+
+	var edits []Edit
+	x, y := 0, 0
+	for x < n || y < m {
+		switch {
+		case x < n && s.deleted[x]:
+			edits = append(edits, Edit{Op: OpDelete, Text: a[x], OldLine: x + 1})
+			x++
+		case y < m && s.added[y]:
+			edits = append(edits, Edit{Op: OpInsert, Text: b[y], NewLine: y + 1})
+			y++
+		default:
+			edits = append(edits, Edit{Op: OpEqual, Text: a[x], OldLine: x + 1, NewLine: y + 1})
+			x++
+			y++
+		}
+	}
+	return edits
+}
+
+// myersState holds the inputs, the per-line results and the two frontiers
+// shared by every level of the recursion.
+type myersState struct {
+	a, b             []string
+	deleted, added   []bool
+	forward, reverse []int
+	offset           int
+}
+
+// compare marks the lines of a[aLo:aHi] and b[bLo:bHi] that an optimal edit
+// script deletes or inserts.
+func (s *myersState) compare(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && s.a[aLo] == s.b[bLo] {
+		aLo++
+		bLo++
+	}
+	for aLo < aHi && bLo < bHi && s.a[aHi-1] == s.b[bHi-1] {
+		aHi--
+		bHi--
+	}
+
+	switch {
+	case aLo == aHi:
+		for y := bLo; y < bHi; y++ {
+			s.added[y] = true
+		}
+	case bLo == bHi:
+		for x := aLo; x < aHi; x++ {
+			s.deleted[x] = true
+		}
+	default:
+		// With the common ends trimmed and both sides non-empty, the script has
+		// at least two edits, so each half of the split is strictly smaller.
+		x, y, u, v := s.middleSnake(aLo, aHi, bLo, bHi)
+		s.compare(aLo, x, bLo, y)
+		s.compare(u, aHi, v, bHi)
+	}
+}
+
+// middleSnake returns the start (x, y) and end (u, v) of a diagonal run that
+// lies in the middle of an optimal path through the given ranges.
+func (s *myersState) middleSnake(aLo, aHi, bLo, bHi int) (x, y, u, v int) {
+	n, m := aHi-aLo, bHi-bLo
+	delta := n - m
+	odd := delta%2 != 0
+	fv, rv, off := s.forward, s.reverse, s.offset
+	fv[off+1], rv[off+1] = 0, 0
+
+	for d := 0; d <= (n+m+1)/2; d++ {
+		// Forward search: fv[k] is the furthest x reached on diagonal x-y = k.
+		for k := -d; k <= d; k += 2 {
+			var fx int
+			if k == -d || (k != d && fv[off+k-1] < fv[off+k+1]) {
+				fx = fv[off+k+1]
+			} else {
+				fx = fv[off+k-1] + 1
+			}
+			fy := fx - k
+			sx, sy := fx, fy
+			for fx < n && fy < m && s.a[aLo+fx] == s.b[bLo+fy] {
+				fx++
+				fy++
+			}
+			fv[off+k] = fx
+			if odd && delta-k >= -(d-1) && delta-k <= d-1 && fx+rv[off+delta-k] >= n {
+				return aLo + sx, bLo + sy, aLo + fx, bLo + fy
+			}
+		}
+
+		// Reverse search, measured from the ends: rv[k] is how far back from
+		// aHi the path on diagonal k = (n-x)-(m-y) has reached.
+		for k := -d; k <= d; k += 2 {
+			var rx int
+			if k == -d || (k != d && rv[off+k-1] < rv[off+k+1]) {
+				rx = rv[off+k+1]
+			} else {
+				rx = rv[off+k-1] + 1
+			}
+			ry := rx - k
+			sx, sy := rx, ry
+			for rx < n && ry < m && s.a[aHi-1-rx] == s.b[bHi-1-ry] {
+				rx++
+				ry++
+			}
+			rv[off+k] = rx
+			if !odd && delta-k >= -d && delta-k <= d && rx+fv[off+delta-k] >= n {
+				return aHi - rx, bHi - ry, aHi - sx, bHi - sy
+			}
+		}
+	}
+	panic("diff: no middle snake found")
+}
+
+// Stat counts inserted and deleted lines in an edit script.
+func Stat(edits []Edit) (added, removed int) {
+	for _, e := range edits {
+		switch e.Op {
+		case OpInsert:
+			added++
+		case OpDelete:
+			removed++
+		}
+	}
+	return added, removed
+}
+
+// Hunks groups an edit script into hunks with up to context unchanged lines
+// around each change. Changes separated by at most 2*context equal lines share
+// a hunk.
+func Hunks(edits []Edit, context int) []Hunk {
+	if context < 0 {
+		context = 0
+	}
+
+	var hunks []Hunk
+	var current *Hunk
+	lastChange := -1
+
+	for i, e := range edits {
+		if e.Op == OpEqual {
+			continue
+		}
+
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		if current != nil && start <= lastChange+context+1 {
+			current.Edits = append(current.Edits, edits[lastChange+1:i+1]...)
+		} else {
+			if current != nil {
+				current.Edits = append(current.Edits, trailing(edits, lastChange, context)...)
+				hunks = append(hunks, *current)
+			}
+			current = &Hunk{}
+			current.Edits = append(current.Edits, edits[start:i+1]...)
+		}
+		lastChange = i
+	}
+
+	if current != nil {
+		current.Edits = append(current.Edits, trailing(edits, lastChange, context)...)
+		hunks = append(hunks, *current)
+	}
+
+	for i := range hunks {
+		hunks[i].fillRanges(edits)
+	}
+	return hunks
+}
+
+func trailing(edits []Edit, lastChange, context int) []Edit {
+	end := lastChange + 1 + context
+	if end > len(edits) {
+		end = len(edits)
+	}
+	return edits[lastChange+1 : end]
+}
+
+// fillRanges computes the hunk header line ranges from its edits.
+func (h *Hunk) fillRanges(all []Edit) {
+	for _, e := range h.Edits {
+		switch e.Op {
+		case OpEqual:
+			h.OldLines++
+			h.NewLines++
+		case OpDelete:
+			h.OldLines++
+		case OpInsert:
+			h.NewLines++
+		}
+		if h.OldStart == 0 && e.OldLine > 0 {
+			h.OldStart = e.OldLine
+		}
+		if h.NewStart == 0 && e.NewLine > 0 {
+			h.NewStart = e.NewLine
+		}
+	}
+
+	// An empty side starts at the line before the change, as in GNU diff.
+	if h.OldLines == 0 {
+		h.OldStart = linesBefore(all, h.Edits[0], true)
+	}
+	if h.NewLines == 0 {
+		h.NewStart = linesBefore(all, h.Edits[0], false)
+	}
+}
+
+// linesBefore returns the number of old (or new) lines that precede the first
+// edit of a hunk.
+func linesBefore(all []Edit, first Edit, old bool) int {
+	count := 0
+	for _, e := range all {
+		if e == first {
+			break
+		}
+		if old && e.Op != OpInsert {
+			count++
+		}
+		if !old && e.Op != OpDelete {
+			count++
+		}
+	}
+	return count
+}
+
+// Header renders the "@@ -a,b +c,d @@" line for a hunk.
+func (h Hunk) Header() string {
+	return fmt.Sprintf("@@ -%s +%s @@", hunkRange(h.OldStart, h.OldLines), hunkRange(h.NewStart, h.NewLines))
+}
+
+func hunkRange(start, lines int) string {
+	if lines == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, lines)
+}
+
+// Prefixed renders an edit as a unified diff body line.
+func (e Edit) Prefixed() string {
+	switch e.Op {
+	case OpInsert:
+		return "+" + e.Text
+	case OpDelete:
+		return "-" + e.Text
+	default:
+		return " " + e.Text
+	}
+}
+
+// Unified renders hunks as a unified diff between oldName and newName.
+func Unified(oldName, newName string, hunks []Hunk) string {
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+	for _, h := range hunks {
+		b.WriteString(h.Header())
+		b.WriteByte('\n')
+		for _, e := range h.Edits {
+			b.WriteString(e.Prefixed())
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
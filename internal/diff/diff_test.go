@@ -0,0 +1,151 @@
+package diff
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestLinesProducesMinimalEditScript(t *testing.T) {
+	edits := Lines("a\nb\nc\na\nb\nb\na\n", "c\nb\na\nb\na\nc\n")
+	added, removed := Stat(edits)
+	// The classic example from Myers' paper has an edit distance of 5.
+	if added+removed != 5 {
+		t.Fatalf("expected 5 edits, got +%d -%d", added, removed)
+	}
+
+	var oldLines, newLines []string
+	for _, e := range edits {
+		if e.Op != OpInsert {
+			oldLines = append(oldLines, e.Text)
+		}
+		if e.Op != OpDelete {
+			newLines = append(newLines, e.Text)
+		}
+	}
+	if strings.Join(oldLines, "") != "abcabba" || strings.Join(newLines, "") != "cbabac" {
+		t.Fatalf("edit script does not reproduce inputs: %v / %v", oldLines, newLines)
+	}
+}
+
+func TestDiffMatchesLongestCommonSubsequence(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	random := func() []string {
+		lines := make([]string, rng.Intn(30))
+		for i := range lines {
+			lines[i] = string(rune('a' + rng.Intn(4)))
+		}
+		return lines
+	}
+
+	for i := 0; i < 500; i++ {
+		a, b := random(), random()
+		edits := Diff(a, b)
+
+		var oldLines, newLines []string
+		equal := 0
+		for _, e := range edits {
+			if e.Op != OpInsert {
+				oldLines = append(oldLines, e.Text)
+				if a[e.OldLine-1] != e.Text {
+					t.Fatalf("%v -> %v: old line %d is %q, edit says %q", a, b, e.OldLine, a[e.OldLine-1], e.Text)
+				}
+			}
+			if e.Op != OpDelete {
+				newLines = append(newLines, e.Text)
+				if b[e.NewLine-1] != e.Text {
+					t.Fatalf("%v -> %v: new line %d is %q, edit says %q", a, b, e.NewLine, b[e.NewLine-1], e.Text)
+				}
+			}
+			if e.Op == OpEqual {
+				equal++
+			}
+		}
+		if strings.Join(oldLines, ",") != strings.Join(a, ",") || strings.Join(newLines, ",") != strings.Join(b, ",") {
+			t.Fatalf("edit script does not reproduce %v -> %v", a, b)
+		}
+		if want := lcsLength(a, b); equal != want {
+			t.Fatalf("%v -> %v: kept %d lines, longest common subsequence has %d", a, b, equal, want)
+		}
+	}
+}
+
+func TestDiffOfLargeUnrelatedInputs(t *testing.T) {
+	// Keeping a copy of the frontier for every round would need over a gigabyte
+	a := make([]string, 5000)
+	b := make([]string, 5000)
+	for i := range a {
+		a[i] = fmt.Sprintf("old %d", i)
+		b[i] = fmt.Sprintf("new %d", i)
+	}
+	added, removed := Stat(Diff(a, b))
+	if added != len(b) || removed != len(a) {
+		t.Fatalf("expected every line to change, got +%d -%d", added, removed)
+	}
+}
+
+func lcsLength(a, b []string) int {
+	prev := make([]int, len(b)+1)
+	for i := range a {
+		cur := make([]int, len(b)+1)
+		for j := range b {
+			switch {
+			case a[i] == b[j]:
+				cur[j+1] = prev[j] + 1
+			case prev[j+1] > cur[j]:
+				cur[j+1] = prev[j+1]
+			default:
+				cur[j+1] = cur[j]
+			}
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+func TestLinesHandlesEmptySides(t *testing.T) {
+	if added, removed := Stat(Lines("", "x\ny\n")); added != 2 || removed != 0 {
+		t.Fatalf("expected 2 additions, got +%d -%d", added, removed)
+	}
+	if added, removed := Stat(Lines("x\ny\n", "")); added != 0 || removed != 2 {
+		t.Fatalf("expected 2 removals, got +%d -%d", added, removed)
+	}
+	if edits := Lines("same\n", "same\n"); len(Hunks(edits, 3)) != 0 {
+		t.Fatalf("expected no hunks for identical text")
+	}
+}
+
+func TestUnifiedMatchesDiffFormat(t *testing.T) {
+	oldText := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	newText := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n"
+
+	got := Unified("a/file", "b/file", Hunks(Lines(oldText, newText), 1))
+	want := "--- a/file\n+++ b/file\n" +
+		"@@ -2,3 +2,3 @@\n 2\n-3\n+three\n 4\n" +
+		"@@ -12 +12,2 @@\n 12\n+13\n"
+	if got != want {
+		t.Fatalf("unexpected unified diff:\n%s\nwant:\n%s", got, want)
+	}
+
+	merged := Hunks(Lines(oldText, newText), 5)
+	if len(merged) != 1 || merged[0].Header() != "@@ -1,12 +1,13 @@" {
+		t.Fatalf("expected one merged hunk, got %d (%v)", len(merged), merged)
+	}
+}
+
+func TestHunkRangesForPureInsertion(t *testing.T) {
+	hunks := Hunks(Lines("a\nb\n", "a\nx\nb\n"), 0)
+	if len(hunks) != 1 || hunks[0].Header() != "@@ -1,0 +2 @@" {
+		t.Fatalf("unexpected hunk: %v", hunks)
+	}
+}
+
+func TestIsBinary(t *testing.T) {
+	if IsBinary([]byte("plain text")) {
+		t.Fatalf("text reported as binary")
+	}
+	if !IsBinary([]byte{'P', 'K', 0, 1}) {
+		t.Fatalf("expected NUL byte to mark content as binary")
+	}
+}
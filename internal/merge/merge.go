@@ -0,0 +1,158 @@
+// Package merge performs line-based three-way merges of file contents. Regions
+// changed on only one side are taken from that side; regions both sides changed
+// differently are written out between conflict markers.
+package merge
+
+import (
+	"strings"
+
+	"github.com/niczy/gitslice/internal/diff"
+)
+
+// Conflict markers, as written by git's default merge style.
+const (
+	MarkerOurs   = "<<<<<<<"
+	MarkerSep    = "======="
+	MarkerTheirs = ">>>>>>>"
+)
+
+// Labels name the two sides of a merge in conflict markers.
+type Labels struct {
+	Ours   string
+	Theirs string
+}
+
+// Result is the outcome of a three-way merge. Content holds the merged text,
+// including conflict markers when Conflicts is non-zero.
+type Result struct {
+	Content   string
+	Conflicts int
+}
+
+// Clean reports whether the merge finished without conflicts.
+func (r Result) Clean() bool {
+	return r.Conflicts == 0
+}
+
+// Merge combines ours and theirs, two descendants of base. Lines keep their
+// newlines, so a missing newline at the end of a file survives the merge.
+func Merge(base, ours, theirs string, labels Labels) Result {
+	if labels.Ours == "" {
+		labels.Ours = "ours"
+	}
+	if labels.Theirs == "" {
+		labels.Theirs = "theirs"
+	}
+
+	baseLines, ourLines, theirLines := splitLines(base), splitLines(ours), splitLines(theirs)
+	ourMatch := matches(baseLines, ourLines)
+	theirMatch := matches(baseLines, theirLines)
+
+	var out strings.Builder
+	var result Result
+	i, j, k := 0, 0, 0
+	for {
+		// Copy lines all three versions agree on
+		for i < len(baseLines) && ourMatch[i] == j && theirMatch[i] == k {
+			out.WriteString(baseLines[i])
+			i, j, k = i+1, j+1, k+1
+		}
+		if i == len(baseLines) && j == len(ourLines) && k == len(theirLines) {
+			break
+		}
+
+		// The changed region runs up to the next base line both sides kept
+		nextBase, nextOurs, nextTheirs := len(baseLines), len(ourLines), len(theirLines)
+		for b := i; b < len(baseLines); b++ {
+			if ourMatch[b] >= 0 && theirMatch[b] >= 0 {
+				nextBase, nextOurs, nextTheirs = b, ourMatch[b], theirMatch[b]
+				break
+			}
+		}
+
+		baseRegion := baseLines[i:nextBase]
+		ourRegion := ourLines[j:nextOurs]
+		theirRegion := theirLines[k:nextTheirs]
+		switch {
+		case equal(ourRegion, baseRegion):
+			writeLines(&out, theirRegion)
+		case equal(theirRegion, baseRegion), equal(ourRegion, theirRegion):
+			writeLines(&out, ourRegion)
+		default:
+			result.Conflicts++
+			out.WriteString(MarkerOurs + " " + labels.Ours + "\n")
+			writeSide(&out, ourRegion)
+			out.WriteString(MarkerSep + "\n")
+			writeSide(&out, theirRegion)
+			out.WriteString(MarkerTheirs + " " + labels.Theirs + "\n")
+		}
+		i, j, k = nextBase, nextOurs, nextTheirs
+	}
+
+	result.Content = out.String()
+	return result
+}
+
+// HasMarkers reports whether text still contains an unresolved conflict.
+func HasMarkers(text string) bool {
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(line, MarkerOurs+" ") || strings.HasPrefix(line, MarkerTheirs+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// splitLines splits text after every newline, keeping the newlines.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// matches maps each base line to the index of the same line in other, or -1
+// when other deleted or replaced it.
+func matches(base, other []string) []int {
+	match := make([]int, len(base))
+	for i := range match {
+		match[i] = -1
+	}
+	for _, e := range diff.Diff(base, other) {
+		if e.Op == diff.OpEqual {
+			match[e.OldLine-1] = e.NewLine - 1
+		}
+	}
+	return match
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func writeLines(out *strings.Builder, lines []string) {
+	for _, line := range lines {
+		out.WriteString(line)
+	}
+}
+
+// writeSide writes one side of a conflict, terminating its last line so the
+// following marker starts on a line of its own.
+func writeSide(out *strings.Builder, lines []string) {
+	writeLines(out, lines)
+	if n := len(lines); n > 0 && !strings.HasSuffix(lines[n-1], "\n") {
+		out.WriteString("\n")
+	}
+}
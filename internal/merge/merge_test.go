@@ -0,0 +1,53 @@
+package merge
+
+import "testing"
+
+const base = "one\ntwo\nthree\nfour\nfive\n"
+
+func TestMergeCombinesSeparateChanges(t *testing.T) {
+	ours := "one\nTWO\nthree\nfour\nfive\n"
+	theirs := "one\ntwo\nthree\nfour\nFIVE\nsix\n"
+
+	result := Merge(base, ours, theirs, Labels{})
+	if !result.Clean() {
+		t.Fatalf("expected clean merge, got:\n%s", result.Content)
+	}
+	if want := "one\nTWO\nthree\nfour\nFIVE\nsix\n"; result.Content != want {
+		t.Fatalf("unexpected merge result:\n%s", result.Content)
+	}
+}
+
+func TestMergeTakesIdenticalChangesOnce(t *testing.T) {
+	changed := "zero\none\ntwo\nthree\nfour\n"
+	result := Merge(base, changed, changed, Labels{})
+	if !result.Clean() || result.Content != changed {
+		t.Fatalf("expected identical changes to merge cleanly, got:\n%s", result.Content)
+	}
+}
+
+func TestMergeMarksOverlappingChanges(t *testing.T) {
+	ours := "one\nmine\nthree\nfour\nfive\n"
+	theirs := "one\nyours\nthree\nfour\nfive"
+
+	result := Merge(base, ours, theirs, Labels{Ours: "changeset", Theirs: "slice"})
+	if result.Conflicts != 1 {
+		t.Fatalf("expected 1 conflict, got %d:\n%s", result.Conflicts, result.Content)
+	}
+	want := "one\n<<<<<<< changeset\nmine\n=======\nyours\n>>>>>>> slice\nthree\nfour\nfive"
+	if result.Content != want {
+		t.Fatalf("unexpected conflict output:\n%s", result.Content)
+	}
+	if !HasMarkers(result.Content) {
+		t.Fatalf("expected markers to be detected")
+	}
+}
+
+func TestMergeWithoutBaseConflictsOnDifferentContent(t *testing.T) {
+	result := Merge("", "a\n", "b\n", Labels{})
+	if result.Conflicts != 1 {
+		t.Fatalf("expected conflict when both sides add different content, got:\n%s", result.Content)
+	}
+	if HasMarkers("plain\ntext\n") {
+		t.Fatalf("did not expect markers in plain text")
+	}
+}
@@ -10,6 +10,7 @@ const (
 	ChangesetStatusApproved
 	ChangesetStatusRejected
 	ChangesetStatusMerged
+	ChangesetStatusAbandoned
 )
 
 // Changeset represents a change list submitted against a slice
@@ -18,10 +19,73 @@ type Changeset struct {
 	Hash           string
 	SliceID        string
 	BaseCommitHash string
+	TreeHash       string
 	ModifiedFiles  []string
 	Status         ChangesetStatus
 	Author         string
 	Message        string
 	CreatedAt      time.Time
 	MergedAt       *time.Time
+	Votes          []ReviewVote
+	AbandonReason  string
+	// ParentChangesetID names the changeset this one is stacked on, and
+	// ParentTreeHash the revision of the parent it was last stacked against.
+	ParentChangesetID string
+	ParentTreeHash    string
+	Checks            []StatusCheck
+}
+
+// CheckState is the result an external system reported for a status check
+type CheckState int
+
+const (
+	CheckStatePending CheckState = iota
+	CheckStateSuccess
+	CheckStateFailure
+)
+
+// StatusCheck is the latest result of a named validation, such as a CI run,
+// reported against a changeset. Reporting a check again replaces it.
+type StatusCheck struct {
+	Name        string
+	State       CheckState
+	Description string
+	TargetURL   string
+	UpdatedAt   time.Time
+}
+
+// MergeQueueEntry is a changeset waiting in its slice's merge queue. Entries
+// are merged in the order they were enqueued.
+type MergeQueueEntry struct {
+	SliceID     string
+	ChangesetID string
+	EnqueuedBy  string
+	EnqueuedAt  time.Time
+}
+
+// ReviewVote is a reviewer's approval or rejection of a changeset. Only the
+// latest vote from each reviewer counts.
+type ReviewVote struct {
+	Reviewer  string
+	Approved  bool
+	Comment   string
+	CreatedAt time.Time
+}
+
+// ReviewComment is feedback left on a changeset, anchored to a line of a file
+// in the changeset revision it was written against. Replies carry the ID of
+// the comment that opened the thread.
+type ReviewComment struct {
+	ID            string
+	ChangesetID   string
+	ChangesetHash string
+	Path          string
+	Line          int
+	ParentID      string
+	Author        string
+	Body          string
+	Resolved      bool
+	ResolvedBy    string
+	CreatedAt     time.Time
+	ResolvedAt    *time.Time
 }
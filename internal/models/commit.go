@@ -5,7 +5,11 @@ import "time"
 // Commit represents a single commit in a slice's history.
 type Commit struct {
 	CommitHash string    `json:"commit_hash"`
+	TreeHash   string    `json:"tree_hash,omitempty"`
 	ParentHash string    `json:"parent_hash"`
+	FoldedHash string    `json:"folded_hash,omitempty"` // head of a child slice this commit folded in
+	SliceID    string    `json:"slice_id,omitempty"`
+	Author     string    `json:"author,omitempty"`
 	Timestamp  time.Time `json:"timestamp"`
 	Message    string    `json:"message"`
 }
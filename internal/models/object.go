@@ -0,0 +1,35 @@
+package models
+
+// ObjectType identifies the kind of content-addressable object.
+type ObjectType int
+
+const (
+	ObjectTypeBlob ObjectType = iota
+	ObjectTypeTree
+	ObjectTypeCommit
+)
+
+// Tree entry modes, following git conventions.
+const (
+	TreeModeFile = "100644"
+	TreeModeDir  = "040000"
+)
+
+// Object is an immutable blob, tree or commit addressed by the SHA-256 of its data.
+type Object struct {
+	Type ObjectType `json:"type"`
+	Hash string     `json:"hash"`
+	Data []byte     `json:"data"`
+}
+
+// TreeEntry is a single named child of a tree: either a blob or a subtree.
+type TreeEntry struct {
+	Name string `json:"name"`
+	Mode string `json:"mode"`
+	Hash string `json:"hash"`
+}
+
+// Tree represents a directory listing.
+type Tree struct {
+	Entries []TreeEntry `json:"entries"`
+}
@@ -1,19 +1,62 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/niczy/gitslice/internal/pathspec"
+)
 
 // Slice represents a slice in the system
 type Slice struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Files       []string  `json:"files"`
-	Owners      []string  `json:"owners"`
-	CreatedBy   string    `json:"created_by"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	ParentSlice string    `json:"parent_slice,omitempty"`
-	IsRoot      bool      `json:"is_root,omitempty"`
+	ID           string       `json:"id"`
+	Name         string       `json:"name"`
+	Description  string       `json:"description"`
+	Files        []string     `json:"files"`
+	Owners       []string     `json:"owners"`
+	CreatedBy    string       `json:"created_by"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+	ParentSlice  string       `json:"parent_slice,omitempty"`
+	IsRoot       bool         `json:"is_root,omitempty"`
+	ApprovalRule ApprovalRule `json:"approval_rule"`
+	// RequiredChecks names the status checks that must succeed before a
+	// changeset can merge.
+	RequiredChecks []string `json:"required_checks,omitempty"`
+	// Include and Exclude define the slice by path patterns: globs, where
+	// "**" spans directories, or directory prefixes. They add to Files.
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+	// Archived slices keep their history but claim no files and accept no
+	// new changesets.
+	Archived bool `json:"archived,omitempty"`
+}
+
+// PathSpec returns the paths the slice claims, from its files and patterns.
+// Archived slices claim nothing.
+func (s *Slice) PathSpec() pathspec.Spec {
+	if s.Archived {
+		return pathspec.Spec{}
+	}
+	return pathspec.Spec{Files: s.Files, Include: s.Include, Exclude: s.Exclude}
+}
+
+// Parent returns the slice this one hangs under: its recorded parent, or the
+// root slice for slices created without one. The root has no parent.
+func (s *Slice) Parent(rootID string) string {
+	if s.IsRoot || s.ID == rootID {
+		return ""
+	}
+	if s.ParentSlice != "" {
+		return s.ParentSlice
+	}
+	return rootID
+}
+
+// ApprovalRule requires a number of approvals before a changeset can merge.
+// When OwnersOnly is set, only votes from the slice's owners count.
+type ApprovalRule struct {
+	RequiredApprovals int  `json:"required_approvals,omitempty"`
+	OwnersOnly        bool `json:"owners_only,omitempty"`
 }
 
 // SliceMetadata represents slice metadata
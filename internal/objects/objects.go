@@ -0,0 +1,362 @@
+// Package objects implements the content-addressable encoding shared by the
+// server and the CLI. Every object is hashed as SHA-256 over a short type
+// header followed by its data, so a blob, tree and commit can never collide
+// even when their payloads are identical.
+package objects
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/niczy/gitslice/internal/models"
+)
+
+var (
+	ErrHashMismatch  = errors.New("object hash mismatch")
+	ErrInvalidObject = errors.New("invalid object")
+)
+
+// TypeName returns the header name used when hashing an object of the given type.
+func TypeName(t models.ObjectType) (string, error) {
+	switch t {
+	case models.ObjectTypeBlob:
+		return "blob", nil
+	case models.ObjectTypeTree:
+		return "tree", nil
+	case models.ObjectTypeCommit:
+		return "commit", nil
+	default:
+		return "", fmt.Errorf("%w: unsupported object type %d", ErrInvalidObject, t)
+	}
+}
+
+// Envelope returns the bytes that are hashed and persisted for an object:
+// "<type> <len>\x00<data>".
+func Envelope(t models.ObjectType, data []byte) ([]byte, error) {
+	name, err := TypeName(t)
+	if err != nil {
+		return nil, err
+	}
+
+	header := fmt.Sprintf("%s %d\x00", name, len(data))
+	buf := make([]byte, 0, len(header)+len(data))
+	buf = append(buf, header...)
+	buf = append(buf, data...)
+	return buf, nil
+}
+
+// MaxHeaderSize is the longest envelope header, so reading that many bytes
+// from the start of an envelope is enough to parse it.
+const MaxHeaderSize = len("commit ") + 20 + 1
+
+// ParseHeader reads an object's type and data size from the start of its
+// envelope, which may be cut off after the header.
+func ParseHeader(raw []byte) (models.ObjectType, int64, error) {
+	objType, size, _, err := parseHeader(raw)
+	return objType, size, err
+}
+
+// ParseEnvelope splits persisted object bytes back into a typed object.
+func ParseEnvelope(raw []byte) (*models.Object, error) {
+	objType, size, start, err := parseHeader(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	data := raw[start:]
+	if size != int64(len(data)) {
+		return nil, fmt.Errorf("%w: size mismatch", ErrInvalidObject)
+	}
+
+	sum := sha256.Sum256(raw)
+	return &models.Object{Type: objType, Hash: hex.EncodeToString(sum[:]), Data: data}, nil
+}
+
+// parseHeader also returns where the data starts.
+func parseHeader(raw []byte) (models.ObjectType, int64, int, error) {
+	nul := bytes.IndexByte(raw, 0)
+	if nul < 0 {
+		return 0, 0, 0, fmt.Errorf("%w: missing header", ErrInvalidObject)
+	}
+
+	name, size, ok := strings.Cut(string(raw[:nul]), " ")
+	if !ok {
+		return 0, 0, 0, fmt.Errorf("%w: malformed header", ErrInvalidObject)
+	}
+
+	var objType models.ObjectType
+	switch name {
+	case "blob":
+		objType = models.ObjectTypeBlob
+	case "tree":
+		objType = models.ObjectTypeTree
+	case "commit":
+		objType = models.ObjectTypeCommit
+	default:
+		return 0, 0, 0, fmt.Errorf("%w: unknown type %q", ErrInvalidObject, name)
+	}
+
+	n, err := strconv.ParseInt(size, 10, 64)
+	if err != nil || n < 0 {
+		return 0, 0, 0, fmt.Errorf("%w: size mismatch", ErrInvalidObject)
+	}
+	return objType, n, nul + 1, nil
+}
+
+// Hash computes the content address of an object.
+func Hash(t models.ObjectType, data []byte) (string, error) {
+	raw, err := Envelope(t, data)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// HashBlob computes the content address of file content.
+func HashBlob(data []byte) string {
+	hash, _ := Hash(models.ObjectTypeBlob, data)
+	return hash
+}
+
+// Validate checks that an object is well formed and that its hash matches its
+// content. An empty hash is filled in rather than rejected.
+func Validate(obj *models.Object) error {
+	hash, err := Hash(obj.Type, obj.Data)
+	if err != nil {
+		return err
+	}
+
+	if obj.Hash == "" {
+		obj.Hash = hash
+	} else if obj.Hash != hash {
+		return fmt.Errorf("%w: expected %s, computed %s", ErrHashMismatch, obj.Hash, hash)
+	}
+
+	switch obj.Type {
+	case models.ObjectTypeTree:
+		_, err = DecodeTree(obj.Data)
+	case models.ObjectTypeCommit:
+		_, err = DecodeCommit(obj.Hash, obj.Data)
+	}
+	return err
+}
+
+// IsHash reports whether s looks like a hex-encoded SHA-256 digest.
+func IsHash(s string) bool {
+	if len(s) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// EncodeTree serializes a tree canonically: entries sorted by name, one
+// "<mode> <hash>\t<name>" line each. Identical trees therefore always hash the same.
+func EncodeTree(tree *models.Tree) ([]byte, error) {
+	entries := make([]models.TreeEntry, len(tree.Entries))
+	copy(entries, tree.Entries)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+
+	var buf bytes.Buffer
+	for i, entry := range entries {
+		if err := validateTreeEntry(entry); err != nil {
+			return nil, err
+		}
+		if i > 0 && entries[i-1].Name == entry.Name {
+			return nil, fmt.Errorf("%w: duplicate tree entry %q", ErrInvalidObject, entry.Name)
+		}
+		fmt.Fprintf(&buf, "%s %s\t%s\n", entry.Mode, entry.Hash, entry.Name)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// DecodeTree parses a tree produced by EncodeTree. Non-canonical encodings are
+// rejected so a tree has exactly one valid hash.
+func DecodeTree(data []byte) (*models.Tree, error) {
+	tree := &models.Tree{Entries: []models.TreeEntry{}}
+	if len(data) == 0 {
+		return tree, nil
+	}
+
+	text := string(data)
+	if !strings.HasSuffix(text, "\n") {
+		return nil, fmt.Errorf("%w: tree must end with a newline", ErrInvalidObject)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		meta, name, ok := strings.Cut(line, "\t")
+		if !ok {
+			return nil, fmt.Errorf("%w: malformed tree entry %q", ErrInvalidObject, line)
+		}
+		mode, hash, ok := strings.Cut(meta, " ")
+		if !ok {
+			return nil, fmt.Errorf("%w: malformed tree entry %q", ErrInvalidObject, line)
+		}
+		tree.Entries = append(tree.Entries, models.TreeEntry{Name: name, Mode: mode, Hash: hash})
+	}
+
+	canonical, err := EncodeTree(tree)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(canonical, data) {
+		return nil, fmt.Errorf("%w: tree entries are not in canonical order", ErrInvalidObject)
+	}
+
+	return tree, nil
+}
+
+func validateTreeEntry(entry models.TreeEntry) error {
+	if entry.Name == "" || entry.Name == "." || entry.Name == ".." || strings.ContainsAny(entry.Name, "/\t\n\x00") {
+		return fmt.Errorf("%w: invalid tree entry name %q", ErrInvalidObject, entry.Name)
+	}
+	if entry.Mode != models.TreeModeFile && entry.Mode != models.TreeModeDir {
+		return fmt.Errorf("%w: invalid mode %q for %s", ErrInvalidObject, entry.Mode, entry.Name)
+	}
+	if !IsHash(entry.Hash) {
+		return fmt.Errorf("%w: invalid hash for %s", ErrInvalidObject, entry.Name)
+	}
+	return nil
+}
+
+// EncodeCommit serializes a commit as a header block followed by the message.
+// The commit's own hash is not part of its content.
+func EncodeCommit(commit *models.Commit) ([]byte, error) {
+	if !IsHash(commit.TreeHash) {
+		return nil, fmt.Errorf("%w: commit requires a tree hash", ErrInvalidObject)
+	}
+	for _, field := range []string{commit.ParentHash, commit.FoldedHash, commit.SliceID, commit.Author} {
+		if strings.ContainsAny(field, "\n\x00") {
+			return nil, fmt.Errorf("%w: commit header contains a newline", ErrInvalidObject)
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "tree %s\n", commit.TreeHash)
+	if commit.ParentHash != "" {
+		fmt.Fprintf(&buf, "parent %s\n", commit.ParentHash)
+	}
+	if commit.FoldedHash != "" {
+		fmt.Fprintf(&buf, "folded %s\n", commit.FoldedHash)
+	}
+	fmt.Fprintf(&buf, "slice %s\n", commit.SliceID)
+	fmt.Fprintf(&buf, "author %s\n", commit.Author)
+	fmt.Fprintf(&buf, "timestamp %d\n", commit.Timestamp.UnixNano())
+	buf.WriteString("\n")
+	buf.WriteString(commit.Message)
+
+	return buf.Bytes(), nil
+}
+
+// DecodeCommit parses a commit produced by EncodeCommit.
+func DecodeCommit(hash string, data []byte) (*models.Commit, error) {
+	headers, message, ok := strings.Cut(string(data), "\n\n")
+	if !ok {
+		return nil, fmt.Errorf("%w: commit is missing a message separator", ErrInvalidObject)
+	}
+
+	commit := &models.Commit{CommitHash: hash, Message: message}
+	for _, line := range strings.Split(headers, "\n") {
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "tree":
+			commit.TreeHash = value
+		case "parent":
+			commit.ParentHash = value
+		case "folded":
+			commit.FoldedHash = value
+		case "slice":
+			commit.SliceID = value
+		case "author":
+			commit.Author = value
+		case "timestamp":
+			nanos, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid commit timestamp", ErrInvalidObject)
+			}
+			commit.Timestamp = time.Unix(0, nanos)
+		default:
+			return nil, fmt.Errorf("%w: unknown commit header %q", ErrInvalidObject, key)
+		}
+	}
+
+	if !IsHash(commit.TreeHash) {
+		return nil, fmt.Errorf("%w: commit requires a tree hash", ErrInvalidObject)
+	}
+
+	return commit, nil
+}
+
+// BuildSnapshot encodes the nested trees for a flat map of clean,
+// slash-separated paths to blob hashes. Trees are returned children first so
+// they can be stored or uploaded in order; the last one is the root.
+func BuildSnapshot(files map[string]string) (string, []*models.Object, error) {
+	type dir struct {
+		files map[string]string
+		dirs  map[string]*dir
+	}
+	newDir := func() *dir { return &dir{files: map[string]string{}, dirs: map[string]*dir{}} }
+
+	root := newDir()
+	for filePath, blobHash := range files {
+		parts := strings.Split(filePath, "/")
+		current := root
+		for _, part := range parts[:len(parts)-1] {
+			if _, isFile := current.files[part]; isFile {
+				return "", nil, fmt.Errorf("%w: %s is both a file and a directory", ErrInvalidObject, part)
+			}
+			next, ok := current.dirs[part]
+			if !ok {
+				next = newDir()
+				current.dirs[part] = next
+			}
+			current = next
+		}
+		name := parts[len(parts)-1]
+		if _, isDir := current.dirs[name]; isDir {
+			return "", nil, fmt.Errorf("%w: %s is both a file and a directory", ErrInvalidObject, filePath)
+		}
+		current.files[name] = blobHash
+	}
+
+	var trees []*models.Object
+	var encode func(d *dir) (string, error)
+	encode = func(d *dir) (string, error) {
+		tree := &models.Tree{}
+		for name, hash := range d.files {
+			tree.Entries = append(tree.Entries, models.TreeEntry{Name: name, Mode: models.TreeModeFile, Hash: hash})
+		}
+		for name, child := range d.dirs {
+			hash, err := encode(child)
+			if err != nil {
+				return "", err
+			}
+			tree.Entries = append(tree.Entries, models.TreeEntry{Name: name, Mode: models.TreeModeDir, Hash: hash})
+		}
+		data, err := EncodeTree(tree)
+		if err != nil {
+			return "", err
+		}
+		hash, err := Hash(models.ObjectTypeTree, data)
+		if err != nil {
+			return "", err
+		}
+		trees = append(trees, &models.Object{Type: models.ObjectTypeTree, Hash: hash, Data: data})
+		return hash, nil
+	}
+
+	rootHash, err := encode(root)
+	if err != nil {
+		return "", nil, err
+	}
+	return rootHash, trees, nil
+}
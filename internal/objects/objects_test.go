@@ -0,0 +1,110 @@
+package objects
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/niczy/gitslice/internal/models"
+)
+
+func TestIdenticalTreesHashTheSame(t *testing.T) {
+	blobA := HashBlob([]byte("a"))
+	blobB := HashBlob([]byte("b"))
+
+	first, err := EncodeTree(&models.Tree{Entries: []models.TreeEntry{
+		{Name: "b.txt", Mode: models.TreeModeFile, Hash: blobB},
+		{Name: "a.txt", Mode: models.TreeModeFile, Hash: blobA},
+	}})
+	if err != nil {
+		t.Fatalf("EncodeTree failed: %v", err)
+	}
+	second, err := EncodeTree(&models.Tree{Entries: []models.TreeEntry{
+		{Name: "a.txt", Mode: models.TreeModeFile, Hash: blobA},
+		{Name: "b.txt", Mode: models.TreeModeFile, Hash: blobB},
+	}})
+	if err != nil {
+		t.Fatalf("EncodeTree failed: %v", err)
+	}
+
+	h1, _ := Hash(models.ObjectTypeTree, first)
+	h2, _ := Hash(models.ObjectTypeTree, second)
+	if h1 != h2 {
+		t.Fatalf("expected identical trees to share a hash, got %s and %s", h1, h2)
+	}
+
+	decoded, err := DecodeTree(first)
+	if err != nil || len(decoded.Entries) != 2 || decoded.Entries[0].Name != "a.txt" {
+		t.Fatalf("DecodeTree mismatch: %+v err=%v", decoded, err)
+	}
+}
+
+func TestEmptyBlobAndTreeDoNotCollide(t *testing.T) {
+	blob, _ := Hash(models.ObjectTypeBlob, nil)
+	tree, _ := Hash(models.ObjectTypeTree, nil)
+	if blob == tree {
+		t.Fatalf("expected type header to separate blob and tree hashes")
+	}
+}
+
+func TestValidateRejectsWrongHash(t *testing.T) {
+	obj := &models.Object{Type: models.ObjectTypeBlob, Hash: HashBlob([]byte("other")), Data: []byte("content")}
+	if err := Validate(obj); !errors.Is(err, ErrHashMismatch) {
+		t.Fatalf("expected ErrHashMismatch, got %v", err)
+	}
+
+	obj = &models.Object{Type: models.ObjectTypeBlob, Data: []byte("content")}
+	if err := Validate(obj); err != nil {
+		t.Fatalf("expected empty hash to be filled in, got %v", err)
+	}
+	if obj.Hash != HashBlob([]byte("content")) {
+		t.Fatalf("unexpected computed hash %s", obj.Hash)
+	}
+
+	bad := &models.Object{Type: models.ObjectTypeTree, Data: []byte("not a tree")}
+	if err := Validate(bad); !errors.Is(err, ErrInvalidObject) {
+		t.Fatalf("expected ErrInvalidObject for malformed tree, got %v", err)
+	}
+}
+
+func TestParseHeaderOfTruncatedEnvelope(t *testing.T) {
+	raw, err := Envelope(models.ObjectTypeBlob, make([]byte, 12345))
+	if err != nil {
+		t.Fatalf("Envelope failed: %v", err)
+	}
+	objType, size, err := ParseHeader(raw[:MaxHeaderSize])
+	if err != nil || objType != models.ObjectTypeBlob || size != 12345 {
+		t.Fatalf("unexpected header: type=%d size=%d err=%v", objType, size, err)
+	}
+	if _, _, err := ParseHeader([]byte("blob 12")); !errors.Is(err, ErrInvalidObject) {
+		t.Fatalf("expected ErrInvalidObject for a header cut short, got %v", err)
+	}
+}
+
+func TestCommitRoundTrip(t *testing.T) {
+	tree, _ := Hash(models.ObjectTypeTree, nil)
+	commit := &models.Commit{
+		TreeHash:   tree,
+		ParentHash: HashBlob([]byte("parent")),
+		FoldedHash: HashBlob([]byte("folded")),
+		SliceID:    "slice-1",
+		Author:     "alice",
+		Timestamp:  time.Unix(0, 1700000000123456789),
+		Message:    "first line\n\nbody",
+	}
+
+	data, err := EncodeCommit(commit)
+	if err != nil {
+		t.Fatalf("EncodeCommit failed: %v", err)
+	}
+	decoded, err := DecodeCommit("h", data)
+	if err != nil {
+		t.Fatalf("DecodeCommit failed: %v", err)
+	}
+
+	if decoded.TreeHash != commit.TreeHash || decoded.ParentHash != commit.ParentHash || decoded.FoldedHash != commit.FoldedHash ||
+		decoded.SliceID != commit.SliceID || decoded.Author != commit.Author ||
+		!decoded.Timestamp.Equal(commit.Timestamp) || decoded.Message != commit.Message {
+		t.Fatalf("commit round trip mismatch: %+v", decoded)
+	}
+}
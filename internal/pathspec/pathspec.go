@@ -0,0 +1,235 @@
+// Package pathspec matches repository paths against slice definitions. A
+// definition lists literal files plus include and exclude patterns; a pattern
+// is either a glob, where "**" stands for any number of directories, or a
+// directory prefix covering everything below it.
+package pathspec
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Spec is the set of paths a slice claims: its literal files, and the paths
+// matched by an include pattern and by no exclude pattern. Excludes never
+// remove a file listed literally.
+type Spec struct {
+	Files   []string
+	Include []string
+	Exclude []string
+}
+
+// Overlap is a pair of patterns, one from each spec, that can match the same
+// path. Literal files appear as patterns of their own.
+type Overlap struct {
+	Pattern      string
+	OtherPattern string
+}
+
+// Validate reports whether a pattern is well formed: a relative path that
+// stays inside the repository, whose segments are valid globs and which uses
+// "**" only as a whole segment.
+func Validate(pattern string) error {
+	_, err := compile(pattern)
+	return err
+}
+
+// HasPatterns reports whether the spec claims paths beyond its literal files.
+func (s Spec) HasPatterns() bool {
+	return len(s.Include) > 0
+}
+
+// Match reports whether the spec claims a path. Invalid patterns match
+// nothing.
+func (s Spec) Match(p string) bool {
+	p = strings.TrimPrefix(p, "/")
+	for _, file := range s.Files {
+		if strings.TrimPrefix(file, "/") == p {
+			return true
+		}
+	}
+
+	segs := strings.Split(p, "/")
+	included := false
+	for _, pattern := range s.Include {
+		if compiled, err := compile(pattern); err == nil && matchSegments(compiled, segs) {
+			included = true
+			break
+		}
+	}
+	if !included {
+		return false
+	}
+	for _, pattern := range s.Exclude {
+		if compiled, err := compile(pattern); err == nil && matchSegments(compiled, segs) {
+			return false
+		}
+	}
+	return true
+}
+
+// Overlaps returns the pattern pairs through which both specs can claim the
+// same path. The analysis is conservative: two globs are assumed to meet
+// unless their fixed parts rule it out, and a pair is dropped only when an
+// exclude of either spec covers one of its patterns entirely.
+func Overlaps(a, b Spec) []Overlap {
+	var overlaps []Overlap
+	for _, ours := range a.terms() {
+		for _, theirs := range b.terms() {
+			if !intersect(ours.segs, theirs.segs) {
+				continue
+			}
+			if a.excludes(ours, theirs) || b.excludes(ours, theirs) {
+				continue
+			}
+			overlaps = append(overlaps, Overlap{Pattern: ours.text, OtherPattern: theirs.text})
+		}
+	}
+	return overlaps
+}
+
+type term struct {
+	text string
+	segs []string
+	// literal terms are files listed by name, which excludes leave alone
+	literal bool
+}
+
+func (s Spec) terms() []term {
+	var terms []term
+	for _, file := range s.Files {
+		clean := strings.TrimPrefix(file, "/")
+		terms = append(terms, term{text: clean, segs: strings.Split(clean, "/"), literal: true})
+	}
+	for _, pattern := range s.Include {
+		if compiled, err := compile(pattern); err == nil {
+			terms = append(terms, term{text: pattern, segs: compiled})
+		}
+	}
+	return terms
+}
+
+// excludes reports whether one of the spec's excludes removes every path the
+// two terms could share.
+func (s Spec) excludes(ours, theirs term) bool {
+	for _, pattern := range s.Exclude {
+		compiled, err := compile(pattern)
+		if err != nil {
+			continue
+		}
+		if (!ours.literal && covers(compiled, ours.segs)) || (!theirs.literal && covers(compiled, theirs.segs)) {
+			return true
+		}
+	}
+	return false
+}
+
+// compile splits a pattern into segments. Patterns without wildcards, and
+// those ending in a slash, are directory prefixes and gain a trailing "**" so
+// they match the path itself and everything below it.
+func compile(pattern string) ([]string, error) {
+	trimmed := strings.TrimPrefix(pattern, "/")
+	prefix := strings.HasSuffix(trimmed, "/") || !hasMeta(trimmed)
+	clean := path.Clean(trimmed)
+	if trimmed == "" || clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+		return nil, fmt.Errorf("invalid pattern %q", pattern)
+	}
+
+	segs := strings.Split(clean, "/")
+	for _, seg := range segs {
+		if seg == "**" {
+			continue
+		}
+		if strings.Contains(seg, "**") {
+			return nil, fmt.Errorf("invalid pattern %q: ** must be a whole path segment", pattern)
+		}
+		if _, err := path.Match(seg, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %v", pattern, err)
+		}
+	}
+	if prefix && segs[len(segs)-1] != "**" {
+		segs = append(segs, "**")
+	}
+	return segs, nil
+}
+
+func hasMeta(s string) bool {
+	return strings.ContainsAny(s, "*?[")
+}
+
+func matchSegments(pattern, segs []string) bool {
+	if len(pattern) == 0 {
+		return len(segs) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segs); i++ {
+			if matchSegments(pattern[1:], segs[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segs) == 0 {
+		return false
+	}
+	ok, err := path.Match(pattern[0], segs[0])
+	return err == nil && ok && matchSegments(pattern[1:], segs[1:])
+}
+
+// intersect reports whether some path can match both segment patterns.
+func intersect(a, b []string) bool {
+	if len(a) > 0 && a[0] == "**" {
+		return intersect(a[1:], b) || (len(b) > 0 && intersect(a, b[1:]))
+	}
+	if len(b) > 0 && b[0] == "**" {
+		return intersect(a, b[1:]) || (len(a) > 0 && intersect(a[1:], b))
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	return segmentsMeet(a[0], b[0]) && intersect(a[1:], b[1:])
+}
+
+// segmentsMeet reports whether a single path segment can match both globs.
+// When both contain wildcards only their fixed prefixes and suffixes are
+// compared.
+func segmentsMeet(a, b string) bool {
+	switch {
+	case a == b:
+		return true
+	case !hasMeta(a):
+		ok, _ := path.Match(b, a)
+		return ok
+	case !hasMeta(b):
+		ok, _ := path.Match(a, b)
+		return ok
+	}
+	prefixA, prefixB := a[:strings.IndexAny(a, "*?[")], b[:strings.IndexAny(b, "*?[")]
+	suffixA, suffixB := a[strings.LastIndexAny(a, "*?]")+1:], b[strings.LastIndexAny(b, "*?]")+1:]
+	return (strings.HasPrefix(prefixA, prefixB) || strings.HasPrefix(prefixB, prefixA)) &&
+		(strings.HasSuffix(suffixA, suffixB) || strings.HasSuffix(suffixB, suffixA))
+}
+
+// covers reports whether every path matching inner also matches outer. Only
+// the shapes that matter for excludes are recognized: outer may widen a
+// segment to "*" or end in "**"; anything else must match segment for segment.
+func covers(outer, inner []string) bool {
+	for i, seg := range outer {
+		if seg == "**" && i == len(outer)-1 {
+			return true
+		}
+		if i >= len(inner) || inner[i] == "**" || seg == "**" {
+			return false
+		}
+		if seg == inner[i] || seg == "*" {
+			continue
+		}
+		if hasMeta(inner[i]) {
+			return false
+		}
+		if ok, _ := path.Match(seg, inner[i]); !ok {
+			return false
+		}
+	}
+	return len(outer) == len(inner)
+}
@@ -0,0 +1,74 @@
+package pathspec
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	spec := Spec{
+		Files:   []string{"tools/release.sh", "services/payments/legacy_test.go"},
+		Include: []string{"services/payments", "libs/*/money/", "**/*.proto"},
+		Exclude: []string{"**/*_test.go", "services/payments/vendor/"},
+	}
+
+	cases := map[string]bool{
+		"services/payments":                     true,
+		"services/payments/api/handler.go":      true,
+		"services/payments/api/handler_test.go": false,
+		"services/payments/legacy_test.go":      true,
+		"services/payments/vendor/lib.go":       false,
+		"services/paymentsx/main.go":            false,
+		"libs/go/money/amount.go":               true,
+		"libs/go/time/clock.go":                 false,
+		"api/v1/payments.proto":                 true,
+		"payments.proto":                        true,
+		"tools/release.sh":                      true,
+		"tools/build.sh":                        false,
+	}
+	for p, want := range cases {
+		if got := spec.Match(p); got != want {
+			t.Errorf("Match(%q) = %v, want %v", p, got, want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, pattern := range []string{"services/payments", "services/payments/", "**/*.go", "libs/[a-m]*/"} {
+		if err := Validate(pattern); err != nil {
+			t.Errorf("Validate(%q) returned %v", pattern, err)
+		}
+	}
+	for _, pattern := range []string{"", "/", "..", "../outside", "services/**.go", "libs/[a-"} {
+		if err := Validate(pattern); err == nil {
+			t.Errorf("Validate(%q) accepted an invalid pattern", pattern)
+		}
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	payments := Spec{Include: []string{"services/payments/"}, Exclude: []string{"services/payments/docs/"}}
+
+	cases := []struct {
+		name  string
+		other Spec
+		want  []Overlap
+	}{
+		{"nested prefix", Spec{Include: []string{"services/payments/api"}}, []Overlap{{"services/payments/", "services/payments/api"}}},
+		{"sibling prefix", Spec{Include: []string{"services/billing/"}}, nil},
+		{"glob across directories", Spec{Include: []string{"**/*.go"}}, []Overlap{{"services/payments/", "**/*.go"}}},
+		{"excluded directory", Spec{Include: []string{"services/payments/docs/**/*.md"}}, nil},
+		{"excluded by the other spec", Spec{Include: []string{"services/"}, Exclude: []string{"services/payments"}}, nil},
+		{"literal file", Spec{Files: []string{"services/payments/main.go"}}, []Overlap{{"services/payments/", "services/payments/main.go"}}},
+		{"disjoint globs", Spec{Include: []string{"services/*.md"}}, nil},
+	}
+	for _, tc := range cases {
+		got := Overlaps(payments, tc.other)
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: Overlaps = %v, want %v", tc.name, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("%s: Overlaps = %v, want %v", tc.name, got, tc.want)
+			}
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/niczy/gitslice/internal/models"
+	"github.com/niczy/gitslice/internal/pathspec"
 	"github.com/niczy/gitslice/internal/storage"
 	adminv1 "github.com/niczy/gitslice/proto/admin"
 	"google.golang.org/grpc"
@@ -48,7 +49,7 @@ func (s *adminServiceServer) BatchMerge(ctx context.Context, req *adminv1.BatchM
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to load root slice: %v", err))
 	}
 
-	conflicts, err := s.storage.ListConflicts(ctx)
+	conflicts, err := s.listConflicts(ctx)
 	if err != nil {
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list conflicts: %v", err))
 	}
@@ -178,6 +179,19 @@ func (s *adminServiceServer) CreateSlice(ctx context.Context, req *adminv1.Creat
 	if req.SliceId == "" {
 		return nil, status.Error(codes.InvalidArgument, "slice_id is required")
 	}
+	if req.RequiredApprovals < 0 {
+		return nil, status.Error(codes.InvalidArgument, "required_approvals cannot be negative")
+	}
+	for _, name := range req.RequiredChecks {
+		if name == "" {
+			return nil, status.Error(codes.InvalidArgument, "required check names cannot be empty")
+		}
+	}
+	for _, pattern := range append(append([]string{}, req.IncludePatterns...), req.ExcludePatterns...) {
+		if err := pathspec.Validate(pattern); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
 
 	// Create slice model
 	slice := &models.Slice{
@@ -187,6 +201,18 @@ func (s *adminServiceServer) CreateSlice(ctx context.Context, req *adminv1.Creat
 		Files:       req.Files,
 		Owners:      req.Owners,
 		CreatedBy:   req.CreatedBy,
+		ApprovalRule: models.ApprovalRule{
+			RequiredApprovals: int(req.RequiredApprovals),
+			OwnersOnly:        req.OwnerApprovalsOnly,
+		},
+		RequiredChecks: req.RequiredChecks,
+		Include:        req.IncludePatterns,
+		Exclude:        req.ExcludePatterns,
+	}
+
+	overlaps, err := s.sliceOverlaps(ctx, slice)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to analyze overlaps: %v", err))
 	}
 
 	// Store slice
@@ -195,11 +221,37 @@ func (s *adminServiceServer) CreateSlice(ctx context.Context, req *adminv1.Creat
 	}
 
 	return &adminv1.CreateSliceResponse{
-		SliceId: req.SliceId,
-		Status:  "created",
+		SliceId:  req.SliceId,
+		Status:   "created",
+		Overlaps: overlaps,
 	}, nil
 }
 
+// sliceOverlaps compares a slice's files and patterns with those of every
+// other slice and reports the pairs that can claim the same paths.
+func (s *adminServiceServer) sliceOverlaps(ctx context.Context, slice *models.Slice) ([]*adminv1.SliceOverlap, error) {
+	existing, err := s.storage.ListSlices(ctx, int(^uint(0)>>1), 0)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(existing, func(i, j int) bool { return existing[i].ID < existing[j].ID })
+
+	var overlaps []*adminv1.SliceOverlap
+	for _, other := range existing {
+		if other.IsRoot || other.ID == slice.ID {
+			continue
+		}
+		for _, overlap := range pathspec.Overlaps(slice.PathSpec(), other.PathSpec()) {
+			overlaps = append(overlaps, &adminv1.SliceOverlap{
+				SliceId:      other.ID,
+				Pattern:      overlap.Pattern,
+				OtherPattern: overlap.OtherPattern,
+			})
+		}
+	}
+	return overlaps, nil
+}
+
 func (s *adminServiceServer) ListSlices(ctx context.Context, req *adminv1.ListSlicesRequest) (*adminv1.ListSlicesResponse, error) {
 	log.Printf("ListSlices called: limit=%d, offset=%d", req.Limit, req.Offset)
 
@@ -218,6 +270,7 @@ func (s *adminServiceServer) ListSlices(ctx context.Context, req *adminv1.ListSl
 			LatestCommitHash:   metadata.HeadCommitHash,
 			ModifiedFilesCount: int32(metadata.ModifiedFilesCount),
 			LastModified:       metadata.LastModified.Unix(),
+			Archived:           slice.Archived,
 		})
 	}
 
@@ -226,10 +279,21 @@ func (s *adminServiceServer) ListSlices(ctx context.Context, req *adminv1.ListSl
 	}, nil
 }
 
+// listConflicts returns the files whose claiming slices changed them in
+// different ways. Slices that merely share a file in the index, or made
+// identical edits to it, are not in conflict.
+func (s *adminServiceServer) listConflicts(ctx context.Context) ([]*models.FileConflict, error) {
+	candidates, err := s.storage.ListConflicts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return storage.NewConflictDetector(s.storage).Filter(ctx, candidates)
+}
+
 func (s *adminServiceServer) GetConflicts(ctx context.Context, req *adminv1.ConflictsRequest) (*adminv1.ConflictsResponse, error) {
 	log.Printf("GetConflicts called: slice_id=%v", req.SliceId)
 
-	conflicts, err := s.storage.ListConflicts(ctx)
+	conflicts, err := s.listConflicts(ctx)
 	if err != nil {
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list conflicts: %v", err))
 	}
@@ -311,7 +375,7 @@ func (s *adminServiceServer) GetGlobalState(ctx context.Context, req *adminv1.Gl
 func (s *adminServiceServer) WatchConflicts(req *adminv1.WatchConflictsRequest, stream adminv1.AdminService_WatchConflictsServer) error {
 	log.Printf("WatchConflicts called: slice_id=%v", req.SliceId)
 
-	conflicts, err := s.storage.ListConflicts(stream.Context())
+	conflicts, err := s.listConflicts(stream.Context())
 	if err != nil {
 		return status.Error(codes.Internal, fmt.Sprintf("failed to list conflicts: %v", err))
 	}
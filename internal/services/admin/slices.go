@@ -0,0 +1,331 @@
+package adminservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/niczy/gitslice/internal/models"
+	"github.com/niczy/gitslice/internal/objects"
+	"github.com/niczy/gitslice/internal/storage"
+	adminv1 "github.com/niczy/gitslice/proto/admin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// handoff describes what a deleted or archived slice passes on: its files and
+// child slices, which go to its heir. batch holds the writes that hand them
+// over; the caller adds the slice's own removal and commits it.
+type handoff struct {
+	heir     string
+	files    []string
+	children []string
+	batch    *storage.Batch
+}
+
+// successor returns the slice that inherits from a slice: its parent, or the
+// root slice when it has no parent or the parent is gone. It also returns the
+// slice's children, sorted by ID. An archived heir cannot take anything on.
+func (s *adminServiceServer) successor(ctx context.Context, slice *models.Slice) (string, []string, error) {
+	rootID := ""
+	if root, err := s.storage.GetRootSlice(ctx); err == nil {
+		rootID = root.ID
+	} else if !errors.Is(err, storage.ErrSliceNotFound) {
+		return "", nil, status.Error(codes.Internal, fmt.Sprintf("failed to find the slice's successor: %v", err))
+	}
+
+	heir := slice.Parent(rootID)
+	if heir != "" && heir != rootID {
+		parent, err := s.storage.GetSlice(ctx, heir)
+		if errors.Is(err, storage.ErrSliceNotFound) {
+			heir = rootID
+		} else if err != nil {
+			return "", nil, status.Error(codes.Internal, fmt.Sprintf("failed to find the slice's successor: %v", err))
+		} else if parent.Archived {
+			return "", nil, status.Error(codes.FailedPrecondition,
+				fmt.Sprintf("slice %s's parent %s is archived and cannot take its files; reparent it first", slice.ID, heir))
+		}
+	}
+
+	all, err := s.storage.ListSlices(ctx, int(^uint(0)>>1), 0)
+	if err != nil {
+		return "", nil, status.Error(codes.Internal, fmt.Sprintf("failed to find the slice's successor: %v", err))
+	}
+	var children []string
+	for _, other := range all {
+		if other.ID != slice.ID && other.Parent(rootID) == slice.ID {
+			children = append(children, other.ID)
+		}
+	}
+	sort.Strings(children)
+	return heir, children, nil
+}
+
+// claimedFiles lists the files a slice claims, sorted: the files it lists and,
+// for a slice defined by patterns, the files in the root slice's head tree
+// that its patterns match.
+func (s *adminServiceServer) claimedFiles(ctx context.Context, slice *models.Slice) ([]string, error) {
+	claimed := make(map[string]bool, len(slice.Files))
+	for _, fileID := range slice.Files {
+		claimed[fileID] = true
+	}
+	if spec := slice.PathSpec(); spec.HasPatterns() {
+		tree, err := s.rootTree(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for p := range tree {
+			if spec.Match(p) {
+				claimed[p] = true
+			}
+		}
+	}
+
+	files := make([]string, 0, len(claimed))
+	for fileID := range claimed {
+		files = append(files, fileID)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// rootTree returns the files at the root slice's head. Without a root slice,
+// or before the root has a stored commit, it is empty.
+func (s *adminServiceServer) rootTree(ctx context.Context) (map[string]string, error) {
+	root, err := s.storage.GetRootSlice(ctx)
+	if errors.Is(err, storage.ErrSliceNotFound) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := s.storage.GetSliceMetadata(ctx, root.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !objects.IsHash(metadata.HeadCommitHash) {
+		return map[string]string{}, nil
+	}
+	commit, err := storage.ReadCommit(ctx, s.storage, metadata.HeadCommitHash)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return storage.ReadSnapshot(ctx, s.storage, commit.TreeHash)
+}
+
+// retireSlice checks that a slice may be deleted or archived and starts the
+// batch that moves its file claims, including files its patterns match, and
+// its children to its successor.
+func (s *adminServiceServer) retireSlice(ctx context.Context, sliceID, action string) (*models.Slice, *handoff, error) {
+	if sliceID == "" {
+		return nil, nil, status.Error(codes.InvalidArgument, "slice_id is required")
+	}
+	slice, err := s.storage.GetSlice(ctx, sliceID)
+	if err != nil {
+		return nil, nil, status.Error(codes.NotFound, fmt.Sprintf("slice not found: %s", sliceID))
+	}
+	if slice.IsRoot {
+		return nil, nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("the root slice cannot be %s", action))
+	}
+
+	changesets, err := s.storage.ListChangesets(ctx, slice.ID, nil, 0)
+	if err != nil {
+		return nil, nil, status.Error(codes.Internal, fmt.Sprintf("failed to list changesets: %v", err))
+	}
+	for _, cs := range changesets {
+		if cs.Status != models.ChangesetStatusMerged && cs.Status != models.ChangesetStatusAbandoned {
+			return nil, nil, status.Error(codes.FailedPrecondition,
+				fmt.Sprintf("slice %s has open changeset %s; merge or abandon it first", slice.ID, cs.ID))
+		}
+	}
+
+	heir, children, err := s.successor(ctx, slice)
+	if err != nil {
+		return nil, nil, err
+	}
+	if heir == "" {
+		return nil, nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("slice %s has no parent or root slice to take its files", slice.ID))
+	}
+
+	files, err := s.claimedFiles(ctx, slice)
+	if err != nil {
+		return nil, nil, status.Error(codes.Internal, fmt.Sprintf("failed to list the slice's files: %v", err))
+	}
+
+	batch := storage.NewBatch()
+	for _, fileID := range files {
+		batch.AddFileToSlice(fileID, heir)
+	}
+	for _, child := range children {
+		batch.SetSliceParent(child, heir)
+	}
+	return slice, &handoff{heir: heir, files: files, children: children, batch: batch}, nil
+}
+
+// UpdateSlice edits a slice in place. Files removed from it pass to its
+// successor so they stay claimed.
+func (s *adminServiceServer) UpdateSlice(ctx context.Context, req *adminv1.UpdateSliceRequest) (*adminv1.UpdateSliceResponse, error) {
+	log.Printf("UpdateSlice called: slice_id=%s", req.SliceId)
+
+	if req.SliceId == "" {
+		return nil, status.Error(codes.InvalidArgument, "slice_id is required")
+	}
+	slice, err := s.storage.GetSlice(ctx, req.SliceId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("slice not found: %s", req.SliceId))
+	}
+	if slice.Archived {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("slice %s is archived", slice.ID))
+	}
+
+	if req.Name != nil {
+		if *req.Name == "" {
+			return nil, status.Error(codes.InvalidArgument, "name cannot be empty")
+		}
+		slice.Name = *req.Name
+	}
+	if req.Description != nil {
+		slice.Description = *req.Description
+	}
+
+	cleanPaths := func(paths []string) ([]string, error) {
+		var cleaned []string
+		for _, p := range paths {
+			clean, err := storage.CleanPath(p)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid file path: %q", p))
+			}
+			cleaned = append(cleaned, clean)
+		}
+		return cleaned, nil
+	}
+	added, err := cleanPaths(req.AddFiles)
+	if err != nil {
+		return nil, err
+	}
+	removing, err := cleanPaths(req.RemoveFiles)
+	if err != nil {
+		return nil, err
+	}
+	for _, owner := range append(append([]string{}, req.AddOwners...), req.RemoveOwners...) {
+		if owner == "" {
+			return nil, status.Error(codes.InvalidArgument, "owner names cannot be empty")
+		}
+	}
+
+	var released []string
+	for _, fileID := range removing {
+		if containsString(slice.Files, fileID) {
+			released = append(released, fileID)
+			slice.Files = storage.RemoveString(slice.Files, fileID)
+		}
+	}
+	for _, fileID := range added {
+		if !containsString(slice.Files, fileID) {
+			slice.Files = append(slice.Files, fileID)
+		}
+	}
+	for _, owner := range req.RemoveOwners {
+		slice.Owners = storage.RemoveString(slice.Owners, owner)
+	}
+	for _, owner := range req.AddOwners {
+		if !containsString(slice.Owners, owner) {
+			slice.Owners = append(slice.Owners, owner)
+		}
+	}
+
+	// The edit and the handoff of removed files commit together, so the files
+	// are never left unclaimed
+	batch := storage.NewBatch()
+	batch.UpdateSlice(slice)
+	heir := ""
+	if len(released) > 0 {
+		heir, _, err = s.successor(ctx, slice)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if heir != "" {
+		for _, fileID := range released {
+			batch.AddFileToSlice(fileID, heir)
+		}
+	}
+	if err := s.storage.CommitBatch(ctx, batch); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to update slice: %v", err))
+	}
+
+	files := append([]string(nil), slice.Files...)
+	sort.Strings(files)
+	owners := append([]string(nil), slice.Owners...)
+	sort.Strings(owners)
+	return &adminv1.UpdateSliceResponse{
+		SliceId:     slice.ID,
+		Name:        slice.Name,
+		Description: slice.Description,
+		Files:       files,
+		Owners:      owners,
+		HeirSliceId: heir,
+	}, nil
+}
+
+// DeleteSlice removes a slice and its history once its files and children
+// have passed to its successor. Changesets made in the slice are kept.
+func (s *adminServiceServer) DeleteSlice(ctx context.Context, req *adminv1.DeleteSliceRequest) (*adminv1.DeleteSliceResponse, error) {
+	log.Printf("DeleteSlice called: slice_id=%s", req.SliceId)
+
+	slice, handed, err := s.retireSlice(ctx, req.SliceId, "deleted")
+	if err != nil {
+		return nil, err
+	}
+	handed.batch.DeleteSlice(slice.ID)
+	if err := s.storage.CommitBatch(ctx, handed.batch); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to delete slice: %v", err))
+	}
+
+	return &adminv1.DeleteSliceResponse{
+		SliceId:          slice.ID,
+		HeirSliceId:      handed.heir,
+		ReassignedFiles:  handed.files,
+		ReparentedSlices: handed.children,
+	}, nil
+}
+
+// ArchiveSlice retires a slice but keeps its record and history readable.
+// Its files and children pass to its successor, and it accepts no new
+// changesets.
+func (s *adminServiceServer) ArchiveSlice(ctx context.Context, req *adminv1.ArchiveSliceRequest) (*adminv1.ArchiveSliceResponse, error) {
+	log.Printf("ArchiveSlice called: slice_id=%s", req.SliceId)
+
+	if slice, err := s.storage.GetSlice(ctx, req.SliceId); err == nil && slice.Archived {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("slice %s is already archived", slice.ID))
+	}
+	slice, handed, err := s.retireSlice(ctx, req.SliceId, "archived")
+	if err != nil {
+		return nil, err
+	}
+	handed.batch.ArchiveSlice(slice.ID)
+	if err := s.storage.CommitBatch(ctx, handed.batch); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to archive slice: %v", err))
+	}
+
+	return &adminv1.ArchiveSliceResponse{
+		SliceId:          slice.ID,
+		HeirSliceId:      handed.heir,
+		ReassignedFiles:  handed.files,
+		ReparentedSlices: handed.children,
+	}, nil
+}
+
+func containsString(values []string, want string) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package sliceservice
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/niczy/gitslice/internal/models"
+	slicev1 "github.com/niczy/gitslice/proto/slice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *sliceServiceServer) ApproveChangeset(ctx context.Context, req *slicev1.ChangesetVoteRequest) (*slicev1.ChangesetVoteResponse, error) {
+	log.Printf("ApproveChangeset called: changeset_id=%s, reviewer=%s", req.ChangesetId, req.Reviewer)
+	return s.castVote(ctx, req, true)
+}
+
+func (s *sliceServiceServer) RejectChangeset(ctx context.Context, req *slicev1.ChangesetVoteRequest) (*slicev1.ChangesetVoteResponse, error) {
+	log.Printf("RejectChangeset called: changeset_id=%s, reviewer=%s", req.ChangesetId, req.Reviewer)
+	return s.castVote(ctx, req, false)
+}
+
+// castVote records a reviewer's vote, replacing any earlier vote of theirs, and
+// moves the changeset between PENDING, APPROVED and REJECTED accordingly.
+func (s *sliceServiceServer) castVote(ctx context.Context, req *slicev1.ChangesetVoteRequest, approved bool) (*slicev1.ChangesetVoteResponse, error) {
+	if req.Reviewer == "" {
+		return nil, status.Error(codes.InvalidArgument, "reviewer is required")
+	}
+
+	cs, err := s.storage.GetChangeset(ctx, req.ChangesetId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("changeset not found: %s", req.ChangesetId))
+	}
+	if err := requireOpen(cs); err != nil {
+		return nil, err
+	}
+	slice, err := s.storage.GetSlice(ctx, cs.SliceID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("slice not found: %s", cs.SliceID))
+	}
+
+	vote := models.ReviewVote{Reviewer: req.Reviewer, Approved: approved, Comment: req.Comment, CreatedAt: time.Now()}
+	replaced := false
+	for i := range cs.Votes {
+		if cs.Votes[i].Reviewer == req.Reviewer {
+			cs.Votes[i] = vote
+			replaced = true
+		}
+	}
+	if !replaced {
+		cs.Votes = append(cs.Votes, vote)
+	}
+
+	approvals, rejectedBy := tallyVotes(slice, cs)
+	switch {
+	case len(rejectedBy) > 0:
+		cs.Status = models.ChangesetStatusRejected
+	case approvals > 0 && approvals >= slice.ApprovalRule.RequiredApprovals:
+		cs.Status = models.ChangesetStatusApproved
+	default:
+		cs.Status = models.ChangesetStatusPending
+	}
+
+	if err := s.storage.UpdateChangeset(ctx, cs); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to update changeset: %v", err))
+	}
+
+	return &slicev1.ChangesetVoteResponse{
+		Changeset:         convertChangesetToProto(cs),
+		Approvals:         int32(approvals),
+		RequiredApprovals: int32(slice.ApprovalRule.RequiredApprovals),
+	}, nil
+}
+
+// tallyVotes counts the approvals the slice's rule accepts and lists the
+// eligible reviewers who rejected the changeset.
+func tallyVotes(slice *models.Slice, cs *models.Changeset) (approvals int, rejectedBy []string) {
+	owners := make(map[string]bool, len(slice.Owners))
+	for _, owner := range slice.Owners {
+		owners[owner] = true
+	}
+
+	for _, vote := range cs.Votes {
+		if slice.ApprovalRule.OwnersOnly && !owners[vote.Reviewer] {
+			continue
+		}
+		if vote.Approved {
+			approvals++
+		} else {
+			rejectedBy = append(rejectedBy, vote.Reviewer)
+		}
+	}
+	return approvals, rejectedBy
+}
+
+// approvalProblem describes why a changeset may not merge yet, or returns an
+// empty string when the slice's approval rule is satisfied.
+func approvalProblem(slice *models.Slice, cs *models.Changeset) string {
+	approvals, rejectedBy := tallyVotes(slice, cs)
+	if len(rejectedBy) > 0 {
+		return fmt.Sprintf("changeset %s was rejected by %s", cs.ID, strings.Join(rejectedBy, ", "))
+	}
+	if required := slice.ApprovalRule.RequiredApprovals; approvals < required {
+		who := "reviewers"
+		if slice.ApprovalRule.OwnersOnly {
+			who = "slice owners"
+		}
+		return fmt.Sprintf("changeset %s has %d of %d required approvals from %s", cs.ID, approvals, required, who)
+	}
+	return ""
+}
+
+func convertVotesToProto(votes []models.ReviewVote) []*slicev1.ReviewVote {
+	result := make([]*slicev1.ReviewVote, 0, len(votes))
+	for _, vote := range votes {
+		result = append(result, &slicev1.ReviewVote{
+			Reviewer:  vote.Reviewer,
+			Approved:  vote.Approved,
+			Comment:   vote.Comment,
+			CreatedAt: vote.CreatedAt.Unix(),
+		})
+	}
+	return result
+}
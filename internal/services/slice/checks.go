@@ -0,0 +1,157 @@
+package sliceservice
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/niczy/gitslice/internal/models"
+	slicev1 "github.com/niczy/gitslice/proto/slice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SetChangesetCheck records the latest state of a named status check on an
+// open changeset, replacing any earlier report for the same name.
+func (s *sliceServiceServer) SetChangesetCheck(ctx context.Context, req *slicev1.SetChangesetCheckRequest) (*slicev1.SetChangesetCheckResponse, error) {
+	log.Printf("SetChangesetCheck called: changeset_id=%s, name=%s, state=%s", req.ChangesetId, req.Name, req.State)
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "check name is required")
+	}
+	state, ok := convertProtoCheckStateToModel(req.State)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("unknown check state: %s", req.State))
+	}
+
+	cs, err := s.storage.GetChangeset(ctx, req.ChangesetId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("changeset not found: %s", req.ChangesetId))
+	}
+	if err := requireOpen(cs); err != nil {
+		return nil, err
+	}
+	slice, err := s.storage.GetSlice(ctx, cs.SliceID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("slice not found: %s", cs.SliceID))
+	}
+
+	check := models.StatusCheck{Name: name, State: state, Description: req.Description, TargetURL: req.TargetUrl, UpdatedAt: time.Now()}
+	replaced := false
+	for i := range cs.Checks {
+		if cs.Checks[i].Name == name {
+			cs.Checks[i] = check
+			replaced = true
+		}
+	}
+	if !replaced {
+		cs.Checks = append(cs.Checks, check)
+	}
+
+	if err := s.storage.UpdateChangeset(ctx, cs); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to update changeset: %v", err))
+	}
+
+	var waiting []string
+	for _, required := range slice.RequiredChecks {
+		if got, ok := findCheck(cs, required); !ok || got.State != models.CheckStateSuccess {
+			waiting = append(waiting, required)
+		}
+	}
+	return &slicev1.SetChangesetCheckResponse{
+		Changeset:             convertChangesetToProto(cs),
+		PendingRequiredChecks: waiting,
+	}, nil
+}
+
+func findCheck(cs *models.Changeset, name string) (models.StatusCheck, bool) {
+	for _, check := range cs.Checks {
+		if check.Name == name {
+			return check, true
+		}
+	}
+	return models.StatusCheck{}, false
+}
+
+// checkWarnings describes each of the slice's required checks that has not
+// succeeded on the changeset.
+func checkWarnings(slice *models.Slice, cs *models.Changeset) []string {
+	var warnings []string
+	for _, name := range slice.RequiredChecks {
+		check, ok := findCheck(cs, name)
+		switch {
+		case !ok:
+			warnings = append(warnings, fmt.Sprintf("Required check %s has not reported.", name))
+		case check.State == models.CheckStatePending:
+			warnings = append(warnings, fmt.Sprintf("Required check %s is pending.", name))
+		case check.State == models.CheckStateFailure && check.Description != "":
+			warnings = append(warnings, fmt.Sprintf("Required check %s failed: %s.", name, check.Description))
+		case check.State == models.CheckStateFailure:
+			warnings = append(warnings, fmt.Sprintf("Required check %s failed.", name))
+		}
+	}
+	return warnings
+}
+
+// checksProblem explains which required checks keep a changeset from merging,
+// or returns an empty string once every one of them has succeeded.
+func checksProblem(slice *models.Slice, cs *models.Changeset) string {
+	var failed, waiting []string
+	for _, name := range slice.RequiredChecks {
+		check, ok := findCheck(cs, name)
+		switch {
+		case ok && check.State == models.CheckStateFailure:
+			failed = append(failed, name)
+		case !ok || check.State != models.CheckStateSuccess:
+			waiting = append(waiting, name)
+		}
+	}
+
+	var reasons []string
+	if len(failed) > 0 {
+		reasons = append(reasons, fmt.Sprintf("failed required checks: %s", strings.Join(failed, ", ")))
+	}
+	if len(waiting) > 0 {
+		reasons = append(reasons, fmt.Sprintf("waiting on required checks: %s", strings.Join(waiting, ", ")))
+	}
+	if len(reasons) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("changeset %s has %s", cs.ID, strings.Join(reasons, "; "))
+}
+
+func convertChecksToProto(checks []models.StatusCheck) []*slicev1.StatusCheck {
+	result := make([]*slicev1.StatusCheck, 0, len(checks))
+	for _, check := range checks {
+		state := slicev1.CheckState_CHECK_STATE_PENDING
+		switch check.State {
+		case models.CheckStateSuccess:
+			state = slicev1.CheckState_CHECK_STATE_SUCCESS
+		case models.CheckStateFailure:
+			state = slicev1.CheckState_CHECK_STATE_FAILURE
+		}
+		result = append(result, &slicev1.StatusCheck{
+			Name:        check.Name,
+			State:       state,
+			Description: check.Description,
+			TargetUrl:   check.TargetURL,
+			UpdatedAt:   check.UpdatedAt.Unix(),
+		})
+	}
+	return result
+}
+
+func convertProtoCheckStateToModel(state slicev1.CheckState) (models.CheckState, bool) {
+	switch state {
+	case slicev1.CheckState_CHECK_STATE_PENDING:
+		return models.CheckStatePending, true
+	case slicev1.CheckState_CHECK_STATE_SUCCESS:
+		return models.CheckStateSuccess, true
+	case slicev1.CheckState_CHECK_STATE_FAILURE:
+		return models.CheckStateFailure, true
+	}
+	return 0, false
+}
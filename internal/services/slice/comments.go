@@ -0,0 +1,169 @@
+package sliceservice
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/niczy/gitslice/internal/models"
+	slicev1 "github.com/niczy/gitslice/proto/slice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *sliceServiceServer) AddComment(ctx context.Context, req *slicev1.AddCommentRequest) (*slicev1.AddCommentResponse, error) {
+	log.Printf("AddComment called: changeset_id=%s, path=%s, line=%d, author=%s", req.ChangesetId, req.Path, req.Line, req.Author)
+
+	if req.Author == "" {
+		return nil, status.Error(codes.InvalidArgument, "author is required")
+	}
+	if req.Body == "" {
+		return nil, status.Error(codes.InvalidArgument, "comment body is required")
+	}
+	if req.Line < 0 {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid line %d", req.Line))
+	}
+
+	cs, err := s.storage.GetChangeset(ctx, req.ChangesetId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("changeset not found: %s", req.ChangesetId))
+	}
+
+	now := time.Now()
+	comment := &models.ReviewComment{
+		ID:            fmt.Sprintf("comment-%d", now.UnixNano()),
+		ChangesetID:   cs.ID,
+		ChangesetHash: cs.Hash,
+		Path:          req.Path,
+		Line:          int(req.Line),
+		Author:        req.Author,
+		Body:          req.Body,
+		CreatedAt:     now,
+	}
+
+	if req.ParentId != "" {
+		root, err := s.threadRoot(ctx, req.ParentId)
+		if err != nil {
+			return nil, err
+		}
+		if root.ChangesetID != cs.ID {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("comment %s belongs to changeset %s", req.ParentId, root.ChangesetID))
+		}
+		comment.ParentID = root.ID
+		comment.Path = root.Path
+		comment.Line = root.Line
+	} else {
+		if req.Path == "" && req.Line != 0 {
+			return nil, status.Error(codes.InvalidArgument, "line comments need a path")
+		}
+		if req.Path != "" && !slices.Contains(cs.ModifiedFiles, req.Path) {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("%s is not modified by changeset %s", req.Path, cs.ID))
+		}
+	}
+
+	if err := s.storage.AddComment(ctx, comment); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to add comment: %v", err))
+	}
+
+	return &slicev1.AddCommentResponse{Comment: convertCommentToProto(comment)}, nil
+}
+
+func (s *sliceServiceServer) ListComments(ctx context.Context, req *slicev1.ListCommentsRequest) (*slicev1.ListCommentsResponse, error) {
+	log.Printf("ListComments called: changeset_id=%s", req.ChangesetId)
+
+	if _, err := s.storage.GetChangeset(ctx, req.ChangesetId); err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("changeset not found: %s", req.ChangesetId))
+	}
+
+	comments, err := s.storage.ListComments(ctx, req.ChangesetId)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list comments: %v", err))
+	}
+
+	response := &slicev1.ListCommentsResponse{}
+	for _, comment := range comments {
+		response.Comments = append(response.Comments, convertCommentToProto(comment))
+	}
+	return response, nil
+}
+
+// ResolveComment resolves the thread the comment belongs to; resolving a reply
+// resolves the comment that opened its thread.
+func (s *sliceServiceServer) ResolveComment(ctx context.Context, req *slicev1.ResolveCommentRequest) (*slicev1.ResolveCommentResponse, error) {
+	log.Printf("ResolveComment called: comment_id=%s, resolved_by=%s", req.CommentId, req.ResolvedBy)
+
+	if req.ResolvedBy == "" {
+		return nil, status.Error(codes.InvalidArgument, "resolved_by is required")
+	}
+
+	root, err := s.threadRoot(ctx, req.CommentId)
+	if err != nil {
+		return nil, err
+	}
+	if root.Resolved {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("comment %s is already resolved", root.ID))
+	}
+
+	now := time.Now()
+	root.Resolved = true
+	root.ResolvedBy = req.ResolvedBy
+	root.ResolvedAt = &now
+	if err := s.storage.UpdateComment(ctx, root); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to update comment: %v", err))
+	}
+
+	return &slicev1.ResolveCommentResponse{Comment: convertCommentToProto(root)}, nil
+}
+
+// threadRoot loads a comment and, for replies, the comment that opened the thread.
+func (s *sliceServiceServer) threadRoot(ctx context.Context, commentID string) (*models.ReviewComment, error) {
+	comment, err := s.storage.GetComment(ctx, commentID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("comment not found: %s", commentID))
+	}
+	if comment.ParentID == "" {
+		return comment, nil
+	}
+	root, err := s.storage.GetComment(ctx, comment.ParentID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("comment not found: %s", comment.ParentID))
+	}
+	return root, nil
+}
+
+// unresolvedThreads counts the comment threads on a changeset still awaiting resolution.
+func (s *sliceServiceServer) unresolvedThreads(ctx context.Context, changesetID string) (int, error) {
+	comments, err := s.storage.ListComments(ctx, changesetID)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, comment := range comments {
+		if comment.ParentID == "" && !comment.Resolved {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func convertCommentToProto(comment *models.ReviewComment) *slicev1.ReviewComment {
+	info := &slicev1.ReviewComment{
+		CommentId:     comment.ID,
+		ChangesetId:   comment.ChangesetID,
+		ChangesetHash: comment.ChangesetHash,
+		Path:          comment.Path,
+		Line:          int32(comment.Line),
+		ParentId:      comment.ParentID,
+		Author:        comment.Author,
+		Body:          comment.Body,
+		Resolved:      comment.Resolved,
+		ResolvedBy:    comment.ResolvedBy,
+		CreatedAt:     comment.CreatedAt.Unix(),
+	}
+	if comment.ResolvedAt != nil {
+		info.ResolvedAt = comment.ResolvedAt.Unix()
+	}
+	return info
+}
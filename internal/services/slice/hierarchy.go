@@ -0,0 +1,372 @@
+package sliceservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/niczy/gitslice/internal/models"
+	"github.com/niczy/gitslice/internal/objects"
+	"github.com/niczy/gitslice/internal/storage"
+	slicev1 "github.com/niczy/gitslice/proto/slice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sliceHierarchy loads every slice and groups them under their parents, each
+// group sorted by ID. It also returns the root slice's ID, or "" when no root
+// slice exists yet.
+func (s *sliceServiceServer) sliceHierarchy(ctx context.Context) (map[string][]*models.Slice, string, error) {
+	rootID := ""
+	if root, err := s.storage.GetRootSlice(ctx); err == nil {
+		rootID = root.ID
+	} else if !errors.Is(err, storage.ErrSliceNotFound) {
+		return nil, "", err
+	}
+
+	slices, err := s.storage.ListSlices(ctx, int(^uint(0)>>1), 0)
+	if err != nil {
+		return nil, "", err
+	}
+	children := make(map[string][]*models.Slice)
+	for _, slice := range slices {
+		if parent := slice.Parent(rootID); parent != "" {
+			children[parent] = append(children[parent], slice)
+		}
+	}
+	for _, group := range children {
+		sort.Slice(group, func(i, j int) bool { return group[i].ID < group[j].ID })
+	}
+	return children, rootID, nil
+}
+
+func sliceNode(slice *models.Slice, rootID string, depth int) *slicev1.SliceNode {
+	return &slicev1.SliceNode{
+		SliceId:       slice.ID,
+		Name:          slice.Name,
+		ParentSliceId: slice.Parent(rootID),
+		Depth:         int32(depth),
+		FileCount:     int32(len(slice.Files)),
+	}
+}
+
+// ancestors walks from a slice's parent up to the root slice.
+func (s *sliceServiceServer) ancestors(ctx context.Context, slice *models.Slice, rootID string) ([]*models.Slice, error) {
+	var chain []*models.Slice
+	seen := map[string]bool{slice.ID: true}
+	for current := slice; ; {
+		parentID := current.Parent(rootID)
+		if parentID == "" {
+			return chain, nil
+		}
+		if seen[parentID] {
+			return nil, fmt.Errorf("slice hierarchy has a cycle at %s", parentID)
+		}
+		seen[parentID] = true
+		parent, err := s.storage.GetSlice(ctx, parentID)
+		if err != nil {
+			return nil, fmt.Errorf("parent slice %s of %s: %w", parentID, current.ID, err)
+		}
+		chain = append(chain, parent)
+		current = parent
+	}
+}
+
+func (s *sliceServiceServer) ListSliceChildren(ctx context.Context, req *slicev1.ListSliceChildrenRequest) (*slicev1.ListSliceChildrenResponse, error) {
+	log.Printf("ListSliceChildren called: slice_id=%s, recursive=%v", req.SliceId, req.Recursive)
+
+	if _, err := s.storage.GetSlice(ctx, req.SliceId); err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("slice not found: %s", req.SliceId))
+	}
+	children, rootID, err := s.sliceHierarchy(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list slices: %v", err))
+	}
+
+	resp := &slicev1.ListSliceChildrenResponse{}
+	seen := map[string]bool{req.SliceId: true}
+	var visit func(parentID string, depth int)
+	visit = func(parentID string, depth int) {
+		for _, child := range children[parentID] {
+			if seen[child.ID] {
+				continue
+			}
+			seen[child.ID] = true
+			resp.Children = append(resp.Children, sliceNode(child, rootID, depth))
+			if req.Recursive {
+				visit(child.ID, depth+1)
+			}
+		}
+	}
+	visit(req.SliceId, 1)
+	return resp, nil
+}
+
+func (s *sliceServiceServer) GetSliceAncestry(ctx context.Context, req *slicev1.GetSliceAncestryRequest) (*slicev1.GetSliceAncestryResponse, error) {
+	log.Printf("GetSliceAncestry called: slice_id=%s", req.SliceId)
+
+	slice, err := s.storage.GetSlice(ctx, req.SliceId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("slice not found: %s", req.SliceId))
+	}
+	_, rootID, err := s.sliceHierarchy(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list slices: %v", err))
+	}
+	chain, err := s.ancestors(ctx, slice, rootID)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	resp := &slicev1.GetSliceAncestryResponse{}
+	for i, ancestor := range chain {
+		resp.Ancestors = append(resp.Ancestors, sliceNode(ancestor, rootID, i+1))
+	}
+	return resp, nil
+}
+
+// ReparentSlice moves a slice, with everything under it, beneath another
+// slice. Files and history stay where they are.
+func (s *sliceServiceServer) ReparentSlice(ctx context.Context, req *slicev1.ReparentSliceRequest) (*slicev1.ReparentSliceResponse, error) {
+	log.Printf("ReparentSlice called: slice_id=%s, parent_slice_id=%s", req.SliceId, req.ParentSliceId)
+
+	if req.SliceId == "" || req.ParentSliceId == "" {
+		return nil, status.Error(codes.InvalidArgument, "slice_id and parent_slice_id are required")
+	}
+	if req.SliceId == req.ParentSliceId {
+		return nil, status.Error(codes.InvalidArgument, "a slice cannot be its own parent")
+	}
+	slice, err := s.storage.GetSlice(ctx, req.SliceId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("slice not found: %s", req.SliceId))
+	}
+	parent, err := s.storage.GetSlice(ctx, req.ParentSliceId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("parent slice not found: %s", req.ParentSliceId))
+	}
+	_, rootID, err := s.sliceHierarchy(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list slices: %v", err))
+	}
+	if slice.IsRoot || slice.ID == rootID {
+		return nil, status.Error(codes.FailedPrecondition, "the root slice cannot be moved")
+	}
+
+	chain, err := s.ancestors(ctx, parent, rootID)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	for _, ancestor := range chain {
+		if ancestor.ID == slice.ID {
+			return nil, status.Error(codes.FailedPrecondition,
+				fmt.Sprintf("slice %s is under %s; moving it there would create a cycle", parent.ID, slice.ID))
+		}
+	}
+
+	batch := storage.NewBatch()
+	batch.SetSliceParent(slice.ID, parent.ID)
+	if err := s.storage.CommitBatch(ctx, batch); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to move slice: %v", err))
+	}
+
+	return &slicev1.ReparentSliceResponse{
+		SliceId:               slice.ID,
+		PreviousParentSliceId: slice.Parent(rootID),
+		ParentSliceId:         parent.ID,
+	}, nil
+}
+
+// FoldSlice hands a slice's files back to its parent. The parent gains a commit
+// whose tree is its head with the slice's head laid over it and which points
+// at the slice's head, so the slice's history stays its own lineage. The
+// slice's children move up to the parent. The folded slice stays behind but
+// no longer claims any files: its patterns are cleared and the parent lists
+// the files they matched.
+func (s *sliceServiceServer) FoldSlice(ctx context.Context, req *slicev1.FoldSliceRequest) (*slicev1.FoldSliceResponse, error) {
+	log.Printf("FoldSlice called: slice_id=%s, author=%s", req.SliceId, req.Author)
+
+	slice, err := s.storage.GetSlice(ctx, req.SliceId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("slice not found: %s", req.SliceId))
+	}
+	children, rootID, err := s.sliceHierarchy(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list slices: %v", err))
+	}
+	parentID := slice.Parent(rootID)
+	if parentID == "" {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("slice %s has no parent to fold into", slice.ID))
+	}
+	parent, err := s.storage.GetSlice(ctx, parentID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("parent slice not found: %s", parentID))
+	}
+
+	changesets, err := s.storage.ListChangesets(ctx, slice.ID, nil, 0)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list changesets: %v", err))
+	}
+	for _, cs := range changesets {
+		if cs.Status != models.ChangesetStatusMerged && cs.Status != models.ChangesetStatusAbandoned {
+			return nil, status.Error(codes.FailedPrecondition,
+				fmt.Sprintf("slice %s has open changeset %s; merge or abandon it before folding", slice.ID, cs.ID))
+		}
+	}
+
+	metadata, err := s.storage.GetSliceMetadata(ctx, slice.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to load slice metadata: %v", err))
+	}
+	parentMetadata, err := s.storage.GetSliceMetadata(ctx, parent.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to load parent metadata: %v", err))
+	}
+	head, parentHead := metadata.HeadCommitHash, parentMetadata.HeadCommitHash
+
+	files, err := s.commitSnapshot(ctx, slice.ID, head)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to read slice tree: %v", err))
+	}
+	parentFiles, err := s.commitSnapshot(ctx, parent.ID, parentHead)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to read parent tree: %v", err))
+	}
+
+	// Only files the slice still claims move; its tree can list files that
+	// were since carved out into a child
+	candidates := storage.SnapshotPaths(files)
+	listed := make(map[string]bool, len(slice.Files))
+	for _, fileID := range slice.Files {
+		listed[fileID] = true
+		if _, ok := files[fileID]; !ok {
+			candidates = append(candidates, fileID)
+		}
+	}
+	// A slice defined by patterns also claims the matching files in the root
+	// tree that it never changed
+	if spec := slice.PathSpec(); spec.HasPatterns() && rootID != "" {
+		rootMetadata, err := s.storage.GetSliceMetadata(ctx, rootID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to load root metadata: %v", err))
+		}
+		rootFiles, err := s.commitSnapshot(ctx, rootID, rootMetadata.HeadCommitHash)
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to read root tree: %v", err))
+		}
+		for _, p := range storage.SnapshotPaths(rootFiles) {
+			if _, ok := files[p]; !ok && !listed[p] && spec.Match(p) {
+				candidates = append(candidates, p)
+			}
+		}
+	}
+	var moved []string
+	for _, fileID := range candidates {
+		owners, err := s.storage.GetActiveSlicesForFile(ctx, fileID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to look up owners of %s: %v", fileID, err))
+		}
+		for _, owner := range owners {
+			if owner == slice.ID {
+				moved = append(moved, fileID)
+				break
+			}
+		}
+	}
+	sort.Strings(moved)
+
+	if err := s.storage.LockSliceAndFiles(ctx, slice.ID, moved); err != nil {
+		if errors.Is(err, storage.ErrLockHeld) {
+			return nil, status.Error(codes.Aborted, "slice or files are locked by another operation")
+		}
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to acquire locks: %v", err))
+	}
+	defer s.storage.UnlockSliceAndFiles(ctx, slice.ID, moved)
+
+	folded := make(map[string]string, len(parentFiles)+len(files))
+	for p, blobHash := range parentFiles {
+		folded[p] = blobHash
+	}
+	for _, p := range moved {
+		if blobHash, ok := files[p]; ok {
+			folded[p] = blobHash
+		} else if objects.IsHash(head) {
+			// The slice deleted a file it owned; drop the parent's stale copy
+			delete(folded, p)
+		}
+	}
+
+	treeHash, err := storage.WriteSnapshot(ctx, s.storage, folded)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to write folded tree: %v", err))
+	}
+	now := time.Now()
+	commit := &models.Commit{
+		TreeHash:  treeHash,
+		SliceID:   parent.ID,
+		Author:    req.Author,
+		Timestamp: now,
+		Message:   fmt.Sprintf("Fold slice %s into %s", slice.ID, parent.ID),
+	}
+	if objects.IsHash(parentHead) {
+		commit.ParentHash = parentHead
+	}
+	if objects.IsHash(head) {
+		commit.FoldedHash = head
+	}
+	if _, err := storage.WriteCommit(ctx, s.storage, commit); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to write commit: %v", err))
+	}
+
+	history, err := s.storage.ListSliceCommits(ctx, slice.ID, 0, "")
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list slice commits: %v", err))
+	}
+
+	batch := storage.NewBatch()
+	batch.ExpectHead(slice.ID, head)
+	batch.ExpectHead(parent.ID, parentHead)
+	// Clear the slice's patterns so it stops claiming the files they match;
+	// the parent takes them over as listed files
+	if slice.PathSpec().HasPatterns() {
+		slice.Include, slice.Exclude = nil, nil
+		batch.UpdateSlice(slice)
+	}
+	for _, fileID := range moved {
+		batch.RemoveFileFromSlice(fileID, slice.ID)
+		batch.AddFileToSlice(fileID, parent.ID)
+	}
+	var reparented []string
+	for _, child := range children[slice.ID] {
+		batch.SetSliceParent(child.ID, parent.ID)
+		reparented = append(reparented, child.ID)
+	}
+	batch.AddSliceCommit(parent.ID, commit)
+
+	parentMetadata.HeadCommitHash = commit.CommitHash
+	parentMetadata.ModifiedFiles = moved
+	parentMetadata.ModifiedFilesCount = len(moved)
+	parentMetadata.LastModified = now
+	batch.UpdateSliceMetadata(parent.ID, parentMetadata)
+
+	if err := s.promoteSlice(ctx, batch, commit, folded, moved); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to promote slice %s to global state: %v", parent.ID, err))
+	}
+
+	if err := s.storage.CommitBatch(ctx, batch); err != nil {
+		if errors.Is(err, storage.ErrHeadMoved) {
+			return nil, status.Error(codes.Aborted, fmt.Sprintf("slice %s or %s moved while folding; retry", slice.ID, parent.ID))
+		}
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to fold slice: %v", err))
+	}
+
+	return &slicev1.FoldSliceResponse{
+		ParentSliceId:    parent.ID,
+		CommitHash:       commit.CommitHash,
+		Files:            moved,
+		CommitsFolded:    int32(len(history)),
+		ReparentedSlices: reparented,
+	}, nil
+}
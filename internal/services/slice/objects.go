@@ -0,0 +1,351 @@
+package sliceservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/niczy/gitslice/internal/models"
+	"github.com/niczy/gitslice/internal/objects"
+	"github.com/niczy/gitslice/internal/storage"
+	slicev1 "github.com/niczy/gitslice/proto/slice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxObjectSize is the largest object a streamed upload may reassemble from
+// parts. Parts past it are refused rather than buffered.
+const maxObjectSize = 64 << 20
+
+// objectUpload validates and persists the objects attached to a changeset and
+// tracks the trees among them so the changeset's root tree can be identified.
+type objectUpload struct {
+	storage    storage.Storage
+	trees      []string
+	referenced map[string]bool
+	// partial collects the parts of a streamed object until its last one arrives
+	partial *slicev1.Object
+}
+
+func newObjectUpload(st storage.Storage) *objectUpload {
+	return &objectUpload{storage: st, referenced: make(map[string]bool)}
+}
+
+// add checks the object's hash against its content and writes it to the object store.
+func (u *objectUpload) add(ctx context.Context, obj *slicev1.Object) error {
+	if obj == nil {
+		return status.Error(codes.InvalidArgument, "object is required")
+	}
+	if obj.Offset != 0 || obj.More {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("object %s is split into parts; only streamed uploads may split objects", obj.Hash))
+	}
+
+	var objType models.ObjectType
+	switch obj.Type {
+	case slicev1.ObjectType_BLOB:
+		objType = models.ObjectTypeBlob
+	case slicev1.ObjectType_TREE:
+		objType = models.ObjectTypeTree
+	case slicev1.ObjectType_COMMIT:
+		objType = models.ObjectTypeCommit
+	default:
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("unsupported object type: %s", obj.Type))
+	}
+
+	object := &models.Object{Type: objType, Hash: obj.Hash, Data: obj.Data}
+	if err := u.storage.PutObject(ctx, object); err != nil {
+		if errors.Is(err, objects.ErrHashMismatch) || errors.Is(err, objects.ErrInvalidObject) {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		return status.Error(codes.Internal, fmt.Sprintf("failed to store object %s: %v", obj.Hash, err))
+	}
+
+	if objType == models.ObjectTypeTree {
+		tree, err := objects.DecodeTree(object.Data)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		u.trees = append(u.trees, object.Hash)
+		for _, entry := range tree.Entries {
+			u.referenced[entry.Hash] = true
+		}
+	}
+
+	return nil
+}
+
+// addPart collects one part of a streamed object and stores the object once
+// its last part arrives. Parts must arrive in order and without gaps.
+func (u *objectUpload) addPart(ctx context.Context, obj *slicev1.Object) error {
+	if obj == nil {
+		return status.Error(codes.InvalidArgument, "object is required")
+	}
+
+	if u.partial == nil {
+		if obj.Offset != 0 {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("object %s starts at offset %d", obj.Hash, obj.Offset))
+		}
+		if !obj.More {
+			return u.add(ctx, obj)
+		}
+		u.partial = &slicev1.Object{Type: obj.Type, Hash: obj.Hash}
+	} else if obj.Hash != u.partial.Hash || obj.Type != u.partial.Type {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("object %s started before the last part of %s", obj.Hash, u.partial.Hash))
+	} else if obj.Offset != int64(len(u.partial.Data)) {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("object %s: expected part at offset %d, got %d", obj.Hash, len(u.partial.Data), obj.Offset))
+	}
+
+	if len(u.partial.Data)+len(obj.Data) > maxObjectSize {
+		return status.Error(codes.ResourceExhausted, fmt.Sprintf("object %s exceeds the %d byte object size limit", obj.Hash, maxObjectSize))
+	}
+	u.partial.Data = append(u.partial.Data, obj.Data...)
+	if obj.More {
+		return nil
+	}
+	whole := u.partial
+	u.partial = nil
+	return u.add(ctx, whole)
+}
+
+// rootTree verifies that every object referenced by an uploaded tree exists and
+// returns the single tree no other uploaded tree points at. It returns an empty
+// hash when no trees were uploaded.
+func (u *objectUpload) rootTree(ctx context.Context) (string, error) {
+	if u.partial != nil {
+		return "", status.Error(codes.InvalidArgument, fmt.Sprintf("object %s is missing its last part", u.partial.Hash))
+	}
+	for hash := range u.referenced {
+		exists, err := u.storage.HasObject(ctx, hash)
+		if err != nil {
+			return "", status.Error(codes.Internal, fmt.Sprintf("failed to look up object %s: %v", hash, err))
+		}
+		if !exists {
+			return "", status.Error(codes.InvalidArgument, fmt.Sprintf("missing object referenced by tree: %s", hash))
+		}
+	}
+
+	var root string
+	for _, hash := range u.trees {
+		if u.referenced[hash] || hash == root {
+			continue
+		}
+		if root != "" {
+			return "", status.Error(codes.InvalidArgument, "changeset contains more than one root tree")
+		}
+		root = hash
+	}
+
+	return root, nil
+}
+
+// commitSnapshot returns the files recorded by a slice commit as a path -> blob
+// hash map. Commits made before slices were versioned in the object store have
+// no tree, so the slice's stored file contents are written as blobs instead.
+func (s *sliceServiceServer) commitSnapshot(ctx context.Context, sliceID, commitHash string) (map[string]string, error) {
+	if objects.IsHash(commitHash) {
+		commit, err := storage.ReadCommit(ctx, s.storage, commitHash)
+		if err == nil {
+			return storage.ReadSnapshot(ctx, s.storage, commit.TreeHash)
+		}
+		if !errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, err
+		}
+	}
+
+	files, err := s.storage.GetSliceFiles(ctx, sliceID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := make(map[string]string, len(files))
+	for _, file := range files {
+		filePath := file.Path
+		if filePath == "" {
+			filePath = file.FileID
+		}
+		clean, err := storage.CleanPath(filePath)
+		if err != nil {
+			return nil, err
+		}
+		blobHash, err := storage.WriteBlob(ctx, s.storage, file.Content)
+		if err != nil {
+			return nil, err
+		}
+		snapshot[clean] = blobHash
+	}
+	return snapshot, nil
+}
+
+// applyChangeset overlays the changeset's uploaded tree on a snapshot. When the
+// changeset carries a tree, modified files missing from it are deletions.
+func (s *sliceServiceServer) applyChangeset(ctx context.Context, base map[string]string, cs *models.Changeset) (map[string]string, error) {
+	result := make(map[string]string, len(base))
+	for p, hash := range base {
+		result[p] = hash
+	}
+	if cs.TreeHash == "" {
+		return result, nil
+	}
+
+	overlay, err := storage.ReadSnapshot(ctx, s.storage, cs.TreeHash)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range cs.ModifiedFiles {
+		clean, err := storage.CleanPath(file)
+		if err != nil {
+			continue
+		}
+		if _, ok := overlay[clean]; !ok {
+			delete(result, clean)
+		}
+	}
+	for p, hash := range overlay {
+		result[p] = hash
+	}
+	return result, nil
+}
+
+// writeSliceCommit stores the snapshot as a tree and records a commit object on
+// top of parentHash, returning the new commit.
+func (s *sliceServiceServer) writeSliceCommit(ctx context.Context, sliceID, parentHash string, files map[string]string, author, message string, when time.Time) (*models.Commit, error) {
+	treeHash, err := storage.WriteSnapshot(ctx, s.storage, files)
+	if err != nil {
+		return nil, err
+	}
+
+	commit := &models.Commit{
+		TreeHash:  treeHash,
+		SliceID:   sliceID,
+		Author:    author,
+		Timestamp: when,
+		Message:   message,
+	}
+	if objects.IsHash(parentHash) {
+		commit.ParentHash = parentHash
+	}
+	if _, err := storage.WriteCommit(ctx, s.storage, commit); err != nil {
+		return nil, err
+	}
+	return commit, nil
+}
+
+// checkoutCommit builds a checkout response from the tree of a commit object.
+// File contents are only included when withFiles is set.
+func (s *sliceServiceServer) checkoutCommit(ctx context.Context, commit *models.Commit, withFiles bool) (*slicev1.CheckoutResponse, error) {
+	files, err := storage.ReadSnapshot(ctx, s.storage, commit.TreeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &slicev1.CheckoutResponse{
+		Manifest: &slicev1.SliceManifest{CommitHash: commit.CommitHash},
+	}
+	for _, filePath := range storage.SnapshotPaths(files) {
+		var content []byte
+		var size int64
+		if withFiles {
+			if content, err = storage.ReadBlob(ctx, s.storage, files[filePath]); err != nil {
+				return nil, err
+			}
+			size = int64(len(content))
+			resp.Files = append(resp.Files, &slicev1.FileContent{
+				FileId:  filePath,
+				Content: content,
+			})
+		} else if size, err = storage.BlobSize(ctx, s.storage, files[filePath]); err != nil {
+			return nil, err
+		}
+		resp.Manifest.FileMetadata = append(resp.Manifest.FileMetadata, &slicev1.FileMetadata{
+			FileId: filePath,
+			Path:   filePath,
+			Size:   size,
+			Hash:   files[filePath],
+		})
+	}
+	return resp, nil
+}
+
+// checkoutTarget resolves a checkout request to the commit object to serve,
+// along with the slice's metadata. The commit is nil when the request names the
+// head of a slice that predates the object store; its files are then read from
+// the slice itself.
+func (s *sliceServiceServer) checkoutTarget(ctx context.Context, req *slicev1.CheckoutRequest) (*models.Commit, *models.SliceMetadata, error) {
+	metadata, err := s.storage.GetSliceMetadata(ctx, req.SliceId)
+	if err != nil {
+		return nil, nil, status.Error(codes.NotFound, fmt.Sprintf("slice not found: %s", req.SliceId))
+	}
+
+	commitHash, err := s.resolveSliceCommit(ctx, req.SliceId, metadata.HeadCommitHash, req.CommitHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if objects.IsHash(commitHash) {
+		commit, err := storage.ReadCommit(ctx, s.storage, commitHash)
+		if err == nil {
+			return commit, metadata, nil
+		}
+		if !errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, nil, status.Error(codes.Internal, fmt.Sprintf("failed to read commit: %v", err))
+		}
+	}
+	if commitHash != metadata.HeadCommitHash {
+		return nil, nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("commit %s has no snapshot to check out", commitHash))
+	}
+	return nil, metadata, nil
+}
+
+// resolveSliceCommit maps a checkout reference to a commit in the slice's
+// history. An empty reference or HEAD selects the head; otherwise the reference
+// may be a full hash or an unambiguous prefix of one.
+func (s *sliceServiceServer) resolveSliceCommit(ctx context.Context, sliceID, head, ref string) (string, error) {
+	if ref == "" || ref == "HEAD" || ref == head {
+		return head, nil
+	}
+
+	commits, err := s.storage.ListSliceCommits(ctx, sliceID, 0, "")
+	if err != nil {
+		return "", status.Error(codes.Internal, fmt.Sprintf("failed to list commits: %v", err))
+	}
+
+	var match string
+	for _, commit := range commits {
+		if commit.CommitHash == ref {
+			return ref, nil
+		}
+		if strings.HasPrefix(commit.CommitHash, ref) && commit.CommitHash != match {
+			if match != "" {
+				return "", status.Error(codes.InvalidArgument, fmt.Sprintf("commit reference %s is ambiguous", ref))
+			}
+			match = commit.CommitHash
+		}
+	}
+	if match == "" {
+		return "", status.Error(codes.NotFound, fmt.Sprintf("commit %s not found in slice %s", ref, sliceID))
+	}
+	return match, nil
+}
+
+// FindMissingObjects lets clients skip uploading objects the server already holds.
+func (s *sliceServiceServer) FindMissingObjects(ctx context.Context, req *slicev1.FindMissingObjectsRequest) (*slicev1.FindMissingObjectsResponse, error) {
+	log.Printf("FindMissingObjects called: %d hashes", len(req.Hashes))
+
+	resp := &slicev1.FindMissingObjectsResponse{}
+	for _, hash := range req.Hashes {
+		if !objects.IsHash(hash) {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid object hash: %s", hash))
+		}
+		exists, err := s.storage.HasObject(ctx, hash)
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to look up object %s: %v", hash, err))
+		}
+		if !exists {
+			resp.MissingHashes = append(resp.MissingHashes, hash)
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,386 @@
+package sliceservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/niczy/gitslice/internal/models"
+	"github.com/niczy/gitslice/internal/storage"
+	slicev1 "github.com/niczy/gitslice/proto/slice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mergeQueueAttempts bounds how often a queued changeset is rebased and merged
+// again after losing a race for the slice head or its locks.
+const mergeQueueAttempts = 5
+
+// mergeQueueBackoff is the pause before each retry, multiplied by the attempt.
+const mergeQueueBackoff = 50 * time.Millisecond
+
+// mergeQueueOutcomeTTL is how long the final event of a changeset that left
+// the queue stays available to watchers that connect late.
+const mergeQueueOutcomeTTL = 10 * time.Minute
+
+// mergeQueue tracks the workers landing each slice's queued changesets and the
+// streams watching them. The queue itself lives in storage, so a restarted
+// server resumes it when the changeset is next watched or enqueued.
+type mergeQueue struct {
+	mu sync.Mutex
+	// running marks slices with an active worker; pending records that the
+	// slice's queue changed since its worker last listed it.
+	running map[string]bool
+	pending map[string]bool
+	// merging is the changeset each slice's worker is landing
+	merging  map[string]string
+	watchers map[string][]chan *slicev1.MergeQueueEvent
+	// outcomes keeps the final event of each changeset that left the queue
+	// for mergeQueueOutcomeTTL
+	outcomes map[string]queueOutcome
+}
+
+type queueOutcome struct {
+	event     *slicev1.MergeQueueEvent
+	expiresAt time.Time
+}
+
+func newMergeQueue() *mergeQueue {
+	return &mergeQueue{
+		running:  make(map[string]bool),
+		pending:  make(map[string]bool),
+		merging:  make(map[string]string),
+		watchers: make(map[string][]chan *slicev1.MergeQueueEvent),
+		outcomes: make(map[string]queueOutcome),
+	}
+}
+
+// subscribe returns a channel carrying the latest event for a changeset. Only
+// the newest event is kept, so slow watchers skip positions but never miss the
+// final outcome.
+func (q *mergeQueue) subscribe(changesetID string) (<-chan *slicev1.MergeQueueEvent, func()) {
+	ch := make(chan *slicev1.MergeQueueEvent, 1)
+
+	q.mu.Lock()
+	q.watchers[changesetID] = append(q.watchers[changesetID], ch)
+	q.mu.Unlock()
+
+	return ch, func() {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		watchers := q.watchers[changesetID]
+		for i, watcher := range watchers {
+			if watcher == ch {
+				q.watchers[changesetID] = append(watchers[:i:i], watchers[i+1:]...)
+				break
+			}
+		}
+		if len(q.watchers[changesetID]) == 0 {
+			delete(q.watchers, changesetID)
+		}
+	}
+}
+
+// publish hands an event to every watcher of its changeset, replacing any
+// event they have not read yet. Recording a final event also drops outcomes
+// that have expired.
+func (q *mergeQueue) publish(event *slicev1.MergeQueueEvent) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if isFinal(event) {
+		now := time.Now()
+		for id, outcome := range q.outcomes {
+			if now.After(outcome.expiresAt) {
+				delete(q.outcomes, id)
+			}
+		}
+		q.outcomes[event.ChangesetId] = queueOutcome{event: event, expiresAt: now.Add(mergeQueueOutcomeTTL)}
+	}
+	for _, ch := range q.watchers[event.ChangesetId] {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- event
+	}
+}
+
+func isFinal(event *slicev1.MergeQueueEvent) bool {
+	return event.State == slicev1.MergeQueueState_MERGE_QUEUE_STATE_MERGED || event.State == slicev1.MergeQueueState_MERGE_QUEUE_STATE_FAILED
+}
+
+// EnqueueMerge adds an approved changeset to its slice's merge queue. A worker
+// rebases and merges queued changesets one at a time, in the order they were
+// enqueued.
+func (s *sliceServiceServer) EnqueueMerge(ctx context.Context, req *slicev1.EnqueueMergeRequest) (*slicev1.EnqueueMergeResponse, error) {
+	log.Printf("EnqueueMerge called: changeset_id=%s, requested_by=%s", req.ChangesetId, req.RequestedBy)
+
+	cs, err := s.storage.GetChangeset(ctx, req.ChangesetId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("changeset not found: %s", req.ChangesetId))
+	}
+	if err := requireOpen(cs); err != nil {
+		return nil, err
+	}
+
+	slice, err := s.storage.GetSlice(ctx, cs.SliceID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("slice not found: %s", cs.SliceID))
+	}
+	if problem := approvalProblem(slice, cs); problem != "" {
+		return nil, status.Error(codes.FailedPrecondition, problem)
+	}
+	if problem := checksProblem(slice, cs); problem != "" {
+		return nil, status.Error(codes.FailedPrecondition, problem)
+	}
+	queue, err := s.storage.ListMergeQueue(ctx, cs.SliceID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list merge queue: %v", err))
+	}
+	if problem := s.stackProblem(ctx, cs); problem != "" && queuePosition(queue, cs.ParentChangesetID) == 0 {
+		// A parent queued ahead of it lands first, so only an unqueued parent blocks
+		return nil, status.Error(codes.FailedPrecondition, problem)
+	}
+
+	s.queue.mu.Lock()
+	delete(s.queue.outcomes, cs.ID)
+	s.queue.mu.Unlock()
+
+	entry := &models.MergeQueueEntry{SliceID: cs.SliceID, ChangesetID: cs.ID, EnqueuedBy: req.RequestedBy, EnqueuedAt: time.Now()}
+	if err := s.storage.EnqueueMerge(ctx, entry); err != nil {
+		if errors.Is(err, storage.ErrEntryExists) {
+			return nil, status.Error(codes.AlreadyExists, fmt.Sprintf("changeset %s is already queued", cs.ID))
+		}
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to enqueue changeset: %v", err))
+	}
+	queue = append(queue, entry)
+	s.kickMergeQueue(cs.SliceID)
+
+	return &slicev1.EnqueueMergeResponse{
+		ChangesetId: cs.ID,
+		SliceId:     cs.SliceID,
+		Position:    int32(queuePosition(queue, cs.ID)),
+	}, nil
+}
+
+// WatchMergeQueue streams a queued changeset's position until it merges or
+// fails. The first event describes where the changeset is now.
+func (s *sliceServiceServer) WatchMergeQueue(req *slicev1.WatchMergeQueueRequest, stream slicev1.SliceService_WatchMergeQueueServer) error {
+	log.Printf("WatchMergeQueue called: changeset_id=%s", req.ChangesetId)
+	ctx := stream.Context()
+
+	cs, err := s.storage.GetChangeset(ctx, req.ChangesetId)
+	if err != nil {
+		return status.Error(codes.NotFound, fmt.Sprintf("changeset not found: %s", req.ChangesetId))
+	}
+
+	// Subscribe before reading the current state so no event slips between
+	events, cancel := s.queue.subscribe(cs.ID)
+	defer cancel()
+
+	current, err := s.mergeQueueEvent(ctx, cs)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(current); err != nil {
+		return err
+	}
+	if isFinal(current) {
+		return nil
+	}
+	s.kickMergeQueue(cs.SliceID)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case event := <-events:
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+			if isFinal(event) {
+				return nil
+			}
+		}
+	}
+}
+
+// mergeQueueEvent describes where a changeset currently stands in its slice's
+// merge queue.
+func (s *sliceServiceServer) mergeQueueEvent(ctx context.Context, cs *models.Changeset) (*slicev1.MergeQueueEvent, error) {
+	event := &slicev1.MergeQueueEvent{ChangesetId: cs.ID, SliceId: cs.SliceID}
+
+	// The worker marks a changeset merging before it leaves the queue and
+	// records its outcome before clearing that mark, so one of them holds
+	queue, err := s.storage.ListMergeQueue(ctx, cs.SliceID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list merge queue: %v", err))
+	}
+	s.queue.mu.Lock()
+	outcome, finished := s.queue.outcomes[cs.ID]
+	merging := s.queue.merging[cs.SliceID] == cs.ID
+	s.queue.mu.Unlock()
+
+	switch position := queuePosition(queue, cs.ID); {
+	case finished && time.Now().Before(outcome.expiresAt):
+		return outcome.event, nil
+	case merging:
+		event.State = slicev1.MergeQueueState_MERGE_QUEUE_STATE_MERGING
+	case position > 0:
+		event.State = slicev1.MergeQueueState_MERGE_QUEUE_STATE_QUEUED
+		event.Position = int32(position)
+	case cs.Status == models.ChangesetStatusMerged:
+		event.State = slicev1.MergeQueueState_MERGE_QUEUE_STATE_MERGED
+	default:
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("changeset %s is not in the merge queue", cs.ID))
+	}
+	return event, nil
+}
+
+// queuePosition returns a changeset's 1-based position in a queue, or 0 when
+// it is not queued.
+func queuePosition(queue []*models.MergeQueueEntry, changesetID string) int {
+	for i, entry := range queue {
+		if entry.ChangesetID == changesetID {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// kickMergeQueue starts a worker for the slice unless one is already running,
+// in which case the worker lists the queue again before it stops.
+func (s *sliceServiceServer) kickMergeQueue(sliceID string) {
+	s.queue.mu.Lock()
+	defer s.queue.mu.Unlock()
+
+	s.queue.pending[sliceID] = true
+	if s.queue.running[sliceID] {
+		return
+	}
+	s.queue.running[sliceID] = true
+	go s.drainMergeQueue(sliceID)
+}
+
+// drainMergeQueue lands a slice's queued changesets oldest first until the
+// queue is empty.
+func (s *sliceServiceServer) drainMergeQueue(sliceID string) {
+	ctx := context.Background()
+	for {
+		s.queue.mu.Lock()
+		s.queue.pending[sliceID] = false
+		s.queue.mu.Unlock()
+
+		queue, err := s.storage.ListMergeQueue(ctx, sliceID)
+		if err != nil {
+			log.Printf("merge queue for slice %s stopped: %v", sliceID, err)
+			queue = nil
+		}
+		if len(queue) == 0 {
+			s.queue.mu.Lock()
+			if s.queue.pending[sliceID] && err == nil {
+				s.queue.mu.Unlock()
+				continue
+			}
+			delete(s.queue.running, sliceID)
+			delete(s.queue.pending, sliceID)
+			s.queue.mu.Unlock()
+			return
+		}
+
+		head := queue[0]
+		s.queue.mu.Lock()
+		s.queue.merging[sliceID] = head.ChangesetID
+		s.queue.mu.Unlock()
+		s.queue.publish(&slicev1.MergeQueueEvent{ChangesetId: head.ChangesetID, SliceId: sliceID, State: slicev1.MergeQueueState_MERGE_QUEUE_STATE_MERGING})
+		for i, entry := range queue[1:] {
+			s.queue.publish(&slicev1.MergeQueueEvent{ChangesetId: entry.ChangesetID, SliceId: sliceID, State: slicev1.MergeQueueState_MERGE_QUEUE_STATE_QUEUED, Position: int32(i + 2)})
+		}
+
+		outcome := s.landQueued(ctx, head)
+		if err := s.storage.RemoveFromMergeQueue(ctx, sliceID, head.ChangesetID); err != nil && !errors.Is(err, storage.ErrEntryNotFound) {
+			log.Printf("failed to remove changeset %s from merge queue: %v", head.ChangesetID, err)
+		}
+		log.Printf("merge queue for slice %s: changeset %s finished as %s", sliceID, head.ChangesetID, outcome.State)
+
+		s.queue.publish(outcome)
+		s.queue.mu.Lock()
+		delete(s.queue.merging, sliceID)
+		s.queue.mu.Unlock()
+	}
+}
+
+// landQueued rebases a queued changeset onto the slice head and merges it.
+// Review passed when it was queued, so the rebase keeps its votes and checks.
+// Losing a race for the head or the slice locks rebases and tries again.
+func (s *sliceServiceServer) landQueued(ctx context.Context, entry *models.MergeQueueEntry) *slicev1.MergeQueueEvent {
+	event := &slicev1.MergeQueueEvent{ChangesetId: entry.ChangesetID, SliceId: entry.SliceID, State: slicev1.MergeQueueState_MERGE_QUEUE_STATE_FAILED}
+
+	for attempt := 1; ; attempt++ {
+		cs, err := s.storage.GetChangeset(ctx, entry.ChangesetID)
+		if err != nil {
+			event.Message = fmt.Sprintf("changeset not found: %s", entry.ChangesetID)
+			return event
+		}
+		if err := requireOpen(cs); err != nil {
+			event.Message = status.Convert(err).Message()
+			return event
+		}
+
+		rebased, err := s.rebaseChangeset(ctx, cs, true)
+		if err == nil && rebased.Status == slicev1.RebaseStatus_REBASE_STATUS_CONFLICT {
+			event.Message = fmt.Sprintf("changeset %s conflicts with slice %s; rebase and resolve before queueing again", cs.ID, cs.SliceID)
+			for _, conflict := range rebased.Conflicts {
+				event.Conflicts = append(event.Conflicts, conflict.FileId)
+			}
+			return event
+		}
+
+		var merged *slicev1.MergeChangesetResponse
+		if err == nil {
+			merged, err = s.MergeChangeset(ctx, &slicev1.MergeChangesetRequest{ChangesetId: cs.ID})
+		}
+		if err != nil {
+			if attempt < mergeQueueAttempts && s.worthRetrying(ctx, cs.ID, err) {
+				time.Sleep(time.Duration(attempt) * mergeQueueBackoff)
+				continue
+			}
+			event.Message = status.Convert(err).Message()
+			return event
+		}
+
+		if merged.Status != slicev1.MergeStatus_MERGE_STATUS_SUCCESS {
+			event.Message = fmt.Sprintf("changeset %s could not merge into slice %s", cs.ID, cs.SliceID)
+			for _, conflict := range merged.Conflicts {
+				event.Conflicts = append(event.Conflicts, conflict.FileId)
+			}
+			return event
+		}
+
+		event.State = slicev1.MergeQueueState_MERGE_QUEUE_STATE_MERGED
+		event.NewCommitHash = merged.NewCommitHash
+		return event
+	}
+}
+
+// worthRetrying reports whether a failed merge lost a race another merge can
+// resolve: the slice locks were held, or the head moved past the changeset's
+// base after it was rebased.
+func (s *sliceServiceServer) worthRetrying(ctx context.Context, changesetID string, err error) bool {
+	switch status.Code(err) {
+	case codes.Aborted:
+		return true
+	case codes.FailedPrecondition:
+		cs, err := s.storage.GetChangeset(ctx, changesetID)
+		if err != nil {
+			return false
+		}
+		metadata, err := s.storage.GetSliceMetadata(ctx, cs.SliceID)
+		return err == nil && metadata.HeadCommitHash != cs.BaseCommitHash
+	}
+	return false
+}
@@ -0,0 +1,189 @@
+package sliceservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/niczy/gitslice/internal/diff"
+	"github.com/niczy/gitslice/internal/merge"
+	"github.com/niczy/gitslice/internal/models"
+	"github.com/niczy/gitslice/internal/storage"
+)
+
+// errBaseNotInHistory is returned when a changeset's base is not one of its
+// slice's commits, so there is no range of commits to replay.
+var errBaseNotInHistory = errors.New("base commit is not in slice history")
+
+// commitsSince returns the slice commits after base up to and including head,
+// oldest first. An empty base means the changeset predates every commit.
+func (s *sliceServiceServer) commitsSince(ctx context.Context, sliceID, base, head string) ([]string, error) {
+	// History is listed newest first
+	commits, err := s.storage.ListSliceCommits(ctx, sliceID, 0, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var hashes []string
+	collecting, found := false, base == ""
+	for _, commit := range commits {
+		if commit.CommitHash == base {
+			found = true
+			break
+		}
+		if commit.CommitHash == head {
+			collecting = true
+		}
+		if collecting {
+			hashes = append(hashes, commit.CommitHash)
+		}
+	}
+	if !found {
+		return nil, errBaseNotInHistory
+	}
+
+	for i, j := 0, len(hashes)-1; i < j; i, j = i+1, j-1 {
+		hashes[i], hashes[j] = hashes[j], hashes[i]
+	}
+	return hashes, nil
+}
+
+// rebaseResult is the outcome of replaying a changeset onto a new base.
+type rebaseResult struct {
+	// files is the changeset's new overlay; paths absent from it but listed
+	// in the changeset's modified files are deletions.
+	files     map[string]string
+	conflicts []string
+	// marked holds the line merge of each conflicted text file, with conflict
+	// markers, so clients can resolve it in the working directory.
+	marked map[string]*merge.Result
+}
+
+// rebaseFiles three-way merges every file a changeset touches: the old base is
+// the common ancestor, the changeset is ours and the new head is theirs. Files
+// changed on both sides are merged line by line. Changesets without uploaded
+// content can only be compared by path.
+//
+// A stacked changeset's ancestor includes the parent revision it was stacked
+// on, and while the parent is still open (parent is non-nil) the parent's
+// current changes are laid over the new head.
+func (s *sliceServiceServer) rebaseFiles(ctx context.Context, cs, parent *models.Changeset, base, head string) (*rebaseResult, error) {
+	baseFiles, err := s.snapshotOf(ctx, base)
+	if errors.Is(err, errNoSnapshot) {
+		// Without a recorded ancestor, any file the head also has must be merged by hand
+		baseFiles, err = map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	headFiles, err := s.snapshotOf(ctx, head)
+	if errors.Is(err, errNoSnapshot) {
+		headFiles, err = map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cs.ParentTreeHash != "" {
+		stackedOn, err := s.storage.GetChangeset(ctx, cs.ParentChangesetID)
+		if err != nil {
+			return nil, err
+		}
+		if baseFiles, err = s.withOverlay(ctx, baseFiles, stackedOn, cs.ParentTreeHash); err != nil {
+			return nil, err
+		}
+	}
+	if parent != nil {
+		if headFiles, err = s.withOverlay(ctx, headFiles, parent, parent.TreeHash); err != nil {
+			return nil, err
+		}
+	}
+	ours, err := storage.ReadSnapshot(ctx, s.storage, cs.TreeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &rebaseResult{files: make(map[string]string), marked: make(map[string]*merge.Result)}
+	for p := range touchedPaths(cs, ours) {
+		baseHash, theirHash := baseFiles[p], headFiles[p]
+		ourHash, ok := ours[p]
+
+		if cs.TreeHash == "" {
+			if baseHash != theirHash {
+				result.conflicts = append(result.conflicts, p)
+			}
+			continue
+		}
+
+		switch {
+		case theirHash == baseHash || theirHash == ourHash:
+			// Only the changeset changed the file, or both made the same change
+		case ourHash == baseHash:
+			ourHash, ok = theirHash, theirHash != ""
+		default:
+			merged, err := s.mergeBlobs(ctx, baseHash, ourHash, theirHash, cs)
+			if err != nil {
+				return nil, err
+			}
+			if merged == nil || !merged.Clean() {
+				result.conflicts = append(result.conflicts, p)
+				if merged != nil {
+					result.marked[p] = merged
+				}
+				continue
+			}
+			if ourHash, err = storage.WriteBlob(ctx, s.storage, []byte(merged.Content)); err != nil {
+				return nil, err
+			}
+		}
+		if ok && ourHash != "" {
+			result.files[p] = ourHash
+		}
+	}
+
+	sort.Strings(result.conflicts)
+	return result, nil
+}
+
+// mergeBlobs line-merges two versions of a file that both changed since base.
+// It returns nil when the file was deleted on one side or is binary, since
+// neither can be merged by line.
+func (s *sliceServiceServer) mergeBlobs(ctx context.Context, baseHash, ourHash, theirHash string, cs *models.Changeset) (*merge.Result, error) {
+	if ourHash == "" || theirHash == "" {
+		return nil, nil
+	}
+
+	var contents [3][]byte
+	for i, hash := range []string{baseHash, ourHash, theirHash} {
+		if hash == "" {
+			continue
+		}
+		content, err := storage.ReadBlob(ctx, s.storage, hash)
+		if err != nil {
+			return nil, err
+		}
+		if diff.IsBinary(content) {
+			return nil, nil
+		}
+		contents[i] = content
+	}
+
+	result := merge.Merge(string(contents[0]), string(contents[1]), string(contents[2]), merge.Labels{
+		Ours:   "changeset " + cs.ID,
+		Theirs: "slice " + cs.SliceID,
+	})
+	return &result, nil
+}
+
+// staleBaseMessage explains why a changeset can't merge until it is rebased.
+func staleBaseMessage(cs *models.Changeset, head string) string {
+	return fmt.Sprintf("changeset %s is based on %s but slice %s is at %s; rebase before merging", cs.ID, cs.BaseCommitHash, cs.SliceID, head)
+}
+
+// describeRebaseError adds context to failures while walking slice history.
+func describeRebaseError(base string, err error) string {
+	if errors.Is(err, errBaseNotInHistory) {
+		return fmt.Sprintf("base commit %s is not in the slice history", base)
+	}
+	return fmt.Sprintf("failed to rebase changeset: %v", err)
+}
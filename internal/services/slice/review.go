@@ -0,0 +1,315 @@
+package sliceservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/niczy/gitslice/internal/diff"
+	"github.com/niczy/gitslice/internal/models"
+	"github.com/niczy/gitslice/internal/objects"
+	"github.com/niczy/gitslice/internal/storage"
+	slicev1 "github.com/niczy/gitslice/proto/slice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultDiffContext matches the context size of git and GNU diff.
+const defaultDiffContext = 3
+
+// maxDiffSize is the largest file version diffed line by line. Larger files
+// are reported as too large instead.
+const maxDiffSize = 1 << 20
+
+// fileChange is one path touched by a changeset relative to its base tree.
+// Hashes are empty on the side where the file does not exist or, for
+// changesets without uploaded content, where the new content is unknown.
+type fileChange struct {
+	path       string
+	changeType slicev1.FileChangeType
+	oldHash    string
+	newHash    string
+}
+
+// changesetBase returns the commit a changeset is compared against: its
+// recorded base, or the slice head when none was given.
+func (s *sliceServiceServer) changesetBase(ctx context.Context, cs *models.Changeset) (string, error) {
+	if cs.BaseCommitHash != "" {
+		return cs.BaseCommitHash, nil
+	}
+	metadata, err := s.storage.GetSliceMetadata(ctx, cs.SliceID)
+	if err != nil {
+		return "", err
+	}
+	return metadata.HeadCommitHash, nil
+}
+
+// changesetChanges lists the files a changeset adds, modifies or deletes
+// compared to the tree of baseHash. A stacked changeset is compared to that
+// tree with the changesets beneath it laid over, so it shows only its own
+// changes.
+func (s *sliceServiceServer) changesetChanges(ctx context.Context, cs *models.Changeset, baseHash string) ([]fileChange, error) {
+	base, err := s.commitSnapshot(ctx, cs.SliceID, baseHash)
+	if err != nil {
+		return nil, err
+	}
+	if base, err = s.withStack(ctx, base, cs); err != nil {
+		return nil, err
+	}
+	overlay, err := storage.ReadSnapshot(ctx, s.storage, cs.TreeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []fileChange
+	for p := range touchedPaths(cs, overlay) {
+		oldHash, inBase := base[p]
+		newHash, inOverlay := overlay[p]
+
+		switch {
+		case cs.TreeHash == "" && inBase:
+			// Name-only changesets carry no content to compare against
+			changes = append(changes, fileChange{path: p, changeType: slicev1.FileChangeType_FILE_CHANGE_TYPE_MODIFIED, oldHash: oldHash})
+		case cs.TreeHash == "":
+			changes = append(changes, fileChange{path: p, changeType: slicev1.FileChangeType_FILE_CHANGE_TYPE_ADDED})
+		case inOverlay && !inBase:
+			changes = append(changes, fileChange{path: p, changeType: slicev1.FileChangeType_FILE_CHANGE_TYPE_ADDED, newHash: newHash})
+		case inOverlay && oldHash != newHash:
+			changes = append(changes, fileChange{path: p, changeType: slicev1.FileChangeType_FILE_CHANGE_TYPE_MODIFIED, oldHash: oldHash, newHash: newHash})
+		case !inOverlay && inBase:
+			changes = append(changes, fileChange{path: p, changeType: slicev1.FileChangeType_FILE_CHANGE_TYPE_DELETED, oldHash: oldHash})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].path < changes[j].path })
+	return changes, nil
+}
+
+// touchedPaths returns the clean paths a changeset lists as modified together
+// with the paths in its uploaded tree.
+func touchedPaths(cs *models.Changeset, overlay map[string]string) map[string]bool {
+	paths := make(map[string]bool)
+	for _, file := range cs.ModifiedFiles {
+		if clean, err := storage.CleanPath(file); err == nil {
+			paths[clean] = true
+		}
+	}
+	for p := range overlay {
+		paths[p] = true
+	}
+	return paths
+}
+
+// checkBase compares a changeset's base with the slice head. A base behind the
+// head needs a rebase; if the commits since the base touched any of the
+// changeset's files, the changeset has conflicts and each file is reported.
+func (s *sliceServiceServer) checkBase(ctx context.Context, cs *models.Changeset) (slicev1.ReviewStatus, []string, error) {
+	warnings := []string{}
+	metadata, err := s.storage.GetSliceMetadata(ctx, cs.SliceID)
+	if err != nil {
+		return slicev1.ReviewStatus_READY_FOR_MERGE, nil, err
+	}
+	head := metadata.HeadCommitHash
+	if cs.BaseCommitHash == "" || cs.BaseCommitHash == head {
+		return slicev1.ReviewStatus_READY_FOR_MERGE, warnings, nil
+	}
+
+	warnings = append(warnings, fmt.Sprintf("Changeset based on old commit %s; slice head is %s. Consider rebase.", cs.BaseCommitHash, head))
+
+	changed, err := s.changedPaths(ctx, cs.BaseCommitHash, head)
+	if errors.Is(err, errNoSnapshot) {
+		warnings = append(warnings, fmt.Sprintf("Base commit %s has no snapshot; overlapping changes could not be checked.", cs.BaseCommitHash))
+		return slicev1.ReviewStatus_NEEDS_REBASE, warnings, nil
+	}
+	if err != nil {
+		return slicev1.ReviewStatus_READY_FOR_MERGE, nil, err
+	}
+
+	overlay, err := storage.ReadSnapshot(ctx, s.storage, cs.TreeHash)
+	if err != nil {
+		return slicev1.ReviewStatus_READY_FOR_MERGE, nil, err
+	}
+	var overlapping []string
+	for p := range touchedPaths(cs, overlay) {
+		if changed[p] {
+			overlapping = append(overlapping, p)
+		}
+	}
+	if len(overlapping) == 0 {
+		return slicev1.ReviewStatus_NEEDS_REBASE, warnings, nil
+	}
+
+	sort.Strings(overlapping)
+	for _, p := range overlapping {
+		warnings = append(warnings, fmt.Sprintf("File %s was changed on the slice since the changeset's base.", p))
+	}
+	return slicev1.ReviewStatus_HAS_CONFLICTS, warnings, nil
+}
+
+// errNoSnapshot marks commits recorded before slices were versioned as objects.
+var errNoSnapshot = errors.New("commit has no snapshot")
+
+// changedPaths returns the paths whose content differs between the trees of
+// two commit objects.
+func (s *sliceServiceServer) changedPaths(ctx context.Context, fromHash, toHash string) (map[string]bool, error) {
+	from, err := s.snapshotOf(ctx, fromHash)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.snapshotOf(ctx, toHash)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make(map[string]bool)
+	for p, hash := range from {
+		if to[p] != hash {
+			changed[p] = true
+		}
+	}
+	for p := range to {
+		if _, ok := from[p]; !ok {
+			changed[p] = true
+		}
+	}
+	return changed, nil
+}
+
+// snapshotOf reads the tree of a commit object, returning errNoSnapshot for
+// hashes that are not commit objects.
+func (s *sliceServiceServer) snapshotOf(ctx context.Context, commitHash string) (map[string]string, error) {
+	if !objects.IsHash(commitHash) {
+		return nil, errNoSnapshot
+	}
+	commit, err := storage.ReadCommit(ctx, s.storage, commitHash)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return nil, errNoSnapshot
+	}
+	if err != nil {
+		return nil, err
+	}
+	return storage.ReadSnapshot(ctx, s.storage, commit.TreeHash)
+}
+
+// diffFile computes line counts and hunks for a single change.
+func (s *sliceServiceServer) diffFile(ctx context.Context, change fileChange, contextLines int) (*slicev1.FileDiff, error) {
+	fileDiff := &slicev1.FileDiff{Path: change.path, ChangeType: change.changeType}
+
+	var oldContent, newContent []byte
+	if change.oldHash != "" {
+		data, err := storage.ReadBlob(ctx, s.storage, change.oldHash)
+		if err != nil {
+			return nil, err
+		}
+		oldContent = data
+	}
+	if change.newHash != "" {
+		data, err := storage.ReadBlob(ctx, s.storage, change.newHash)
+		if err != nil {
+			return nil, err
+		}
+		newContent = data
+	}
+	if change.changeType == slicev1.FileChangeType_FILE_CHANGE_TYPE_MODIFIED && change.newHash == "" {
+		return fileDiff, nil
+	}
+
+	if diff.IsBinary(oldContent) || diff.IsBinary(newContent) {
+		fileDiff.Binary = true
+		return fileDiff, nil
+	}
+	if len(oldContent) > maxDiffSize || len(newContent) > maxDiffSize {
+		fileDiff.TooLarge = true
+		return fileDiff, nil
+	}
+
+	edits := diff.Lines(string(oldContent), string(newContent))
+	added, removed := diff.Stat(edits)
+	fileDiff.LinesAdded = int64(added)
+	fileDiff.LinesRemoved = int64(removed)
+
+	for _, hunk := range diff.Hunks(edits, contextLines) {
+		protoHunk := &slicev1.DiffHunk{
+			OldStart: int32(hunk.OldStart),
+			OldLines: int32(hunk.OldLines),
+			NewStart: int32(hunk.NewStart),
+			NewLines: int32(hunk.NewLines),
+		}
+		for _, edit := range hunk.Edits {
+			protoHunk.Lines = append(protoHunk.Lines, edit.Prefixed())
+		}
+		fileDiff.Hunks = append(fileDiff.Hunks, protoHunk)
+	}
+	return fileDiff, nil
+}
+
+// diffChangeset diffs every file in a changeset against its base.
+func (s *sliceServiceServer) diffChangeset(ctx context.Context, cs *models.Changeset, contextLines int) (string, []*slicev1.FileDiff, error) {
+	baseHash, err := s.changesetBase(ctx, cs)
+	if err != nil {
+		return "", nil, err
+	}
+	changes, err := s.changesetChanges(ctx, cs, baseHash)
+	if err != nil {
+		return "", nil, err
+	}
+
+	files := make([]*slicev1.FileDiff, 0, len(changes))
+	for _, change := range changes {
+		fileDiff, err := s.diffFile(ctx, change, contextLines)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to diff %s: %w", change.path, err)
+		}
+		files = append(files, fileDiff)
+	}
+	return baseHash, files, nil
+}
+
+func summarizeDiff(files []*slicev1.FileDiff) *slicev1.DiffSummary {
+	summary := &slicev1.DiffSummary{}
+	for _, file := range files {
+		switch file.ChangeType {
+		case slicev1.FileChangeType_FILE_CHANGE_TYPE_ADDED:
+			summary.FilesAdded++
+		case slicev1.FileChangeType_FILE_CHANGE_TYPE_DELETED:
+			summary.FilesDeleted++
+		default:
+			summary.FilesModified++
+		}
+		summary.LinesAdded += file.LinesAdded
+		summary.LinesRemoved += file.LinesRemoved
+	}
+	return summary
+}
+
+// GetChangesetDiff returns unified hunks for every file in a changeset.
+func (s *sliceServiceServer) GetChangesetDiff(ctx context.Context, req *slicev1.ChangesetDiffRequest) (*slicev1.ChangesetDiffResponse, error) {
+	log.Printf("GetChangesetDiff called: changeset_id=%s", req.ChangesetId)
+
+	cs, err := s.storage.GetChangeset(ctx, req.ChangesetId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("changeset not found: %s", req.ChangesetId))
+	}
+
+	contextLines := defaultDiffContext
+	if req.ContextLines != nil {
+		if req.GetContextLines() < 0 {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("context lines must not be negative, got %d", req.GetContextLines()))
+		}
+		contextLines = int(req.GetContextLines())
+	}
+
+	baseHash, files, err := s.diffChangeset(ctx, cs, contextLines)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to diff changeset: %v", err))
+	}
+
+	return &slicev1.ChangesetDiffResponse{
+		ChangesetId:    cs.ID,
+		BaseCommitHash: baseHash,
+		Summary:        summarizeDiff(files),
+		Files:          files,
+	}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/niczy/gitslice/internal/models"
@@ -18,11 +19,13 @@ import (
 type sliceServiceServer struct {
 	slicev1.UnimplementedSliceServiceServer
 	storage storage.Storage
+	queue   *mergeQueue
 }
 
 func newSliceServiceServer(st storage.Storage) *sliceServiceServer {
 	return &sliceServiceServer{
 		storage: st,
+		queue:   newMergeQueue(),
 	}
 }
 
@@ -41,10 +44,18 @@ func NewService(st storage.Storage) slicev1.SliceServiceServer {
 func (s *sliceServiceServer) CheckoutSlice(ctx context.Context, req *slicev1.CheckoutRequest) (*slicev1.CheckoutResponse, error) {
 	log.Printf("CheckoutSlice called: slice_id=%s, commit_hash=%s", req.SliceId, req.CommitHash)
 
-	// Get slice metadata
-	metadata, err := s.storage.GetSliceMetadata(ctx, req.SliceId)
+	commit, metadata, err := s.checkoutTarget(ctx, req)
 	if err != nil {
-		return nil, status.Error(codes.NotFound, fmt.Sprintf("slice not found: %s", req.SliceId))
+		return nil, err
+	}
+
+	// Serve the commit's tree when the slice has been versioned
+	if commit != nil {
+		resp, err := s.checkoutCommit(ctx, commit, !req.ManifestOnly)
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to read commit tree: %v", err))
+		}
+		return resp, nil
 	}
 
 	// Get slice
@@ -86,11 +97,13 @@ func (s *sliceServiceServer) CheckoutSlice(ctx context.Context, req *slicev1.Che
 
 	// Convert file contents to proto format
 	var fileContents []*slicev1.FileContent
-	for _, file := range files {
-		fileContents = append(fileContents, &slicev1.FileContent{
-			FileId:  file.FileID,
-			Content: file.Content,
-		})
+	if !req.ManifestOnly {
+		for _, file := range files {
+			fileContents = append(fileContents, &slicev1.FileContent{
+				FileId:  file.FileID,
+				Content: file.Content,
+			})
+		}
 	}
 
 	manifest := &slicev1.SliceManifest{
@@ -112,20 +125,88 @@ func (s *sliceServiceServer) CreateChangeset(ctx context.Context, req *slicev1.C
 		return nil, status.Error(codes.NotFound, fmt.Sprintf("slice not found: %s", req.SliceId))
 	}
 
+	upload := newObjectUpload(s.storage)
+	for _, obj := range req.Objects {
+		if err := upload.add(ctx, obj); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.createChangeset(ctx, &slicev1.ChangesetMetadata{
+		SliceId:           req.SliceId,
+		BaseCommitHash:    req.BaseCommitHash,
+		Author:            req.Author,
+		Message:           req.Message,
+		ModifiedFiles:     req.ModifiedFiles,
+		ParentChangesetId: req.ParentChangesetId,
+	}, upload)
+}
+
+// createChangeset records a pending changeset once all of its objects have been
+// uploaded. Modified files default to the paths of the uploaded tree.
+func (s *sliceServiceServer) createChangeset(ctx context.Context, meta *slicev1.ChangesetMetadata, upload *objectUpload) (*slicev1.CreateChangesetResponse, error) {
+	if slice, err := s.storage.GetSlice(ctx, meta.SliceId); err == nil && slice.Archived {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("slice %s is archived", meta.SliceId))
+	}
+
+	treeHash, err := upload.rootTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	modifiedFiles := meta.ModifiedFiles
+	if len(modifiedFiles) == 0 && treeHash != "" {
+		files, err := storage.ReadSnapshot(ctx, s.storage, treeHash)
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to read changeset tree: %v", err))
+		}
+		modifiedFiles = storage.SnapshotPaths(files)
+	}
+
+	// A stacked changeset shares its parent's base; one without an explicit
+	// base was made against the current head
+	baseCommit := meta.BaseCommitHash
+	var parent *models.Changeset
+	if meta.ParentChangesetId != "" {
+		parent, err = s.storage.GetChangeset(ctx, meta.ParentChangesetId)
+		if err != nil {
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("parent changeset not found: %s", meta.ParentChangesetId))
+		}
+		if parent.SliceID != meta.SliceId {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("parent changeset %s belongs to slice %s", parent.ID, parent.SliceID))
+		}
+		if err := requireOpen(parent); err != nil {
+			return nil, err
+		}
+		baseCommit = parent.BaseCommitHash
+	}
+	if baseCommit == "" {
+		metadata, err := s.storage.GetSliceMetadata(ctx, meta.SliceId)
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to load slice metadata: %v", err))
+		}
+		baseCommit = metadata.HeadCommitHash
+	}
+
 	id := fmt.Sprintf("cs-%d", time.Now().UnixNano())
 	hash := fmt.Sprintf("hash-%d", time.Now().UnixNano())
 
 	cs := &models.Changeset{
 		ID:             id,
 		Hash:           hash,
-		SliceID:        req.SliceId,
-		BaseCommitHash: req.BaseCommitHash,
-		ModifiedFiles:  req.ModifiedFiles,
+		SliceID:        meta.SliceId,
+		BaseCommitHash: baseCommit,
+		TreeHash:       treeHash,
+		ModifiedFiles:  modifiedFiles,
 		Status:         models.ChangesetStatusPending,
-		Author:         req.Author,
-		Message:        req.Message,
+		Author:         meta.Author,
+		Message:        meta.Message,
 		CreatedAt:      time.Now(),
 	}
+	if parent != nil {
+		cs.ParentChangesetID = parent.ID
+		cs.ParentTreeHash = parent.TreeHash
+	}
 
 	if err := s.storage.CreateChangeset(ctx, cs); err != nil {
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to create changeset: %v", err))
@@ -146,19 +227,41 @@ func (s *sliceServiceServer) ReviewChangeset(ctx context.Context, req *slicev1.R
 		return nil, status.Error(codes.NotFound, fmt.Sprintf("changeset not found: %s", req.ChangesetId))
 	}
 
-	diff := &slicev1.DiffSummary{
-		FilesAdded:    int32(len(cs.ModifiedFiles)),
-		FilesModified: 0,
-		FilesDeleted:  0,
-		LinesAdded:    int64(len(cs.ModifiedFiles)),
-		LinesRemoved:  0,
+	_, files, err := s.diffChangeset(ctx, cs, 0)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to diff changeset: %v", err))
+	}
+
+	reviewStatus := slicev1.ReviewStatus_READY_FOR_MERGE
+	warnings := []string{}
+	switch cs.Status {
+	case models.ChangesetStatusMerged:
+	case models.ChangesetStatusAbandoned:
+		warnings = append(warnings, "Changeset was abandoned and cannot be merged.")
+	default:
+		reviewStatus, warnings, err = s.checkBase(ctx, cs)
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to compare base with slice head: %v", err))
+		}
+		if slice, err := s.storage.GetSlice(ctx, cs.SliceID); err == nil {
+			if problem := approvalProblem(slice, cs); problem != "" {
+				warnings = append(warnings, fmt.Sprintf("Not mergeable yet: %s.", problem))
+			}
+			warnings = append(warnings, checkWarnings(slice, cs)...)
+		}
+		if problem := s.stackProblem(ctx, cs); problem != "" {
+			warnings = append(warnings, fmt.Sprintf("Not mergeable yet: %s.", problem))
+		}
+		if open, err := s.unresolvedThreads(ctx, cs.ID); err == nil && open > 0 {
+			warnings = append(warnings, fmt.Sprintf("%d unresolved review comment thread(s).", open))
+		}
 	}
 
 	return &slicev1.ReviewChangesetResponse{
 		Changeset:    convertChangesetToProto(cs),
-		Diff:         diff,
-		ReviewStatus: slicev1.ReviewStatus_READY_FOR_MERGE,
-		Warnings:     []string{},
+		Diff:         summarizeDiff(files),
+		ReviewStatus: reviewStatus,
+		Warnings:     warnings,
 	}, nil
 }
 
@@ -169,6 +272,23 @@ func (s *sliceServiceServer) MergeChangeset(ctx context.Context, req *slicev1.Me
 	if err != nil {
 		return nil, status.Error(codes.NotFound, fmt.Sprintf("changeset not found: %s", req.ChangesetId))
 	}
+	if err := requireOpen(cs); err != nil {
+		return nil, err
+	}
+
+	slice, err := s.storage.GetSlice(ctx, cs.SliceID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("slice not found: %s", cs.SliceID))
+	}
+	if problem := approvalProblem(slice, cs); problem != "" {
+		return nil, status.Error(codes.FailedPrecondition, problem)
+	}
+	if problem := checksProblem(slice, cs); problem != "" {
+		return nil, status.Error(codes.FailedPrecondition, problem)
+	}
+	if problem := s.stackProblem(ctx, cs); problem != "" {
+		return nil, status.Error(codes.FailedPrecondition, problem)
+	}
 
 	if err := s.storage.LockSliceAndFiles(ctx, cs.SliceID, cs.ModifiedFiles); err != nil {
 		if errors.Is(err, storage.ErrLockHeld) {
@@ -178,23 +298,18 @@ func (s *sliceServiceServer) MergeChangeset(ctx context.Context, req *slicev1.Me
 	}
 	defer s.storage.UnlockSliceAndFiles(ctx, cs.SliceID, cs.ModifiedFiles)
 
-	var conflicts []*slicev1.Conflict
-	for _, fileID := range cs.ModifiedFiles {
-		slices, err := s.storage.GetActiveSlicesForFile(ctx, fileID)
-		if err != nil {
-			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to check conflicts: %v", err))
-		}
-
-		var conflictingSlices []string
-		for _, sliceID := range slices {
-			if sliceID != cs.SliceID {
-				conflictingSlices = append(conflictingSlices, sliceID)
-			}
-		}
+	metadata, err := s.storage.GetSliceMetadata(ctx, cs.SliceID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to load slice metadata: %v", err))
+	}
+	head := metadata.HeadCommitHash
+	if cs.BaseCommitHash != head {
+		return nil, status.Error(codes.FailedPrecondition, staleBaseMessage(cs, head))
+	}
 
-		if len(conflictingSlices) > 0 {
-			conflicts = append(conflicts, &slicev1.Conflict{FileId: fileID, ConflictingSliceIds: conflictingSlices})
-		}
+	conflicts, err := s.contentConflicts(ctx, cs)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to check conflicts: %v", err))
 	}
 
 	if len(conflicts) > 0 {
@@ -206,41 +321,57 @@ func (s *sliceServiceServer) MergeChangeset(ctx context.Context, req *slicev1.Me
 		}, nil
 	}
 
-	for _, fileID := range cs.ModifiedFiles {
-		if _, err := s.storage.ResolveConflict(ctx, fileID, cs.SliceID); err != nil {
-			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to resolve conflicts for %s: %v", fileID, err))
-		}
-		if err := s.storage.AddFileToSlice(ctx, fileID, cs.SliceID); err != nil {
-			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to mark file ownership: %v", err))
-		}
+	// Apply the changeset's files on top of the slice head and snapshot the result
+	base, err := s.commitSnapshot(ctx, cs.SliceID, head)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to read slice tree: %v", err))
+	}
+	merged, err := s.applyChangeset(ctx, base, cs)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to apply changeset: %v", err))
 	}
 
-	newCommit := fmt.Sprintf("commit-%d", time.Now().UnixNano())
-	cs.Status = models.ChangesetStatusMerged
 	now := time.Now()
-	cs.MergedAt = &now
-
-	if err := s.storage.UpdateChangeset(ctx, cs); err != nil {
-		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to update changeset: %v", err))
+	commit, err := s.writeSliceCommit(ctx, cs.SliceID, head, merged, cs.Author, cs.Message, now)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to write commit: %v", err))
 	}
+	newCommit := commit.CommitHash
 
-	metadata, err := s.storage.GetSliceMetadata(ctx, cs.SliceID)
-	if err == nil {
-		parentHash := metadata.HeadCommitHash
-		metadata.HeadCommitHash = newCommit
-		metadata.ModifiedFiles = cs.ModifiedFiles
-		metadata.ModifiedFilesCount = len(cs.ModifiedFiles)
-		_ = s.storage.UpdateSliceMetadata(ctx, cs.SliceID, metadata)
-
-		_ = s.storage.AddSliceCommit(ctx, cs.SliceID, &models.Commit{
-			CommitHash: newCommit,
-			ParentHash: parentHash,
-			Timestamp:  now,
-			Message:    cs.Message,
-		})
+	cs.Status = models.ChangesetStatusMerged
+	cs.MergedAt = &now
+	metadata.HeadCommitHash = newCommit
+	metadata.ModifiedFiles = cs.ModifiedFiles
+	metadata.ModifiedFilesCount = len(cs.ModifiedFiles)
+	metadata.LastModified = now
+
+	for attempt := 1; ; attempt++ {
+		// Every write below lands together, and only if no other merge moved the head
+		batch := storage.NewBatch()
+		batch.ExpectHead(cs.SliceID, head)
+		for _, fileID := range cs.ModifiedFiles {
+			batch.AddFileToSlice(fileID, cs.SliceID)
+		}
+		batch.UpdateChangeset(cs)
+		batch.UpdateSliceMetadata(cs.SliceID, metadata)
+		batch.AddSliceCommit(cs.SliceID, commit)
+
+		if err := s.promoteSlice(ctx, batch, commit, merged, cs.ModifiedFiles); err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to promote slice %s to global state: %v", cs.SliceID, err))
+		}
 
-		if err := s.promoteSlice(ctx, cs.SliceID, newCommit, cs.ModifiedFiles, now); err != nil {
-			log.Printf("failed to promote slice %s to global state: %v", cs.SliceID, err)
+		err := s.storage.CommitBatch(ctx, batch)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, storage.ErrHeadMoved) {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to commit merge: %v", err))
+		}
+		// The slice is locked, so a moved head there means a merge in
+		// another slice advanced the root first; promote on top of it
+		current, metaErr := s.storage.GetSliceMetadata(ctx, cs.SliceID)
+		if metaErr != nil || current.HeadCommitHash != head || attempt >= promoteAttempts {
+			return nil, status.Error(codes.FailedPrecondition, staleBaseMessage(cs, head))
 		}
 	}
 
@@ -253,27 +384,206 @@ func (s *sliceServiceServer) MergeChangeset(ctx context.Context, req *slicev1.Me
 }
 
 func (s *sliceServiceServer) RebaseChangeset(ctx context.Context, req *slicev1.RebaseChangesetRequest) (*slicev1.RebaseChangesetResponse, error) {
-	log.Printf("RebaseChangeset called: changeset_id=%s", req.ChangesetId)
+	log.Printf("RebaseChangeset called: changeset_id=%s, restack=%t", req.ChangesetId, req.Restack)
 
 	cs, err := s.storage.GetChangeset(ctx, req.ChangesetId)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, fmt.Sprintf("changeset not found: %s", req.ChangesetId))
 	}
+	if err := requireOpen(cs); err != nil {
+		return nil, err
+	}
 
-	newBase := fmt.Sprintf("base-%d", time.Now().UnixNano())
-	cs.BaseCommitHash = newBase
+	response, err := s.rebaseChangeset(ctx, cs, false)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := s.stackedOn(ctx, cs)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list stacked changesets: %v", err))
+	}
+	for _, child := range children {
+		response.StackedChangesets = append(response.StackedChangesets, child.ID)
+	}
+	if req.Restack && response.Status == slicev1.RebaseStatus_REBASE_STATUS_SUCCESS {
+		if response.Restacked, err = s.restack(ctx, cs); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+// rebaseChangeset moves a changeset onto the slice head, or onto its parent
+// when it is stacked on a changeset that has not merged yet. Rewriting the
+// content drops its votes and check results unless keepReview is set, which
+// the merge queue uses: it only lands conflict-free rebases of changesets that
+// passed review when they were queued.
+func (s *sliceServiceServer) rebaseChangeset(ctx context.Context, cs *models.Changeset, keepReview bool) (*slicev1.RebaseChangesetResponse, error) {
+	head, parent, err := s.rebaseTarget(ctx, cs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the slice commits the changeset has not seen yet
+	var toApply []string
+	if cs.BaseCommitHash != head {
+		toApply, err = s.commitsSince(ctx, cs.SliceID, cs.BaseCommitHash, head)
+		if errors.Is(err, errBaseNotInHistory) {
+			return nil, status.Error(codes.FailedPrecondition, describeRebaseError(cs.BaseCommitHash, err))
+		}
+		if err != nil {
+			return nil, status.Error(codes.Internal, describeRebaseError(cs.BaseCommitHash, err))
+		}
+	}
+
+	parentMoved := parent != nil && parent.TreeHash != cs.ParentTreeHash
+	if len(toApply) > 0 || parentMoved {
+		result, err := s.rebaseFiles(ctx, cs, parent, cs.BaseCommitHash, head)
+		if err != nil {
+			return nil, status.Error(codes.Internal, describeRebaseError(cs.BaseCommitHash, err))
+		}
+
+		if len(result.conflicts) > 0 {
+			conflicts := make([]*slicev1.Conflict, 0, len(result.conflicts))
+			for _, p := range result.conflicts {
+				conflicts = append(conflicts, &slicev1.Conflict{FileId: p, ConflictingSliceIds: []string{cs.SliceID}})
+			}
+			var conflicted []*slicev1.ConflictedFile
+			for _, p := range result.conflicts {
+				if marked, ok := result.marked[p]; ok {
+					conflicted = append(conflicted, &slicev1.ConflictedFile{
+						Path:          p,
+						Content:       []byte(marked.Content),
+						ConflictCount: int32(marked.Conflicts),
+					})
+				}
+			}
+			return &slicev1.RebaseChangesetResponse{
+				Status:              slicev1.RebaseStatus_REBASE_STATUS_CONFLICT,
+				NewBaseCommitHash:   head,
+				SliceCommitsToApply: toApply,
+				Conflicts:           conflicts,
+				ConflictedFiles:     conflicted,
+			}, nil
+		}
+
+		if cs.TreeHash != "" {
+			treeHash, err := storage.WriteSnapshot(ctx, s.storage, result.files)
+			if err != nil {
+				return nil, status.Error(codes.Internal, fmt.Sprintf("failed to write rebased tree: %v", err))
+			}
+			if keepReview {
+				cs.TreeHash = treeHash
+			} else {
+				setTree(cs, treeHash)
+			}
+		}
+	}
+
+	cs.BaseCommitHash = head
+	cs.ParentTreeHash = ""
+	if parent != nil {
+		cs.ParentTreeHash = parent.TreeHash
+	}
 	if err := s.storage.UpdateChangeset(ctx, cs); err != nil {
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to update changeset: %v", err))
 	}
 
 	return &slicev1.RebaseChangesetResponse{
 		Status:              slicev1.RebaseStatus_REBASE_STATUS_SUCCESS,
-		NewBaseCommitHash:   newBase,
-		SliceCommitsToApply: []string{},
+		NewBaseCommitHash:   head,
+		SliceCommitsToApply: toApply,
 		Conflicts:           []*slicev1.Conflict{},
 	}, nil
 }
 
+// setTree points a changeset at rebased content. Check reports and review
+// votes describe the tree they were made against, so they are dropped once
+// the content changes and the changeset goes back to waiting for review.
+func setTree(cs *models.Changeset, treeHash string) {
+	if cs.TreeHash == treeHash {
+		return
+	}
+	cs.TreeHash = treeHash
+	cs.Checks = nil
+	cs.Votes = nil
+	if cs.Status == models.ChangesetStatusApproved || cs.Status == models.ChangesetStatusRejected {
+		cs.Status = models.ChangesetStatusPending
+	}
+}
+
+func (s *sliceServiceServer) AbandonChangeset(ctx context.Context, req *slicev1.AbandonChangesetRequest) (*slicev1.AbandonChangesetResponse, error) {
+	log.Printf("AbandonChangeset called: changeset_id=%s", req.ChangesetId)
+
+	cs, err := s.storage.GetChangeset(ctx, req.ChangesetId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("changeset not found: %s", req.ChangesetId))
+	}
+	if err := requireOpen(cs); err != nil {
+		return nil, err
+	}
+
+	cs.Status = models.ChangesetStatusAbandoned
+	cs.AbandonReason = req.Reason
+	if err := s.storage.UpdateChangeset(ctx, cs); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to update changeset: %v", err))
+	}
+
+	return &slicev1.AbandonChangesetResponse{Changeset: convertChangesetToProto(cs)}, nil
+}
+
+// contentConflicts reports the changeset's files whose change clashes with
+// another slice claiming them, judged against the latest global commit's
+// tree: the changeset was built on an older version than the one another
+// slice published. Files the changeset leaves at the global version never
+// conflict. Changesets uploaded without content cannot be compared and
+// conflict with every other slice claiming their files.
+func (s *sliceServiceServer) contentConflicts(ctx context.Context, cs *models.Changeset) ([]*slicev1.Conflict, error) {
+	detector := storage.NewConflictDetector(s.storage)
+
+	var conflicts []*slicev1.Conflict
+	for _, fileID := range cs.ModifiedFiles {
+		slices, err := s.storage.GetActiveSlicesForFile(ctx, fileID)
+		if err != nil {
+			return nil, err
+		}
+		var others []string
+		for _, sliceID := range slices {
+			if sliceID != cs.SliceID {
+				others = append(others, sliceID)
+			}
+		}
+		if len(others) == 0 {
+			continue
+		}
+
+		p, err := storage.CleanPath(fileID)
+		if err != nil {
+			return nil, err
+		}
+		conflictingSlices, err := detector.ChangesetConflicts(ctx, cs, p, others)
+		if err != nil {
+			return nil, err
+		}
+		if len(conflictingSlices) > 0 {
+			conflicts = append(conflicts, &slicev1.Conflict{FileId: fileID, ConflictingSliceIds: conflictingSlices})
+		}
+	}
+	return conflicts, nil
+}
+
+// requireOpen rejects operations on changesets that have been merged or abandoned.
+func requireOpen(cs *models.Changeset) error {
+	switch cs.Status {
+	case models.ChangesetStatusMerged:
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("changeset %s is already merged", cs.ID))
+	case models.ChangesetStatusAbandoned:
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("changeset %s was abandoned", cs.ID))
+	}
+	return nil
+}
+
 func (s *sliceServiceServer) GetSliceCommits(ctx context.Context, req *slicev1.CommitHistoryRequest) (*slicev1.CommitHistoryResponse, error) {
 	log.Printf("GetSliceCommits called: slice_id=%s", req.SliceId)
 
@@ -292,6 +602,7 @@ func (s *sliceServiceServer) GetSliceCommits(ctx context.Context, req *slicev1.C
 			Timestamp:  commit.Timestamp.Unix(),
 			ParentHash: commit.ParentHash,
 			Message:    commit.Message,
+			FoldedHash: commit.FoldedHash,
 		})
 	}
 
@@ -323,13 +634,26 @@ func (s *sliceServiceServer) ListChangesets(ctx context.Context, req *slicev1.Li
 		statusFilter = &converted
 	}
 
-	changesets, err := s.storage.ListChangesets(ctx, req.SliceId, statusFilter, int(req.Limit))
+	// Abandoned changesets are dropped after listing, so the limit is applied here
+	skipAbandoned := statusFilter == nil && !req.IncludeAbandoned
+	limit := int(req.Limit)
+	if skipAbandoned {
+		limit = 0
+	}
+
+	changesets, err := s.storage.ListChangesets(ctx, req.SliceId, statusFilter, limit)
 	if err != nil {
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list changesets: %v", err))
 	}
 
 	response := &slicev1.ListChangesetsResponse{}
 	for _, cs := range changesets {
+		if skipAbandoned && cs.Status == models.ChangesetStatusAbandoned {
+			continue
+		}
+		if req.Limit > 0 && len(response.Changesets) == int(req.Limit) {
+			break
+		}
 		response.Changesets = append(response.Changesets, convertChangesetToProto(cs))
 	}
 
@@ -345,6 +669,8 @@ func convertChangesetToProto(cs *models.Changeset) *slicev1.ChangesetInfo {
 		status = slicev1.ChangesetStatus_REJECTED
 	case models.ChangesetStatusMerged:
 		status = slicev1.ChangesetStatus_MERGED
+	case models.ChangesetStatusAbandoned:
+		status = slicev1.ChangesetStatus_ABANDONED
 	}
 
 	var mergedAt int64
@@ -353,16 +679,20 @@ func convertChangesetToProto(cs *models.Changeset) *slicev1.ChangesetInfo {
 	}
 
 	return &slicev1.ChangesetInfo{
-		ChangesetId:    cs.ID,
-		ChangesetHash:  cs.Hash,
-		SliceId:        cs.SliceID,
-		BaseCommitHash: cs.BaseCommitHash,
-		ModifiedFiles:  cs.ModifiedFiles,
-		Status:         status,
-		Author:         cs.Author,
-		Message:        cs.Message,
-		CreatedAt:      cs.CreatedAt.Unix(),
-		MergedAt:       mergedAt,
+		ChangesetId:       cs.ID,
+		ChangesetHash:     cs.Hash,
+		SliceId:           cs.SliceID,
+		BaseCommitHash:    cs.BaseCommitHash,
+		ModifiedFiles:     cs.ModifiedFiles,
+		Status:            status,
+		Author:            cs.Author,
+		Message:           cs.Message,
+		CreatedAt:         cs.CreatedAt.Unix(),
+		MergedAt:          mergedAt,
+		Votes:             convertVotesToProto(cs.Votes),
+		AbandonReason:     cs.AbandonReason,
+		ParentChangesetId: cs.ParentChangesetID,
+		Checks:            convertChecksToProto(cs.Checks),
 	}
 }
 
@@ -374,6 +704,8 @@ func convertProtoStatusToModel(status slicev1.ChangesetStatus) models.ChangesetS
 		return models.ChangesetStatusRejected
 	case slicev1.ChangesetStatus_MERGED:
 		return models.ChangesetStatusMerged
+	case slicev1.ChangesetStatus_ABANDONED:
+		return models.ChangesetStatusAbandoned
 	default:
 		return models.ChangesetStatusPending
 	}
@@ -395,38 +727,120 @@ func (s *sliceServiceServer) GetRootSlice(ctx context.Context, req *slicev1.GetR
 	}, nil
 }
 
+// CreateSliceFromFolder carves a folder out of a parent slice. The files under
+// the folder in the parent's head become the new slice's first commit, whose
+// parent is that head, and their claims move from the parent to the new slice.
+// A parent defined by patterns excludes the folder from then on.
 func (s *sliceServiceServer) CreateSliceFromFolder(ctx context.Context, req *slicev1.CreateSliceFromFolderRequest) (*slicev1.CreateSliceFromFolderResponse, error) {
 	log.Printf("CreateSliceFromFolder called: parent_slice_id=%s, folder_path=%s, new_slice_id=%s",
 		req.ParentSliceId, req.FolderPath, req.NewSliceId)
 
+	if req.NewSliceId == "" {
+		return nil, status.Error(codes.InvalidArgument, "new_slice_id is required")
+	}
+	folder, err := storage.CleanPath(req.FolderPath)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid folder_path: %q", req.FolderPath))
+	}
+
 	parentSlice, err := s.storage.GetSlice(ctx, req.ParentSliceId)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, fmt.Sprintf("parent slice not found: %s", req.ParentSliceId))
 	}
+	if parentSlice.Archived {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("slice %s is archived", parentSlice.ID))
+	}
+	parentMetadata, err := s.storage.GetSliceMetadata(ctx, parentSlice.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get parent metadata: %v", err))
+	}
+	parentFiles, err := s.commitSnapshot(ctx, parentSlice.ID, parentMetadata.HeadCommitHash)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to read parent tree: %v", err))
+	}
+
+	carved := make(map[string]string)
+	for p, blobHash := range parentFiles {
+		if strings.HasPrefix(p, folder+"/") {
+			carved[p] = blobHash
+		}
+	}
+	if len(carved) == 0 {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("folder %s has no files in slice %s", folder, parentSlice.ID))
+	}
+	files := storage.SnapshotPaths(carved)
 
 	newSlice := &models.Slice{
 		ID:          req.NewSliceId,
 		Name:        req.Name,
 		Description: req.Description,
-		Files:       []string{},
-		Owners:      parentSlice.Owners,
-		CreatedBy:   "user",
+		Files:       files,
+		Owners:      append([]string(nil), parentSlice.Owners...),
+		CreatedBy:   req.CreatedBy,
 		ParentSlice: parentSlice.ID,
 		IsRoot:      false,
 	}
-
-	if err := s.storage.CreateSlice(ctx, newSlice); err != nil {
+	if _, err := s.storage.GetSlice(ctx, newSlice.ID); err == nil {
 		return nil, status.Error(codes.AlreadyExists, fmt.Sprintf("slice already exists: %s", req.NewSliceId))
 	}
 
+	now := time.Now()
+	message := fmt.Sprintf("Create slice %s from %s in %s", newSlice.ID, folder, parentSlice.ID)
+	commit, err := s.writeSliceCommit(ctx, newSlice.ID, parentMetadata.HeadCommitHash, carved, req.CreatedBy, message, now)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to write commit: %v", err))
+	}
+
+	// The slice, its first commit and the carve-out land together, and only
+	// if the parent still has the head the files were read from
+	batch := storage.NewBatch()
+	batch.ExpectHead(parentSlice.ID, parentMetadata.HeadCommitHash)
+	batch.CreateSlice(newSlice)
+	// A parent defined by patterns would still claim the folder through them,
+	// so it excludes the folder as well as giving up its listed files
+	if parentSlice.PathSpec().HasPatterns() {
+		parentSlice.Exclude = append(parentSlice.Exclude, folder+"/")
+		batch.UpdateSlice(parentSlice)
+	}
+	for _, fileID := range files {
+		batch.RemoveFileFromSlice(fileID, parentSlice.ID)
+	}
+	batch.UpdateSliceMetadata(newSlice.ID, &models.SliceMetadata{
+		SliceID:            newSlice.ID,
+		HeadCommitHash:     commit.CommitHash,
+		ModifiedFiles:      files,
+		ModifiedFilesCount: len(files),
+		LastModified:       now,
+	})
+	batch.AddSliceCommit(newSlice.ID, commit)
+	if err := s.storage.CommitBatch(ctx, batch); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrSliceAlreadyExists):
+			return nil, status.Error(codes.AlreadyExists, fmt.Sprintf("slice already exists: %s", req.NewSliceId))
+		case errors.Is(err, storage.ErrHeadMoved):
+			return nil, status.Error(codes.Aborted, fmt.Sprintf("slice %s moved while creating %s; retry", parentSlice.ID, newSlice.ID))
+		}
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to create slice: %v", err))
+	}
+
 	return &slicev1.CreateSliceFromFolderResponse{
-		SliceId: req.NewSliceId,
-		Status:  "created",
-		Files:   []string{},
+		SliceId:    req.NewSliceId,
+		Status:     "created",
+		Files:      files,
+		CommitHash: commit.CommitHash,
 	}, nil
 }
 
-func (s *sliceServiceServer) promoteSlice(ctx context.Context, sliceID, commitHash string, files []string, commitTime time.Time) error {
+// promoteAttempts bounds how often a merge re-reads the root head after a
+// merge in another slice moved it.
+const promoteAttempts = 5
+
+// promoteSlice queues the writes that carry a merged slice commit into the
+// root slice and the global timeline. A slice's tree only holds its own
+// files, so the paths it changed are applied on top of the root's current
+// tree to make a new root commit. The batch fails with ErrHeadMoved if the
+// root moves before it lands.
+func (s *sliceServiceServer) promoteSlice(ctx context.Context, batch *storage.Batch, commit *models.Commit, tree map[string]string, files []string) error {
 	rootSlice, err := s.storage.GetRootSlice(ctx)
 	if errors.Is(err, storage.ErrSliceNotFound) {
 		if initErr := s.storage.InitializeRootSlice(ctx); initErr != nil {
@@ -443,37 +857,34 @@ func (s *sliceServiceServer) promoteSlice(ctx context.Context, sliceID, commitHa
 		return fmt.Errorf("failed to load root metadata: %w", err)
 	}
 
-	rootMetadata.HeadCommitHash = commitHash
-	rootMetadata.ModifiedFiles = files
-	rootMetadata.ModifiedFilesCount = len(files)
-	rootMetadata.LastModified = commitTime
-
-	if err := s.storage.UpdateSliceMetadata(ctx, rootSlice.ID, rootMetadata); err != nil {
-		return fmt.Errorf("failed to update root metadata: %w", err)
-	}
-
-	state, err := s.storage.GetGlobalState(ctx)
-	if err != nil && !errors.Is(err, storage.ErrInvalidInput) {
-		return fmt.Errorf("failed to load global state: %w", err)
-	}
-	if state == nil {
-		state = &models.GlobalState{}
-	}
-
-	newCommit := &models.GlobalCommit{CommitHash: commitHash, Timestamp: commitTime, MergedSliceIDs: []string{sliceID}}
-	state.GlobalCommitHash = commitHash
-	state.Timestamp = commitTime
-	state.History = append([]*models.GlobalCommit{newCommit}, state.History...)
-
-	if err := s.storage.UpdateGlobalState(ctx, state); err != nil {
-		return fmt.Errorf("failed to update global state: %w", err)
+	rootCommit := commit
+	if commit.SliceID != rootSlice.ID {
+		rootHead := rootMetadata.HeadCommitHash
+		rootFiles, err := s.commitSnapshot(ctx, rootSlice.ID, rootHead)
+		if err != nil {
+			return fmt.Errorf("failed to read root tree: %w", err)
+		}
+		for _, p := range files {
+			if blobHash, ok := tree[p]; ok {
+				rootFiles[p] = blobHash
+			} else {
+				delete(rootFiles, p)
+			}
+		}
+		rootCommit, err = s.writeSliceCommit(ctx, rootSlice.ID, rootHead, rootFiles, commit.Author, commit.Message, commit.Timestamp)
+		if err != nil {
+			return fmt.Errorf("failed to write root commit: %w", err)
+		}
+		batch.ExpectHead(rootSlice.ID, rootHead)
+		batch.AddSliceCommit(rootSlice.ID, rootCommit)
 	}
 
-	if updatedState, err := s.storage.GetGlobalState(ctx); err == nil {
-		rootMetadata.HeadCommitHash = updatedState.GlobalCommitHash
-		rootMetadata.LastModified = updatedState.Timestamp
-		_ = s.storage.UpdateSliceMetadata(ctx, rootSlice.ID, rootMetadata)
-	}
+	rootMetadata.HeadCommitHash = rootCommit.CommitHash
+	rootMetadata.ModifiedFiles = files
+	rootMetadata.ModifiedFilesCount = len(files)
+	rootMetadata.LastModified = commit.Timestamp
+	batch.UpdateSliceMetadata(rootSlice.ID, rootMetadata)
 
+	batch.AppendGlobalCommit(&models.GlobalCommit{CommitHash: rootCommit.CommitHash, Timestamp: commit.Timestamp, MergedSliceIDs: []string{commit.SliceID}})
 	return nil
 }
@@ -0,0 +1,164 @@
+package sliceservice
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/niczy/gitslice/internal/models"
+	"github.com/niczy/gitslice/internal/storage"
+	slicev1 "github.com/niczy/gitslice/proto/slice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// rebaseTarget returns the commit a changeset should be rebased onto. A
+// changeset stacked on a parent that has not merged yet follows the parent,
+// which is returned so its changes can be laid over that commit.
+func (s *sliceServiceServer) rebaseTarget(ctx context.Context, cs *models.Changeset) (string, *models.Changeset, error) {
+	if cs.ParentChangesetID != "" {
+		parent, err := s.storage.GetChangeset(ctx, cs.ParentChangesetID)
+		if err != nil {
+			return "", nil, status.Error(codes.NotFound, fmt.Sprintf("parent changeset not found: %s", cs.ParentChangesetID))
+		}
+		switch parent.Status {
+		case models.ChangesetStatusAbandoned:
+			return "", nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("changeset %s is stacked on abandoned changeset %s", cs.ID, parent.ID))
+		case models.ChangesetStatusMerged:
+		default:
+			return parent.BaseCommitHash, parent, nil
+		}
+	}
+
+	metadata, err := s.storage.GetSliceMetadata(ctx, cs.SliceID)
+	if err != nil {
+		return "", nil, status.Error(codes.Internal, fmt.Sprintf("failed to load slice metadata: %v", err))
+	}
+	return metadata.HeadCommitHash, nil, nil
+}
+
+// withOverlay returns files with a revision of a changeset laid over them.
+// Paths the changeset lists but the revision's tree lacks are deletions.
+func (s *sliceServiceServer) withOverlay(ctx context.Context, files map[string]string, cs *models.Changeset, treeHash string) (map[string]string, error) {
+	if treeHash == "" {
+		return files, nil
+	}
+	overlay, err := storage.ReadSnapshot(ctx, s.storage, treeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string, len(files))
+	for p, hash := range files {
+		result[p] = hash
+	}
+	for p := range touchedPaths(cs, overlay) {
+		if hash, ok := overlay[p]; ok {
+			result[p] = hash
+		} else {
+			delete(result, p)
+		}
+	}
+	return result, nil
+}
+
+// withStack returns files with the changesets cs is stacked on laid over them,
+// lowest first, each at the revision the changeset above it was last stacked
+// against.
+func (s *sliceServiceServer) withStack(ctx context.Context, files map[string]string, cs *models.Changeset) (map[string]string, error) {
+	type layer struct {
+		cs       *models.Changeset
+		treeHash string
+	}
+	var layers []layer
+	seen := map[string]bool{cs.ID: true}
+	for current := cs; current.ParentTreeHash != "" && !seen[current.ParentChangesetID]; {
+		parent, err := s.storage.GetChangeset(ctx, current.ParentChangesetID)
+		if err != nil {
+			return nil, err
+		}
+		seen[parent.ID] = true
+		layers = append(layers, layer{cs: parent, treeHash: current.ParentTreeHash})
+		current = parent
+	}
+
+	for i := len(layers) - 1; i >= 0; i-- {
+		var err error
+		if files, err = s.withOverlay(ctx, files, layers[i].cs, layers[i].treeHash); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// stackedOn lists the open changesets stacked directly on cs, oldest first.
+func (s *sliceServiceServer) stackedOn(ctx context.Context, cs *models.Changeset) ([]*models.Changeset, error) {
+	changesets, err := s.storage.ListChangesets(ctx, cs.SliceID, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var children []*models.Changeset
+	for _, candidate := range changesets {
+		if candidate.ParentChangesetID == cs.ID && requireOpen(candidate) == nil {
+			children = append(children, candidate)
+		}
+	}
+	sort.Slice(children, func(i, j int) bool { return children[i].CreatedAt.Before(children[j].CreatedAt) })
+	return children, nil
+}
+
+// restack rebases every changeset stacked on cs onto its new revision, walking
+// down the stack. Changesets above a conflict are left where they are.
+func (s *sliceServiceServer) restack(ctx context.Context, cs *models.Changeset) ([]*slicev1.RestackResult, error) {
+	children, err := s.stackedOn(ctx, cs)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list stacked changesets: %v", err))
+	}
+
+	var results []*slicev1.RestackResult
+	for _, child := range children {
+		response, err := s.rebaseChangeset(ctx, child, false)
+		if err != nil {
+			return nil, err
+		}
+		result := &slicev1.RestackResult{
+			ChangesetId:       child.ID,
+			ParentChangesetId: cs.ID,
+			Status:            response.Status,
+		}
+		for _, conflict := range response.Conflicts {
+			result.Conflicts = append(result.Conflicts, conflict.FileId)
+		}
+		results = append(results, result)
+
+		if response.Status == slicev1.RebaseStatus_REBASE_STATUS_SUCCESS {
+			above, err := s.restack(ctx, child)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, above...)
+		}
+	}
+	return results, nil
+}
+
+// stackProblem explains why a stacked changeset may not merge yet, or returns
+// an empty string once its parent has merged.
+func (s *sliceServiceServer) stackProblem(ctx context.Context, cs *models.Changeset) string {
+	if cs.ParentChangesetID == "" {
+		return ""
+	}
+	parent, err := s.storage.GetChangeset(ctx, cs.ParentChangesetID)
+	if err != nil {
+		return fmt.Sprintf("changeset %s is stacked on %s, which no longer exists", cs.ID, cs.ParentChangesetID)
+	}
+	switch parent.Status {
+	case models.ChangesetStatusMerged:
+		return ""
+	case models.ChangesetStatusAbandoned:
+		return fmt.Sprintf("changeset %s is stacked on abandoned changeset %s", cs.ID, parent.ID)
+	default:
+		return fmt.Sprintf("changeset %s is stacked on %s, which must merge first", cs.ID, parent.ID)
+	}
+}
@@ -0,0 +1,142 @@
+package sliceservice
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/niczy/gitslice/internal/storage"
+	slicev1 "github.com/niczy/gitslice/proto/slice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkoutChunkSize bounds the file bytes carried by a single CheckoutChunk so
+// streamed checkouts stay well below gRPC's default 4MB message limit.
+const checkoutChunkSize = 1 << 20
+
+// StreamCheckoutSlice sends the slice manifest followed by file contents, read
+// one file at a time from the commit's tree so the whole checkout is never held
+// in memory. Files larger than checkoutChunkSize are split across consecutive
+// chunks that share a file ID; clients append them in order.
+func (s *sliceServiceServer) StreamCheckoutSlice(req *slicev1.CheckoutRequest, stream slicev1.SliceService_StreamCheckoutSliceServer) error {
+	log.Printf("StreamCheckoutSlice called: slice_id=%s, commit_hash=%s", req.SliceId, req.CommitHash)
+	ctx := stream.Context()
+
+	commit, _, err := s.checkoutTarget(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	// Slices that predate the object store keep their files in the slice record
+	if commit == nil {
+		resp, err := s.CheckoutSlice(ctx, req)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(&slicev1.CheckoutChunk{Chunk: &slicev1.CheckoutChunk_Manifest{Manifest: resp.Manifest}}); err != nil {
+			return err
+		}
+		for _, file := range resp.Files {
+			if err := sendCheckoutFile(stream, file.FileId, file.Content); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// The manifest carries file sizes, so blobs are read once to size them and
+	// again as their contents are sent
+	resp, err := s.checkoutCommit(ctx, commit, false)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("failed to read commit tree: %v", err))
+	}
+	if err := stream.Send(&slicev1.CheckoutChunk{Chunk: &slicev1.CheckoutChunk_Manifest{Manifest: resp.Manifest}}); err != nil {
+		return err
+	}
+	if req.ManifestOnly {
+		return nil
+	}
+
+	for _, fm := range resp.Manifest.FileMetadata {
+		content, err := storage.ReadBlob(ctx, s.storage, fm.Hash)
+		if err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to read %s: %v", fm.Path, err))
+		}
+		if err := sendCheckoutFile(stream, fm.FileId, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendCheckoutFile sends a file's content in chunks of at most
+// checkoutChunkSize bytes.
+func sendCheckoutFile(stream slicev1.SliceService_StreamCheckoutSliceServer, fileID string, content []byte) error {
+	for {
+		n := len(content)
+		if n > checkoutChunkSize {
+			n = checkoutChunkSize
+		}
+		chunk := &slicev1.FileContent{FileId: fileID, Content: content[:n]}
+		if err := stream.Send(&slicev1.CheckoutChunk{Chunk: &slicev1.CheckoutChunk_File{File: chunk}}); err != nil {
+			return err
+		}
+		content = content[n:]
+		if len(content) == 0 {
+			return nil
+		}
+	}
+}
+
+// StreamCreateChangeset accepts a ChangesetMetadata header followed by object
+// chunks. Objects too large for one message arrive as consecutive parts that
+// share a hash. Each object is verified and stored once it is complete; the
+// changeset is only recorded once the client closes the stream.
+func (s *sliceServiceServer) StreamCreateChangeset(stream slicev1.SliceService_StreamCreateChangesetServer) error {
+	ctx := stream.Context()
+
+	var meta *slicev1.ChangesetMetadata
+	upload := newObjectUpload(s.storage)
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch c := chunk.Chunk.(type) {
+		case *slicev1.ChangesetChunk_Metadata:
+			if meta != nil {
+				return status.Error(codes.InvalidArgument, "changeset metadata sent more than once")
+			}
+			meta = c.Metadata
+			log.Printf("StreamCreateChangeset called: slice_id=%s, author=%s", meta.SliceId, meta.Author)
+			if _, err := s.storage.GetSlice(ctx, meta.SliceId); err != nil {
+				return status.Error(codes.NotFound, fmt.Sprintf("slice not found: %s", meta.SliceId))
+			}
+		case *slicev1.ChangesetChunk_Object:
+			if meta == nil {
+				return status.Error(codes.InvalidArgument, "changeset metadata must be sent before objects")
+			}
+			if err := upload.addPart(ctx, c.Object); err != nil {
+				return err
+			}
+		default:
+			return status.Error(codes.InvalidArgument, "empty changeset chunk")
+		}
+	}
+
+	if meta == nil {
+		return status.Error(codes.InvalidArgument, "changeset metadata is required")
+	}
+
+	resp, err := s.createChangeset(ctx, meta, upload)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(resp)
+}
@@ -0,0 +1,184 @@
+package storage
+
+import "github.com/niczy/gitslice/internal/models"
+
+// Batch is a unit of work: a list of writes that CommitBatch applies together
+// or not at all. Head expectations are checked when the batch commits, so a
+// batch built from a stale read fails with ErrHeadMoved instead of landing.
+type Batch struct {
+	expectedHeads map[string]string
+	ops           []batchOp
+}
+
+type batchOpKind int
+
+const (
+	opCreateSlice batchOpKind = iota
+	opResolveConflict
+	opAddFileToSlice
+	opRemoveFileFromSlice
+	opSetSliceParent
+	opUpdateSlice
+	opArchiveSlice
+	opDeleteSlice
+	opUpdateChangeset
+	opUpdateSliceMetadata
+	opAddSliceCommit
+	opAppendGlobalCommit
+)
+
+type batchOp struct {
+	kind         batchOpKind
+	fileID       string
+	sliceID      string
+	parentID     string
+	slice        *models.Slice
+	changeset    *models.Changeset
+	metadata     *models.SliceMetadata
+	commit       *models.Commit
+	globalCommit *models.GlobalCommit
+}
+
+// NewBatch returns an empty batch.
+func NewBatch() *Batch {
+	return &Batch{expectedHeads: make(map[string]string)}
+}
+
+// ExpectHead makes the batch fail unless the slice head is still head.
+func (b *Batch) ExpectHead(sliceID, head string) {
+	b.expectedHeads[sliceID] = head
+}
+
+// CreateSlice adds a new slice with an empty head, claiming its files. The
+// batch fails with ErrSliceAlreadyExists if the ID is taken; later ops in the
+// batch may refer to the new slice.
+func (b *Batch) CreateSlice(slice *models.Slice) {
+	copySlice := *slice
+	copySlice.Files = append([]string(nil), slice.Files...)
+	b.ops = append(b.ops, batchOp{kind: opCreateSlice, sliceID: slice.ID, slice: &copySlice})
+}
+
+// ResolveConflict keeps only the preferred slice's claim on a file.
+func (b *Batch) ResolveConflict(fileID, preferredSliceID string) {
+	b.ops = append(b.ops, batchOp{kind: opResolveConflict, fileID: fileID, sliceID: preferredSliceID})
+}
+
+// AddFileToSlice records that a slice owns a file.
+func (b *Batch) AddFileToSlice(fileID, sliceID string) {
+	b.ops = append(b.ops, batchOp{kind: opAddFileToSlice, fileID: fileID, sliceID: sliceID})
+}
+
+// RemoveFileFromSlice drops a slice's claim on a file.
+func (b *Batch) RemoveFileFromSlice(fileID, sliceID string) {
+	b.ops = append(b.ops, batchOp{kind: opRemoveFileFromSlice, fileID: fileID, sliceID: sliceID})
+}
+
+// SetSliceParent moves a slice under another parent. An empty parentID makes
+// it a top-level slice again.
+func (b *Batch) SetSliceParent(sliceID, parentID string) {
+	b.ops = append(b.ops, batchOp{kind: opSetSliceParent, sliceID: sliceID, parentID: parentID})
+}
+
+// UpdateSlice replaces a slice's definition and re-indexes its files, as
+// Storage.UpdateSlice does.
+func (b *Batch) UpdateSlice(slice *models.Slice) {
+	copySlice := *slice
+	copySlice.Files = append([]string(nil), slice.Files...)
+	copySlice.Owners = append([]string(nil), slice.Owners...)
+	copySlice.Include = append([]string(nil), slice.Include...)
+	copySlice.Exclude = append([]string(nil), slice.Exclude...)
+	copySlice.RequiredChecks = append([]string(nil), slice.RequiredChecks...)
+	b.ops = append(b.ops, batchOp{kind: opUpdateSlice, sliceID: slice.ID, slice: &copySlice})
+}
+
+// ArchiveSlice marks a slice archived and drops every file claim naming it.
+// Its record, metadata and history stay.
+func (b *Batch) ArchiveSlice(sliceID string) {
+	b.ops = append(b.ops, batchOp{kind: opArchiveSlice, sliceID: sliceID})
+}
+
+// DeleteSlice removes a slice, its metadata and history, and every file claim
+// naming it.
+func (b *Batch) DeleteSlice(sliceID string) {
+	b.ops = append(b.ops, batchOp{kind: opDeleteSlice, sliceID: sliceID})
+}
+
+// UpdateChangeset replaces a stored changeset.
+func (b *Batch) UpdateChangeset(changeset *models.Changeset) {
+	copyCS := *changeset
+	b.ops = append(b.ops, batchOp{kind: opUpdateChangeset, changeset: &copyCS})
+}
+
+// UpdateSliceMetadata replaces a slice's metadata.
+func (b *Batch) UpdateSliceMetadata(sliceID string, metadata *models.SliceMetadata) {
+	copyMeta := *metadata
+	b.ops = append(b.ops, batchOp{kind: opUpdateSliceMetadata, sliceID: sliceID, metadata: &copyMeta})
+}
+
+// AddSliceCommit adds a commit to the front of a slice's history.
+func (b *Batch) AddSliceCommit(sliceID string, commit *models.Commit) {
+	copyCommit := *commit
+	b.ops = append(b.ops, batchOp{kind: opAddSliceCommit, sliceID: sliceID, commit: &copyCommit})
+}
+
+// AppendGlobalCommit makes commit the new global head, keeping history newest first.
+func (b *Batch) AppendGlobalCommit(commit *models.GlobalCommit) {
+	copyCommit := *commit
+	b.ops = append(b.ops, batchOp{kind: opAppendGlobalCommit, globalCommit: &copyCommit})
+}
+
+// Empty reports whether the batch has nothing to write.
+func (b *Batch) Empty() bool {
+	return len(b.ops) == 0
+}
+
+// slicesReferenced lists the slices the batch expects or writes to, which must
+// all exist for it to commit. Slices the batch creates are left out.
+func (b *Batch) slicesReferenced() []string {
+	seen := make(map[string]bool)
+	for _, op := range b.ops {
+		if op.kind == opCreateSlice {
+			seen[op.sliceID] = true
+		}
+	}
+	var ids []string
+	add := func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for id := range b.expectedHeads {
+		add(id)
+	}
+	for _, op := range b.ops {
+		switch op.kind {
+		case opAddFileToSlice, opRemoveFileFromSlice, opUpdateSlice, opArchiveSlice, opDeleteSlice, opUpdateSliceMetadata, opAddSliceCommit:
+			add(op.sliceID)
+		case opSetSliceParent:
+			add(op.sliceID)
+			if op.parentID != "" {
+				add(op.parentID)
+			}
+		}
+	}
+	return ids
+}
+
+// applyGlobalCommit returns state with commit added as its new head.
+func applyGlobalCommit(state *models.GlobalState, commit *models.GlobalCommit) *models.GlobalState {
+	next := &models.GlobalState{
+		GlobalCommitHash: commit.CommitHash,
+		Timestamp:        commit.Timestamp,
+		History:          []*models.GlobalCommit{commit},
+	}
+	if state != nil {
+		for _, entry := range state.History {
+			if entry != nil {
+				copyEntry := *entry
+				next.History = append(next.History, &copyEntry)
+			}
+		}
+	}
+	return next
+}
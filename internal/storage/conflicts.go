@@ -0,0 +1,282 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/niczy/gitslice/internal/models"
+	"github.com/niczy/gitslice/internal/objects"
+)
+
+// ConflictDetector decides whether slices that claim the same file really
+// changed it in different ways. Every merge is promoted to a global commit, so
+// the latest global commit's tree holds the version of a file everyone should
+// build on: the base. Each side is compared against it:
+//
+//   - a slice head that holds the base published the latest change;
+//   - an open changeset changes the file when its version differs from the
+//     base, and is stale when its slice's head, which it was built on, does
+//     not hold the base either.
+//
+// Two slices conflict over a file when their changesets change it to
+// different versions, or when a stale changeset would overwrite the base
+// another slice published. Identical changes never conflict.
+//
+// A detector caches the commit snapshots and global state it reads, so it
+// should live no longer than a single request.
+type ConflictDetector struct {
+	st        Storage
+	snapshots map[string]map[string]string
+	heads     map[string]map[string]string
+	base      map[string]string
+	baseRead  bool
+}
+
+// NewConflictDetector returns a detector reading commits from st.
+func NewConflictDetector(st Storage) *ConflictDetector {
+	return &ConflictDetector{
+		st:        st,
+		snapshots: make(map[string]map[string]string),
+		heads:     make(map[string]map[string]string),
+	}
+}
+
+// side is one slice's or one changeset's version of a file.
+type side struct {
+	sliceID string
+	version string
+	// head sides come from a slice's head commit, the others from open
+	// changesets
+	head bool
+	// stale changesets were built on a version other than the base
+	stale bool
+	// unknown sides come from changesets without content and clash with
+	// every other slice
+	unknown bool
+}
+
+// clashes reports whether two sides from different slices conflict given the
+// base version of the file.
+func (a side) clashes(b side, base string) bool {
+	if a.sliceID == b.sliceID {
+		return false
+	}
+	if a.unknown || b.unknown {
+		return true
+	}
+	if a.head && b.head {
+		return false
+	}
+	if !a.head && !b.head {
+		return a.version != base && b.version != base && a.version != b.version
+	}
+	cs, head := a, b
+	if a.head {
+		cs, head = b, a
+	}
+	return cs.stale && head.version == base
+}
+
+// Base returns the version of path in the latest global commit with a
+// snapshot. An empty string stands for the file being absent, as it is before
+// the first global commit.
+func (d *ConflictDetector) Base(ctx context.Context, path string) (string, error) {
+	if !d.baseRead {
+		state, err := d.st.GetGlobalState(ctx)
+		if err != nil && !errors.Is(err, ErrInvalidInput) {
+			return "", err
+		}
+		if state != nil {
+			hashes := []string{state.GlobalCommitHash}
+			for _, entry := range state.History {
+				if entry != nil {
+					hashes = append(hashes, entry.CommitHash)
+				}
+			}
+			for _, hash := range hashes {
+				files, err := d.commitSnapshot(ctx, hash)
+				if err != nil {
+					return "", err
+				}
+				if files != nil {
+					d.base = files
+					break
+				}
+			}
+		}
+		d.baseRead = true
+	}
+	return d.base[path], nil
+}
+
+// SliceVersion returns the version of path at a slice's head. It reports
+// false when the head has no snapshot or does not hold the file, as for
+// slices that never changed it.
+func (d *ConflictDetector) SliceVersion(ctx context.Context, sliceID, path string) (string, bool, error) {
+	files, ok := d.heads[sliceID]
+	if !ok {
+		metadata, err := d.st.GetSliceMetadata(ctx, sliceID)
+		if err != nil && !errors.Is(err, ErrSliceNotFound) {
+			return "", false, err
+		}
+		if metadata != nil {
+			if files, err = d.commitSnapshot(ctx, metadata.HeadCommitHash); err != nil {
+				return "", false, err
+			}
+		}
+		d.heads[sliceID] = files
+	}
+	version, ok := files[path]
+	return version, ok, nil
+}
+
+// changesetSide returns a changeset's version of path, comparing the version
+// its slice's head holds with the base to tell whether it is stale.
+func (d *ConflictDetector) changesetSide(ctx context.Context, cs *models.Changeset, path, base string) (side, error) {
+	if cs.TreeHash == "" {
+		return side{sliceID: cs.SliceID, unknown: true}, nil
+	}
+	files, ok := d.snapshots[cs.TreeHash]
+	if !ok {
+		var err error
+		if files, err = ReadSnapshot(ctx, d.st, cs.TreeHash); err != nil {
+			return side{}, err
+		}
+		d.snapshots[cs.TreeHash] = files
+	}
+	// A slice that never held the file built on it being absent
+	builtOn, _, err := d.SliceVersion(ctx, cs.SliceID, path)
+	if err != nil {
+		return side{}, err
+	}
+	// A modified path missing from the changeset tree was deleted
+	version := files[path]
+	return side{sliceID: cs.SliceID, version: version, stale: version != base && builtOn != base}, nil
+}
+
+// ChangesetConflicts returns the slices among others that conflict with a
+// changeset's change to path. A changeset that leaves the file at the base
+// conflicts with none; one uploaded without content cannot be compared and
+// conflicts with all of them.
+func (d *ConflictDetector) ChangesetConflicts(ctx context.Context, cs *models.Changeset, path string, others []string) ([]string, error) {
+	base, err := d.Base(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	ours, err := d.changesetSide(ctx, cs, path, base)
+	if err != nil {
+		return nil, err
+	}
+	if ours.unknown {
+		return others, nil
+	}
+
+	var conflicting []string
+	for _, sliceID := range others {
+		version, ok, err := d.SliceVersion(ctx, sliceID, path)
+		if err != nil {
+			return nil, err
+		}
+		if ok && ours.clashes(side{sliceID: sliceID, version: version, head: true}, base) {
+			conflicting = append(conflicting, sliceID)
+		}
+	}
+	return conflicting, nil
+}
+
+// Filter narrows index-level conflicts, such as those from ListConflicts, to
+// the files whose claiming slices changed them in different ways. Each slice
+// contributes its head's version and those of its open changesets that modify
+// the file. Conflicts that survive list only the slices involved.
+func (d *ConflictDetector) Filter(ctx context.Context, conflicts []*models.FileConflict) ([]*models.FileConflict, error) {
+	var result []*models.FileConflict
+	for _, conflict := range conflicts {
+		base, err := d.Base(ctx, conflict.FileID)
+		if err != nil {
+			return nil, err
+		}
+
+		var sides []side
+		for _, sliceID := range conflict.ConflictingSlices {
+			version, ok, err := d.SliceVersion(ctx, sliceID, conflict.FileID)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				sides = append(sides, side{sliceID: sliceID, version: version, head: true})
+			}
+
+			changesets, err := d.st.ListChangesets(ctx, sliceID, nil, 0)
+			if err != nil && !errors.Is(err, ErrSliceNotFound) {
+				return nil, err
+			}
+			for _, cs := range changesets {
+				if !changesetOpen(cs) || !containsPath(cs.ModifiedFiles, conflict.FileID) {
+					continue
+				}
+				ours, err := d.changesetSide(ctx, cs, conflict.FileID, base)
+				if err != nil {
+					return nil, err
+				}
+				sides = append(sides, ours)
+			}
+		}
+
+		involved := make(map[string]bool)
+		for i := range sides {
+			for j := i + 1; j < len(sides); j++ {
+				if sides[i].clashes(sides[j], base) {
+					involved[sides[i].sliceID] = true
+					involved[sides[j].sliceID] = true
+				}
+			}
+		}
+		if len(involved) < 2 {
+			continue
+		}
+
+		narrowed := &models.FileConflict{FileID: conflict.FileID}
+		for sliceID := range involved {
+			narrowed.ConflictingSlices = append(narrowed.ConflictingSlices, sliceID)
+		}
+		sort.Strings(narrowed.ConflictingSlices)
+		result = append(result, narrowed)
+	}
+	return result, nil
+}
+
+// commitSnapshot reads the files of a commit object, or returns nil when the
+// hash does not name one. Snapshots are cached by commit hash.
+func (d *ConflictDetector) commitSnapshot(ctx context.Context, hash string) (map[string]string, error) {
+	if files, ok := d.snapshots[hash]; ok {
+		return files, nil
+	}
+	var files map[string]string
+	if objects.IsHash(hash) {
+		commit, err := ReadCommit(ctx, d.st, hash)
+		switch {
+		case err == nil:
+			if files, err = ReadSnapshot(ctx, d.st, commit.TreeHash); err != nil {
+				return nil, err
+			}
+		case !errors.Is(err, ErrObjectNotFound):
+			return nil, err
+		}
+	}
+	d.snapshots[hash] = files
+	return files, nil
+}
+
+func changesetOpen(cs *models.Changeset) bool {
+	return cs.Status != models.ChangesetStatusMerged && cs.Status != models.ChangesetStatusAbandoned
+}
+
+func containsPath(paths []string, want string) bool {
+	for _, p := range paths {
+		if p == want {
+			return true
+		}
+	}
+	return false
+}
@@ -27,6 +27,9 @@ type InMemoryStorage struct {
 	// File content storage
 	fileContents map[string]*models.FileContent // fileID -> content
 
+	// Content-addressable blobs, trees and commits
+	objects ObjectStore
+
 	// Directory entries
 	entries        map[string]*models.DirectoryEntry // entryID -> entry
 	entriesByPath  map[string]string                 // sliceID:path -> entryID
@@ -36,6 +39,13 @@ type InMemoryStorage struct {
 	changesets      map[string]*models.Changeset // changesetID -> changeset
 	sliceChangesets map[string][]string          // sliceID -> []changesetID
 
+	// Review comments
+	comments          map[string]*models.ReviewComment // commentID -> comment
+	changesetComments map[string][]string              // changesetID -> []commentID
+
+	// Merge queues
+	mergeQueues map[string][]*models.MergeQueueEntry // sliceID -> entries, oldest first
+
 	// Commit history
 	sliceCommits map[string][]*models.Commit // sliceID -> commits (newest first)
 
@@ -46,18 +56,22 @@ type InMemoryStorage struct {
 // NewInMemoryStorage creates a new in-memory storage instance
 func NewInMemoryStorage() *InMemoryStorage {
 	return &InMemoryStorage{
-		slices:          make(map[string]*models.Slice),
-		sliceMetadata:   make(map[string]*models.SliceMetadata),
-		fileIndex:       make(map[string]map[string]bool),
-		fileContents:    make(map[string]*models.FileContent),
-		entries:         make(map[string]*models.DirectoryEntry),
-		entriesByPath:   make(map[string]string),
-		entriesBySlice:  make(map[string][]string),
-		changesets:      make(map[string]*models.Changeset),
-		sliceChangesets: make(map[string][]string),
-		sliceCommits:    make(map[string][]*models.Commit),
-		lockedSlices:    make(map[string]bool),
-		fileLocks:       make(map[string]string),
+		slices:            make(map[string]*models.Slice),
+		sliceMetadata:     make(map[string]*models.SliceMetadata),
+		fileIndex:         make(map[string]map[string]bool),
+		fileContents:      make(map[string]*models.FileContent),
+		objects:           NewInMemoryObjectStore(),
+		entries:           make(map[string]*models.DirectoryEntry),
+		entriesByPath:     make(map[string]string),
+		entriesBySlice:    make(map[string][]string),
+		changesets:        make(map[string]*models.Changeset),
+		sliceChangesets:   make(map[string][]string),
+		comments:          make(map[string]*models.ReviewComment),
+		changesetComments: make(map[string][]string),
+		mergeQueues:       make(map[string][]*models.MergeQueueEntry),
+		sliceCommits:      make(map[string][]*models.Commit),
+		lockedSlices:      make(map[string]bool),
+		fileLocks:         make(map[string]string),
 		globalState: &models.GlobalState{
 			GlobalCommitHash: "global-init",
 			Timestamp:        time.Now(),
@@ -114,7 +128,13 @@ func (s *InMemoryStorage) CreateSlice(ctx context.Context, slice *models.Slice)
 	if _, exists := s.slices[slice.ID]; exists {
 		return ErrSliceAlreadyExists
 	}
+	s.createSlice(slice)
+	return nil
+}
 
+// createSlice stores a new slice with empty metadata and indexes its files.
+// Callers must hold the write lock.
+func (s *InMemoryStorage) createSlice(slice *models.Slice) {
 	now := time.Now()
 	slice.CreatedAt = now
 	slice.UpdatedAt = now
@@ -142,8 +162,6 @@ func (s *InMemoryStorage) CreateSlice(ctx context.Context, slice *models.Slice)
 		}
 		s.fileIndex[fileID][slice.ID] = true
 	}
-
-	return nil
 }
 
 // GetSlice retrieves a slice by ID
@@ -161,6 +179,81 @@ func (s *InMemoryStorage) GetSlice(ctx context.Context, sliceID string) (*models
 	return &copy, nil
 }
 
+// UpdateSlice replaces a slice's definition and re-indexes its files
+func (s *InMemoryStorage) UpdateSlice(ctx context.Context, slice *models.Slice) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.slices[slice.ID]; !exists {
+		return ErrSliceNotFound
+	}
+	s.updateSlice(slice)
+	return nil
+}
+
+// updateSlice replaces an existing slice's definition, keeping its creation
+// time, and re-indexes its files. The caller must hold the write lock.
+func (s *InMemoryStorage) updateSlice(slice *models.Slice) {
+	existing := s.slices[slice.ID]
+	updated := *slice
+	updated.Files = append([]string(nil), slice.Files...)
+	updated.CreatedAt = existing.CreatedAt
+	updated.UpdatedAt = time.Now()
+
+	listed := make(map[string]bool, len(updated.Files))
+	for _, fileID := range updated.Files {
+		listed[fileID] = true
+	}
+	for _, fileID := range existing.Files {
+		if !listed[fileID] {
+			s.removeFileFromSlice(fileID, slice.ID)
+		}
+	}
+	s.slices[slice.ID] = &updated
+	for _, fileID := range updated.Files {
+		s.addFileToSlice(fileID, slice.ID)
+	}
+}
+
+// DeleteSlice removes a slice, its metadata and history, and its file claims
+func (s *InMemoryStorage) DeleteSlice(ctx context.Context, sliceID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.slices[sliceID]; !exists {
+		return ErrSliceNotFound
+	}
+	s.deleteSlice(sliceID)
+	return nil
+}
+
+// deleteSlice drops every file claim naming a slice and then the slice itself.
+// The caller must hold the write lock.
+func (s *InMemoryStorage) deleteSlice(sliceID string) {
+	s.releaseFiles(sliceID)
+	delete(s.slices, sliceID)
+	delete(s.sliceMetadata, sliceID)
+	delete(s.sliceCommits, sliceID)
+}
+
+// archiveSlice drops every file claim naming a slice and marks it archived.
+// The caller must hold the write lock.
+func (s *InMemoryStorage) archiveSlice(sliceID string) {
+	s.releaseFiles(sliceID)
+	slice := s.slices[sliceID]
+	slice.Files = nil
+	slice.Archived = true
+	slice.UpdatedAt = time.Now()
+}
+
+// releaseFiles removes a slice from every file index entry. The caller must
+// hold the write lock.
+func (s *InMemoryStorage) releaseFiles(sliceID string) {
+	for fileID := range s.fileIndex {
+		s.removeFileFromSlice(fileID, sliceID)
+	}
+}
+
 // ListSlices retrieves all slices with pagination
 func (s *InMemoryStorage) ListSlices(ctx context.Context, limit, offset int) ([]*models.Slice, error) {
 	s.mu.RLock()
@@ -268,6 +361,70 @@ func (s *InMemoryStorage) UpdateSliceMetadata(ctx context.Context, sliceID strin
 	return nil
 }
 
+// CommitBatch validates the whole batch and then applies it while holding the
+// write lock, so readers never observe a partially applied batch.
+func (s *InMemoryStorage) CommitBatch(ctx context.Context, batch *Batch) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sliceID := range batch.slicesReferenced() {
+		if _, exists := s.slices[sliceID]; !exists {
+			return ErrSliceNotFound
+		}
+	}
+	for sliceID, head := range batch.expectedHeads {
+		if metadata, exists := s.sliceMetadata[sliceID]; !exists || metadata.HeadCommitHash != head {
+			return ErrHeadMoved
+		}
+	}
+	for _, op := range batch.ops {
+		switch op.kind {
+		case opCreateSlice:
+			if _, exists := s.slices[op.sliceID]; exists {
+				return ErrSliceAlreadyExists
+			}
+		case opUpdateChangeset:
+			if _, exists := s.changesets[op.changeset.ID]; !exists {
+				return ErrChangesetNotFound
+			}
+		}
+	}
+
+	for _, op := range batch.ops {
+		switch op.kind {
+		case opCreateSlice:
+			s.createSlice(op.slice)
+		case opResolveConflict:
+			s.resolveConflict(op.fileID, op.sliceID)
+		case opAddFileToSlice:
+			s.addFileToSlice(op.fileID, op.sliceID)
+		case opRemoveFileFromSlice:
+			s.removeFileFromSlice(op.fileID, op.sliceID)
+		case opSetSliceParent:
+			s.slices[op.sliceID].ParentSlice = op.parentID
+			s.slices[op.sliceID].UpdatedAt = time.Now()
+		case opUpdateSlice:
+			s.updateSlice(op.slice)
+		case opArchiveSlice:
+			s.archiveSlice(op.sliceID)
+		case opDeleteSlice:
+			s.deleteSlice(op.sliceID)
+		case opUpdateChangeset:
+			s.changesets[op.changeset.ID] = op.changeset
+		case opUpdateSliceMetadata:
+			if op.metadata.LastModified.IsZero() {
+				op.metadata.LastModified = time.Now()
+			}
+			s.sliceMetadata[op.sliceID] = op.metadata
+		case opAddSliceCommit:
+			s.sliceCommits[op.sliceID] = append([]*models.Commit{op.commit}, s.sliceCommits[op.sliceID]...)
+		case opAppendGlobalCommit:
+			s.globalState = applyGlobalCommit(s.globalState, op.globalCommit)
+		}
+	}
+	return nil
+}
+
 // AddSliceCommit records a commit for a slice, keeping most recent commits first.
 func (s *InMemoryStorage) AddSliceCommit(ctx context.Context, sliceID string, commit *models.Commit) error {
 	s.mu.Lock()
@@ -329,11 +486,20 @@ func (s *InMemoryStorage) AddFileToSlice(ctx context.Context, fileID, sliceID st
 		return ErrSliceNotFound
 	}
 
+	s.addFileToSlice(fileID, sliceID)
+	return nil
+}
+
+// addFileToSlice indexes a file under a slice and lists it in the slice's
+// files. The caller must hold the write lock.
+func (s *InMemoryStorage) addFileToSlice(fileID, sliceID string) {
 	if s.fileIndex[fileID] == nil {
 		s.fileIndex[fileID] = make(map[string]bool)
 	}
 	s.fileIndex[fileID][sliceID] = true
-	return nil
+	if slice, exists := s.slices[sliceID]; exists {
+		slice.Files = appendUnique(slice.Files, fileID)
+	}
 }
 
 // GetActiveSlicesForFile retrieves all active slices for a file
@@ -341,12 +507,23 @@ func (s *InMemoryStorage) GetActiveSlicesForFile(ctx context.Context, fileID str
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	return s.activeSlices(fileID), nil
+}
+
+// activeSlices returns the slices claiming a file through the file index or
+// their patterns. The caller must hold the lock.
+func (s *InMemoryStorage) activeSlices(fileID string) []string {
 	sliceIDs := make([]string, 0)
 	for sliceID := range s.fileIndex[fileID] {
 		sliceIDs = append(sliceIDs, sliceID)
 	}
-
-	return sliceIDs, nil
+	// Slices defined by patterns claim every file they match
+	for sliceID, slice := range s.slices {
+		if slice.PathSpec().HasPatterns() && !s.fileIndex[fileID][sliceID] && slice.PathSpec().Match(fileID) {
+			sliceIDs = append(sliceIDs, sliceID)
+		}
+	}
+	return sliceIDs
 }
 
 // RemoveFileFromSlice removes a file from the index for a slice
@@ -354,30 +531,36 @@ func (s *InMemoryStorage) RemoveFileFromSlice(ctx context.Context, fileID, slice
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	s.removeFileFromSlice(fileID, sliceID)
+	return nil
+}
+
+// removeFileFromSlice drops a slice from a file's index entry and from the
+// slice's files. The caller must hold the write lock.
+func (s *InMemoryStorage) removeFileFromSlice(fileID, sliceID string) {
 	if slices, exists := s.fileIndex[fileID]; exists {
 		delete(slices, sliceID)
 		if len(slices) == 0 {
 			delete(s.fileIndex, fileID)
 		}
 	}
-	return nil
+	if slice, exists := s.slices[sliceID]; exists {
+		slice.Files = RemoveString(slice.Files, fileID)
+	}
 }
 
-// ListConflicts returns files that are associated with more than one slice.
+// ListConflicts returns indexed files that are claimed by more than one
+// slice, counting slices whose patterns match the file.
 func (s *InMemoryStorage) ListConflicts(ctx context.Context) ([]*models.FileConflict, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	var conflicts []*models.FileConflict
-	for fileID, slices := range s.fileIndex {
-		if len(slices) < 2 {
+	for fileID := range s.fileIndex {
+		sliceIDs := s.activeSlices(fileID)
+		if len(sliceIDs) < 2 {
 			continue
 		}
-
-		var sliceIDs []string
-		for id := range slices {
-			sliceIDs = append(sliceIDs, id)
-		}
 		sort.Strings(sliceIDs)
 
 		conflicts = append(conflicts, &models.FileConflict{
@@ -394,9 +577,15 @@ func (s *InMemoryStorage) ResolveConflict(ctx context.Context, fileID, preferred
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	return s.resolveConflict(fileID, preferredSliceID), nil
+}
+
+// resolveConflict narrows a file's index entry to a single slice. The caller
+// must hold the write lock.
+func (s *InMemoryStorage) resolveConflict(fileID, preferredSliceID string) *models.FileConflict {
 	slices, exists := s.fileIndex[fileID]
 	if !exists {
-		return &models.FileConflict{FileID: fileID, ConflictingSlices: []string{}}, nil
+		return &models.FileConflict{FileID: fileID, ConflictingSlices: []string{}}
 	}
 
 	updated := make(map[string]bool)
@@ -415,6 +604,13 @@ func (s *InMemoryStorage) ResolveConflict(ctx context.Context, fileID, preferred
 	}
 
 	s.fileIndex[fileID] = updated
+	for sliceID := range slices {
+		if !updated[sliceID] {
+			if slice, ok := s.slices[sliceID]; ok {
+				slice.Files = RemoveString(slice.Files, fileID)
+			}
+		}
+	}
 
 	var remaining []string
 	for id := range updated {
@@ -422,7 +618,7 @@ func (s *InMemoryStorage) ResolveConflict(ctx context.Context, fileID, preferred
 	}
 	sort.Strings(remaining)
 
-	return &models.FileConflict{FileID: fileID, ConflictingSlices: remaining}, nil
+	return &models.FileConflict{FileID: fileID, ConflictingSlices: remaining}
 }
 
 // CreateChangeset stores a new changeset
@@ -497,6 +693,114 @@ func (s *InMemoryStorage) UpdateChangeset(ctx context.Context, changeset *models
 	return nil
 }
 
+// AddComment stores a new review comment on an existing changeset
+func (s *InMemoryStorage) AddComment(ctx context.Context, comment *models.ReviewComment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if comment.ID == "" {
+		return ErrInvalidInput
+	}
+	if _, exists := s.changesets[comment.ChangesetID]; !exists {
+		return ErrChangesetNotFound
+	}
+
+	copyComment := *comment
+	s.comments[comment.ID] = &copyComment
+	s.changesetComments[comment.ChangesetID] = append(s.changesetComments[comment.ChangesetID], comment.ID)
+	return nil
+}
+
+// GetComment retrieves a review comment by ID
+func (s *InMemoryStorage) GetComment(ctx context.Context, commentID string) (*models.ReviewComment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	comment, ok := s.comments[commentID]
+	if !ok {
+		return nil, ErrCommentNotFound
+	}
+
+	copy := *comment
+	return &copy, nil
+}
+
+// ListComments returns a changeset's review comments in the order they were added
+func (s *InMemoryStorage) ListComments(ctx context.Context, changesetID string) ([]*models.ReviewComment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := []*models.ReviewComment{}
+	for _, id := range s.changesetComments[changesetID] {
+		if comment, ok := s.comments[id]; ok {
+			copy := *comment
+			result = append(result, &copy)
+		}
+	}
+	return result, nil
+}
+
+// UpdateComment replaces an existing review comment
+func (s *InMemoryStorage) UpdateComment(ctx context.Context, comment *models.ReviewComment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.comments[comment.ID]; !exists {
+		return ErrCommentNotFound
+	}
+
+	copyComment := *comment
+	s.comments[comment.ID] = &copyComment
+	return nil
+}
+
+// EnqueueMerge appends a changeset to its slice's merge queue
+func (s *InMemoryStorage) EnqueueMerge(ctx context.Context, entry *models.MergeQueueEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.slices[entry.SliceID]; !exists {
+		return ErrSliceNotFound
+	}
+	for _, queued := range s.mergeQueues[entry.SliceID] {
+		if queued.ChangesetID == entry.ChangesetID {
+			return ErrEntryExists
+		}
+	}
+
+	copyEntry := *entry
+	s.mergeQueues[entry.SliceID] = append(s.mergeQueues[entry.SliceID], &copyEntry)
+	return nil
+}
+
+// ListMergeQueue returns a slice's queued merges in the order they were enqueued
+func (s *InMemoryStorage) ListMergeQueue(ctx context.Context, sliceID string) ([]*models.MergeQueueEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := []*models.MergeQueueEntry{}
+	for _, entry := range s.mergeQueues[sliceID] {
+		copy := *entry
+		result = append(result, &copy)
+	}
+	return result, nil
+}
+
+// RemoveFromMergeQueue drops a changeset from its slice's merge queue
+func (s *InMemoryStorage) RemoveFromMergeQueue(ctx context.Context, sliceID, changesetID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queue := s.mergeQueues[sliceID]
+	for i, entry := range queue {
+		if entry.ChangesetID == changesetID {
+			s.mergeQueues[sliceID] = append(queue[:i:i], queue[i+1:]...)
+			return nil
+		}
+	}
+	return ErrEntryNotFound
+}
+
 // Ping checks if storage is accessible
 func (s *InMemoryStorage) Ping(ctx context.Context) error {
 	return nil
@@ -546,6 +850,26 @@ func (s *InMemoryStorage) AddFileContent(ctx context.Context, content *models.Fi
 	return nil
 }
 
+// PutObject validates and stores a content-addressable object
+func (s *InMemoryStorage) PutObject(ctx context.Context, object *models.Object) error {
+	return putObject(ctx, s.objects, func(hash string) string { return hash }, object)
+}
+
+// GetObject retrieves a content-addressable object by hash
+func (s *InMemoryStorage) GetObject(ctx context.Context, hash string) (*models.Object, error) {
+	return getObject(ctx, s.objects, hash, hash)
+}
+
+// HasObject reports whether an object with the given hash is stored
+func (s *InMemoryStorage) HasObject(ctx context.Context, hash string) (bool, error) {
+	return hasObject(ctx, s.objects, hash)
+}
+
+// StatObject returns the type and data size of a stored object
+func (s *InMemoryStorage) StatObject(ctx context.Context, hash string) (models.ObjectType, int64, error) {
+	return statObject(ctx, s.objects, hash)
+}
+
 // GetRootSlice returns the root slice
 func (s *InMemoryStorage) GetRootSlice(ctx context.Context) (*models.Slice, error) {
 	s.mu.RLock()
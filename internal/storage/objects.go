@@ -0,0 +1,243 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/niczy/gitslice/internal/models"
+	"github.com/niczy/gitslice/internal/objects"
+)
+
+// putObject validates an object and persists its envelope under the key derived
+// from its hash. Objects are immutable, so rewriting an existing hash is harmless.
+func putObject(ctx context.Context, store ObjectStore, key func(hash string) string, object *models.Object) error {
+	if object == nil {
+		return ErrInvalidInput
+	}
+	if err := objects.Validate(object); err != nil {
+		return err
+	}
+
+	raw, err := objects.Envelope(object.Type, object.Data)
+	if err != nil {
+		return err
+	}
+	return store.PutObject(ctx, key(object.Hash), raw)
+}
+
+// getObject loads an object envelope and verifies it still matches its address.
+func getObject(ctx context.Context, store ObjectStore, key, hash string) (*models.Object, error) {
+	raw, err := store.GetObject(ctx, key)
+	if err != nil {
+		if errors.Is(err, ErrEntryNotFound) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, err
+	}
+
+	object, err := objects.ParseEnvelope(raw)
+	if err != nil {
+		return nil, err
+	}
+	if object.Hash != hash {
+		return nil, fmt.Errorf("%w: stored object %s is corrupt", objects.ErrHashMismatch, hash)
+	}
+	return object, nil
+}
+
+// statObject reads an object's type and data size from its envelope header.
+func statObject(ctx context.Context, store ObjectStore, key string) (models.ObjectType, int64, error) {
+	raw, err := store.GetObjectPrefix(ctx, key, objects.MaxHeaderSize)
+	if err != nil {
+		if errors.Is(err, ErrEntryNotFound) {
+			return 0, 0, ErrObjectNotFound
+		}
+		return 0, 0, err
+	}
+	return objects.ParseHeader(raw)
+}
+
+func hasObject(ctx context.Context, store ObjectStore, key string) (bool, error) {
+	if _, err := store.GetObjectPrefix(ctx, key, objects.MaxHeaderSize); err != nil {
+		if errors.Is(err, ErrEntryNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// WriteBlob stores file content and returns its hash.
+func WriteBlob(ctx context.Context, st Storage, data []byte) (string, error) {
+	object := &models.Object{Type: models.ObjectTypeBlob, Data: data}
+	if err := st.PutObject(ctx, object); err != nil {
+		return "", err
+	}
+	return object.Hash, nil
+}
+
+// ReadBlob loads file content by hash.
+func ReadBlob(ctx context.Context, st Storage, hash string) ([]byte, error) {
+	object, err := readTyped(ctx, st, hash, models.ObjectTypeBlob)
+	if err != nil {
+		return nil, err
+	}
+	return object.Data, nil
+}
+
+// BlobSize returns the length of a blob's content without loading it.
+func BlobSize(ctx context.Context, st Storage, hash string) (int64, error) {
+	objType, size, err := st.StatObject(ctx, hash)
+	if err != nil {
+		return 0, err
+	}
+	if objType != models.ObjectTypeBlob {
+		return 0, fmt.Errorf("%w: %s is not a blob", objects.ErrInvalidObject, hash)
+	}
+	return size, nil
+}
+
+// WriteTree stores a tree and returns its hash.
+func WriteTree(ctx context.Context, st Storage, tree *models.Tree) (string, error) {
+	data, err := objects.EncodeTree(tree)
+	if err != nil {
+		return "", err
+	}
+	object := &models.Object{Type: models.ObjectTypeTree, Data: data}
+	if err := st.PutObject(ctx, object); err != nil {
+		return "", err
+	}
+	return object.Hash, nil
+}
+
+// ReadTree loads a tree by hash.
+func ReadTree(ctx context.Context, st Storage, hash string) (*models.Tree, error) {
+	object, err := readTyped(ctx, st, hash, models.ObjectTypeTree)
+	if err != nil {
+		return nil, err
+	}
+	return objects.DecodeTree(object.Data)
+}
+
+// WriteCommit stores a commit object and sets commit.CommitHash to its address.
+func WriteCommit(ctx context.Context, st Storage, commit *models.Commit) (string, error) {
+	data, err := objects.EncodeCommit(commit)
+	if err != nil {
+		return "", err
+	}
+	object := &models.Object{Type: models.ObjectTypeCommit, Data: data}
+	if err := st.PutObject(ctx, object); err != nil {
+		return "", err
+	}
+	commit.CommitHash = object.Hash
+	return object.Hash, nil
+}
+
+// ReadCommit loads a commit object by hash.
+func ReadCommit(ctx context.Context, st Storage, hash string) (*models.Commit, error) {
+	object, err := readTyped(ctx, st, hash, models.ObjectTypeCommit)
+	if err != nil {
+		return nil, err
+	}
+	return objects.DecodeCommit(hash, object.Data)
+}
+
+func readTyped(ctx context.Context, st Storage, hash string, want models.ObjectType) (*models.Object, error) {
+	object, err := st.GetObject(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if object.Type != want {
+		return nil, fmt.Errorf("%w: %s is not a %s", objects.ErrInvalidObject, hash, typeLabel(want))
+	}
+	return object, nil
+}
+
+func typeLabel(t models.ObjectType) string {
+	name, err := objects.TypeName(t)
+	if err != nil {
+		return "object"
+	}
+	return name
+}
+
+// WriteSnapshot builds the nested trees for a flat path -> blob hash map and
+// returns the root tree hash.
+func WriteSnapshot(ctx context.Context, st Storage, files map[string]string) (string, error) {
+	clean := make(map[string]string, len(files))
+	for filePath, blobHash := range files {
+		p, err := CleanPath(filePath)
+		if err != nil {
+			return "", err
+		}
+		clean[p] = blobHash
+	}
+
+	rootHash, trees, err := objects.BuildSnapshot(clean)
+	if err != nil {
+		return "", err
+	}
+	for _, tree := range trees {
+		if err := st.PutObject(ctx, tree); err != nil {
+			return "", err
+		}
+	}
+	return rootHash, nil
+}
+
+// ReadSnapshot walks a tree recursively and returns its files as a flat
+// path -> blob hash map. An empty tree hash yields an empty snapshot.
+func ReadSnapshot(ctx context.Context, st Storage, treeHash string) (map[string]string, error) {
+	files := make(map[string]string)
+	if treeHash == "" {
+		return files, nil
+	}
+
+	var walk func(prefix, hash string) error
+	walk = func(prefix, hash string) error {
+		tree, err := ReadTree(ctx, st, hash)
+		if err != nil {
+			return err
+		}
+		for _, entry := range tree.Entries {
+			entryPath := path.Join(prefix, entry.Name)
+			if entry.Mode == models.TreeModeDir {
+				if err := walk(entryPath, entry.Hash); err != nil {
+					return err
+				}
+				continue
+			}
+			files[entryPath] = entry.Hash
+		}
+		return nil
+	}
+
+	if err := walk("", treeHash); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// SnapshotPaths returns the paths of a snapshot in sorted order.
+func SnapshotPaths(files map[string]string) []string {
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// CleanPath normalizes a slash-separated repository path and rejects paths
+// that escape the slice root.
+func CleanPath(p string) (string, error) {
+	clean := path.Clean(strings.TrimPrefix(strings.ReplaceAll(p, "\\", "/"), "/"))
+	if clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("%w: invalid path %q", ErrInvalidInput, p)
+	}
+	return clean, nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"sync"
 
@@ -17,6 +18,8 @@ import (
 type ObjectStore interface {
 	PutObject(ctx context.Context, key string, body []byte) error
 	GetObject(ctx context.Context, key string) ([]byte, error)
+	// GetObjectPrefix reads at most the first n bytes of a payload.
+	GetObjectPrefix(ctx context.Context, key string, n int) ([]byte, error)
 	DeleteObject(ctx context.Context, key string) error
 }
 
@@ -60,6 +63,25 @@ func (s *InMemoryObjectStore) GetObject(ctx context.Context, key string) ([]byte
 	return copyData, nil
 }
 
+// GetObjectPrefix retrieves the start of the stored payload.
+func (s *InMemoryObjectStore) GetObjectPrefix(ctx context.Context, key string, n int) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_ = ctx
+	data, ok := s.store[key]
+	if !ok {
+		return nil, ErrEntryNotFound
+	}
+	if len(data) > n {
+		data = data[:n]
+	}
+
+	copyData := make([]byte, len(data))
+	copy(copyData, data)
+	return copyData, nil
+}
+
 // DeleteObject removes the stored payload.
 func (s *InMemoryObjectStore) DeleteObject(ctx context.Context, key string) error {
 	s.mu.Lock()
@@ -121,6 +143,26 @@ func (s *S3ObjectStore) GetObject(ctx context.Context, key string) ([]byte, erro
 	return data, nil
 }
 
+// GetObjectPrefix downloads the start of an object from S3 with a ranged request.
+func (s *S3ObjectStore) GetObjectPrefix(ctx context.Context, key string, n int) ([]byte, error) {
+	byteRange := fmt.Sprintf("bytes=0-%d", n-1)
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &s.bucket,
+		Key:    &key,
+		Range:  &byteRange,
+	})
+	if err != nil {
+		var notFound *types.NoSuchKey
+		if errors.As(err, &notFound) {
+			return nil, ErrEntryNotFound
+		}
+		return nil, err
+	}
+	defer out.Body.Close()
+
+	return io.ReadAll(io.LimitReader(out.Body, int64(n)))
+}
+
 // DeleteObject removes an object from S3.
 func (s *S3ObjectStore) DeleteObject(ctx context.Context, key string) error {
 	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
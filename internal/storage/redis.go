@@ -21,15 +21,18 @@ type RedisStorage struct {
 }
 
 type durableState struct {
-	Slices            map[string]*models.Slice          `json:"slices"`
-	Metadata          map[string]*models.SliceMetadata  `json:"metadata"`
-	SliceCommits      map[string][]*models.Commit       `json:"slice_commits"`
-	Changesets        map[string]*models.Changeset      `json:"changesets"`
-	SliceChangesets   map[string][]string               `json:"slice_changesets"`
-	Entries           map[string]*models.DirectoryEntry `json:"entries"`
-	EntriesByParent   map[string][]string               `json:"entries_by_parent"`
-	EntryPathsBySlice map[string]map[string]string      `json:"entry_paths_by_slice"`
-	GlobalState       *models.GlobalState               `json:"global_state"`
+	Slices            map[string]*models.Slice             `json:"slices"`
+	Metadata          map[string]*models.SliceMetadata     `json:"metadata"`
+	SliceCommits      map[string][]*models.Commit          `json:"slice_commits"`
+	Changesets        map[string]*models.Changeset         `json:"changesets"`
+	SliceChangesets   map[string][]string                  `json:"slice_changesets"`
+	Comments          map[string]*models.ReviewComment     `json:"comments"`
+	ChangesetComments map[string][]string                  `json:"changeset_comments"`
+	MergeQueues       map[string][]*models.MergeQueueEntry `json:"merge_queues"`
+	Entries           map[string]*models.DirectoryEntry    `json:"entries"`
+	EntriesByParent   map[string][]string                  `json:"entries_by_parent"`
+	EntryPathsBySlice map[string]map[string]string         `json:"entry_paths_by_slice"`
+	GlobalState       *models.GlobalState                  `json:"global_state"`
 }
 
 func newDurableState() *durableState {
@@ -39,6 +42,9 @@ func newDurableState() *durableState {
 		SliceCommits:      make(map[string][]*models.Commit),
 		Changesets:        make(map[string]*models.Changeset),
 		SliceChangesets:   make(map[string][]string),
+		Comments:          make(map[string]*models.ReviewComment),
+		ChangesetComments: make(map[string][]string),
+		MergeQueues:       make(map[string][]*models.MergeQueueEntry),
 		Entries:           make(map[string]*models.DirectoryEntry),
 		EntriesByParent:   make(map[string][]string),
 		EntryPathsBySlice: make(map[string]map[string]string),
@@ -87,6 +93,15 @@ func (s *RedisStorage) loadDurableState(ctx context.Context) (*durableState, err
 	if state.SliceChangesets == nil {
 		state.SliceChangesets = make(map[string][]string)
 	}
+	if state.Comments == nil {
+		state.Comments = make(map[string]*models.ReviewComment)
+	}
+	if state.ChangesetComments == nil {
+		state.ChangesetComments = make(map[string][]string)
+	}
+	if state.MergeQueues == nil {
+		state.MergeQueues = make(map[string][]*models.MergeQueueEntry)
+	}
 	if state.Entries == nil {
 		state.Entries = make(map[string]*models.DirectoryEntry)
 	}
@@ -132,6 +147,9 @@ func (s *RedisStorage) cacheSlice(ctx context.Context, slice *models.Slice, meta
 	for _, fileID := range slice.Files {
 		pipe.SAdd(ctx, s.key("file_index", fileID), slice.ID)
 	}
+	if slice.PathSpec().HasPatterns() {
+		pipe.SAdd(ctx, s.key("pattern_slices"), slice.ID)
+	}
 
 	if meta != nil {
 		metaRaw, err := marshal(meta)
@@ -294,6 +312,9 @@ func (s *RedisStorage) CreateSlice(ctx context.Context, slice *models.Slice) err
 	for _, fileID := range slice.Files {
 		pipe.SAdd(ctx, s.key("file_index", fileID), slice.ID)
 	}
+	if slice.PathSpec().HasPatterns() {
+		pipe.SAdd(ctx, s.key("pattern_slices"), slice.ID)
+	}
 
 	_, err = pipe.Exec(ctx)
 	return err
@@ -326,6 +347,89 @@ func (s *RedisStorage) GetSlice(ctx context.Context, sliceID string) (*models.Sl
 	return &slice, nil
 }
 
+// UpdateSlice replaces a slice's definition and re-indexes its files.
+func (s *RedisStorage) UpdateSlice(ctx context.Context, slice *models.Slice) error {
+	ctx = ensureCtx(ctx)
+	existing, err := s.GetSlice(ctx, slice.ID)
+	if err != nil {
+		return err
+	}
+
+	updated := *slice
+	updated.Files = append([]string(nil), slice.Files...)
+	updated.CreatedAt = existing.CreatedAt
+	updated.UpdatedAt = time.Now()
+	raw, err := marshal(&updated)
+	if err != nil {
+		return err
+	}
+
+	if err := s.withDurableState(ctx, func(state *durableState) error {
+		copySlice := updated
+		state.Slices[slice.ID] = &copySlice
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	listed := make(map[string]bool, len(updated.Files))
+	for _, fileID := range updated.Files {
+		listed[fileID] = true
+	}
+
+	pipe := s.rdb.TxPipeline()
+	pipe.Set(ctx, s.key("slice", slice.ID), raw, 0)
+	for _, fileID := range existing.Files {
+		if !listed[fileID] {
+			pipe.SRem(ctx, s.key("file_index", fileID), slice.ID)
+		}
+	}
+	for _, fileID := range updated.Files {
+		pipe.SAdd(ctx, s.key("file_index", fileID), slice.ID)
+	}
+	if updated.PathSpec().HasPatterns() {
+		pipe.SAdd(ctx, s.key("pattern_slices"), slice.ID)
+	} else {
+		pipe.SRem(ctx, s.key("pattern_slices"), slice.ID)
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// DeleteSlice removes a slice, its metadata and history, and its file claims.
+func (s *RedisStorage) DeleteSlice(ctx context.Context, sliceID string) error {
+	ctx = ensureCtx(ctx)
+	if _, err := s.GetSlice(ctx, sliceID); err != nil {
+		return err
+	}
+
+	// The index can name the slice for files it no longer lists, so drop the
+	// slice from every entry rather than only from its files'
+	indexKeys, err := s.rdb.Keys(ctx, s.key("file_index", "*")).Result()
+	if err != nil {
+		return err
+	}
+
+	if err := s.withDurableState(ctx, func(state *durableState) error {
+		delete(state.Slices, sliceID)
+		delete(state.Metadata, sliceID)
+		delete(state.SliceCommits, sliceID)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	pipe := s.rdb.TxPipeline()
+	pipe.Del(ctx, s.key("slice", sliceID), s.key("slice_metadata", sliceID), s.key("slice_commits", sliceID))
+	pipe.SRem(ctx, s.key("slices"), sliceID)
+	pipe.SRem(ctx, s.key("pattern_slices"), sliceID)
+	for _, key := range indexKeys {
+		pipe.SRem(ctx, key, sliceID)
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
 // ListSlices returns slices with pagination.
 func (s *RedisStorage) ListSlices(ctx context.Context, limit, offset int) ([]*models.Slice, error) {
 	ctx = ensureCtx(ctx)
@@ -496,6 +600,515 @@ func (s *RedisStorage) UpdateSliceMetadata(ctx context.Context, sliceID string,
 	return s.rdb.Set(ctx, s.key("slice_metadata", sliceID), raw, 0).Err()
 }
 
+// CommitBatch applies a batch in a single MULTI/EXEC while watching every key
+// the batch reads, retrying a few times if one of them changes underneath it.
+// The durable snapshot is written before EXEC and restored if EXEC fails.
+func (s *RedisStorage) CommitBatch(ctx context.Context, batch *Batch) error {
+	ctx = ensureCtx(ctx)
+	if batch.Empty() && len(batch.expectedHeads) == 0 {
+		return nil
+	}
+
+	attempts := 0
+	for {
+		attempts++
+		err := s.commitBatchOnce(ctx, batch)
+		if err == nil {
+			return nil
+		}
+		if err == redis.TxFailedErr && attempts < 5 {
+			continue
+		}
+		return err
+	}
+}
+
+func (s *RedisStorage) commitBatchOnce(ctx context.Context, batch *Batch) error {
+	slices := make(map[string]*models.Slice)
+	var watched []string
+	// Archiving or deleting a slice drops every index entry naming it, so
+	// those ops read and watch the whole file index
+	var indexKeys []string
+	for _, sliceID := range batch.slicesReferenced() {
+		slice, err := s.GetSlice(ctx, sliceID)
+		if err != nil {
+			return err
+		}
+		slices[sliceID] = slice
+		watched = append(watched, s.key("slice_metadata", sliceID), s.key("slice", sliceID))
+	}
+	for _, op := range batch.ops {
+		switch op.kind {
+		case opCreateSlice:
+			if _, err := s.GetSlice(ctx, op.sliceID); err == nil {
+				return ErrSliceAlreadyExists
+			} else if !errors.Is(err, ErrSliceNotFound) {
+				return err
+			}
+			watched = append(watched, s.key("slice", op.sliceID))
+			for _, fileID := range op.slice.Files {
+				watched = append(watched, s.key("file_index", fileID))
+			}
+		case opUpdateChangeset:
+			if _, err := s.GetChangeset(ctx, op.changeset.ID); err != nil {
+				return err
+			}
+			watched = append(watched, s.key("changeset", op.changeset.ID))
+		case opResolveConflict, opAddFileToSlice, opRemoveFileFromSlice:
+			watched = append(watched, s.key("file_index", op.fileID))
+		case opUpdateSlice:
+			for _, fileID := range op.slice.Files {
+				watched = append(watched, s.key("file_index", fileID))
+			}
+			if existing, ok := slices[op.sliceID]; ok {
+				for _, fileID := range existing.Files {
+					watched = append(watched, s.key("file_index", fileID))
+				}
+			}
+			watched = append(watched, s.key("pattern_slices"))
+		case opArchiveSlice, opDeleteSlice:
+			if indexKeys == nil {
+				keys, err := s.rdb.Keys(ctx, s.key("file_index", "*")).Result()
+				if err != nil {
+					return err
+				}
+				indexKeys = append([]string{}, keys...)
+				watched = append(watched, indexKeys...)
+			}
+			watched = append(watched, s.key("pattern_slices"))
+		case opAppendGlobalCommit:
+			watched = append(watched, s.key("global_state"))
+		}
+	}
+
+	return s.rdb.Watch(ctx, func(tx *redis.Tx) error {
+		for sliceID, head := range batch.expectedHeads {
+			current, err := s.GetSliceMetadata(ctx, sliceID)
+			if err != nil {
+				return err
+			}
+			if current.HeadCommitHash != head {
+				return ErrHeadMoved
+			}
+		}
+
+		previous, err := s.objectStore.GetObject(ctx, s.durableKey("state"))
+		if err != nil && !errors.Is(err, ErrEntryNotFound) {
+			return err
+		}
+		state, err := s.loadDurableState(ctx)
+		if err != nil {
+			return err
+		}
+
+		// Replay the batch against the current file index and global state,
+		// queueing Redis writes and applying the same changes to the snapshot.
+		fileIndex := make(map[string][]string)
+		members := func(fileID string) ([]string, error) {
+			if ids, ok := fileIndex[fileID]; ok {
+				return ids, nil
+			}
+			ids, err := tx.SMembers(ctx, s.key("file_index", fileID)).Result()
+			if err != nil && err != redis.Nil {
+				return nil, err
+			}
+			fileIndex[fileID] = ids
+			return ids, nil
+		}
+		dirtySlices := make(map[string]bool)
+		// dropFile removes a file from a slice's list, loading slices the
+		// batch does not reference, such as those losing a conflict
+		dropFile := func(sliceID, fileID string) error {
+			slice, ok := slices[sliceID]
+			if !ok {
+				loaded, err := s.GetSlice(ctx, sliceID)
+				if errors.Is(err, ErrSliceNotFound) {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				slices[sliceID], slice = loaded, loaded
+			}
+			slice.Files = RemoveString(slice.Files, fileID)
+			dirtySlices[sliceID] = true
+			stored := slice
+			if saved, ok := state.Slices[sliceID]; ok {
+				copySlice := *saved
+				copySlice.Files = RemoveString(copySlice.Files, fileID)
+				stored = &copySlice
+			}
+			state.Slices[sliceID] = stored
+			return nil
+		}
+
+		// releaseFiles removes a slice from every file index entry naming it,
+		// including entries added earlier in the batch
+		indexPrefix := s.key("file_index", "")
+		releaseFiles := func(sliceID string) ([]string, error) {
+			for _, key := range indexKeys {
+				if _, err := members(strings.TrimPrefix(key, indexPrefix)); err != nil {
+					return nil, err
+				}
+			}
+			var released []string
+			for fileID, ids := range fileIndex {
+				if containsID(ids, sliceID) {
+					fileIndex[fileID] = RemoveString(ids, sliceID)
+					released = append(released, fileID)
+				}
+			}
+			return released, nil
+		}
+
+		var writes []func(pipe redis.Pipeliner) error
+		for _, op := range batch.ops {
+			op := op
+			switch op.kind {
+			case opCreateSlice:
+				now := time.Now()
+				slice := op.slice
+				slice.CreatedAt = now
+				slice.UpdatedAt = now
+				slices[slice.ID] = slice
+				dirtySlices[slice.ID] = true
+				copySlice := *slice
+				copySlice.Files = append([]string(nil), slice.Files...)
+				state.Slices[slice.ID] = &copySlice
+
+				meta := &models.SliceMetadata{SliceID: slice.ID, ModifiedFiles: []string{}, LastModified: now}
+				copyMeta := *meta
+				state.Metadata[slice.ID] = &copyMeta
+				if _, ok := state.SliceChangesets[slice.ID]; !ok {
+					state.SliceChangesets[slice.ID] = []string{}
+				}
+				if _, ok := state.SliceCommits[slice.ID]; !ok {
+					state.SliceCommits[slice.ID] = []*models.Commit{}
+				}
+				for _, fileID := range slice.Files {
+					ids, err := members(fileID)
+					if err != nil {
+						return err
+					}
+					fileIndex[fileID] = appendUnique(ids, slice.ID)
+				}
+
+				metaRaw, err := marshal(meta)
+				if err != nil {
+					return err
+				}
+				files := append([]string(nil), slice.Files...)
+				patterns := slice.PathSpec().HasPatterns()
+				writes = append(writes, func(pipe redis.Pipeliner) error {
+					pipe.Set(ctx, s.key("slice_metadata", slice.ID), metaRaw, 0)
+					pipe.SAdd(ctx, s.key("slices"), slice.ID)
+					pipe.Del(ctx, s.key("slice_commits", slice.ID))
+					pipe.Del(ctx, s.key("slice_changesets", slice.ID))
+					for _, fileID := range files {
+						pipe.SAdd(ctx, s.key("file_index", fileID), slice.ID)
+					}
+					if patterns {
+						pipe.SAdd(ctx, s.key("pattern_slices"), slice.ID)
+					}
+					return nil
+				})
+
+			case opResolveConflict:
+				ids, err := members(op.fileID)
+				if err != nil {
+					return err
+				}
+				if len(ids) == 0 {
+					continue
+				}
+				kept := ids[0]
+				for _, id := range ids {
+					if op.sliceID != "" && id == op.sliceID {
+						kept = id
+						break
+					}
+				}
+				fileIndex[op.fileID] = []string{kept}
+				for _, id := range ids {
+					if id == kept {
+						continue
+					}
+					if err := dropFile(id, op.fileID); err != nil {
+						return err
+					}
+				}
+				key := s.key("file_index", op.fileID)
+				writes = append(writes, func(pipe redis.Pipeliner) error {
+					pipe.Del(ctx, key)
+					return pipe.SAdd(ctx, key, kept).Err()
+				})
+
+			case opAddFileToSlice:
+				ids, err := members(op.fileID)
+				if err != nil {
+					return err
+				}
+				fileIndex[op.fileID] = appendUnique(ids, op.sliceID)
+				slice := slices[op.sliceID]
+				slice.Files = appendUnique(slice.Files, op.fileID)
+				dirtySlices[op.sliceID] = true
+				stored := slice
+				if saved, ok := state.Slices[op.sliceID]; ok {
+					copySlice := *saved
+					copySlice.Files = appendUnique(copySlice.Files, op.fileID)
+					stored = &copySlice
+				}
+				state.Slices[op.sliceID] = stored
+				key := s.key("file_index", op.fileID)
+				writes = append(writes, func(pipe redis.Pipeliner) error {
+					return pipe.SAdd(ctx, key, op.sliceID).Err()
+				})
+
+			case opRemoveFileFromSlice:
+				ids, err := members(op.fileID)
+				if err != nil {
+					return err
+				}
+				fileIndex[op.fileID] = RemoveString(ids, op.sliceID)
+				if err := dropFile(op.sliceID, op.fileID); err != nil {
+					return err
+				}
+				key := s.key("file_index", op.fileID)
+				writes = append(writes, func(pipe redis.Pipeliner) error {
+					return pipe.SRem(ctx, key, op.sliceID).Err()
+				})
+
+			case opSetSliceParent:
+				slice := slices[op.sliceID]
+				slice.ParentSlice = op.parentID
+				slice.UpdatedAt = time.Now()
+				dirtySlices[op.sliceID] = true
+				stored := slice
+				if saved, ok := state.Slices[op.sliceID]; ok {
+					copySlice := *saved
+					copySlice.ParentSlice = slice.ParentSlice
+					copySlice.UpdatedAt = slice.UpdatedAt
+					stored = &copySlice
+				}
+				state.Slices[op.sliceID] = stored
+
+			case opUpdateSlice:
+				existing := slices[op.sliceID]
+				copied := *op.slice
+				updated := &copied
+				updated.Files = append([]string(nil), op.slice.Files...)
+				updated.CreatedAt = existing.CreatedAt
+				updated.UpdatedAt = time.Now()
+				listed := make(map[string]bool, len(updated.Files))
+				for _, fileID := range updated.Files {
+					listed[fileID] = true
+				}
+				var released []string
+				for _, fileID := range existing.Files {
+					if listed[fileID] {
+						continue
+					}
+					ids, err := members(fileID)
+					if err != nil {
+						return err
+					}
+					fileIndex[fileID] = RemoveString(ids, op.sliceID)
+					released = append(released, fileID)
+				}
+				for _, fileID := range updated.Files {
+					ids, err := members(fileID)
+					if err != nil {
+						return err
+					}
+					fileIndex[fileID] = appendUnique(ids, op.sliceID)
+				}
+				slices[op.sliceID] = updated
+				dirtySlices[op.sliceID] = true
+				copySlice := *updated
+				copySlice.Files = append([]string(nil), updated.Files...)
+				state.Slices[op.sliceID] = &copySlice
+
+				files := append([]string(nil), updated.Files...)
+				patterns := updated.PathSpec().HasPatterns()
+				writes = append(writes, func(pipe redis.Pipeliner) error {
+					for _, fileID := range released {
+						pipe.SRem(ctx, s.key("file_index", fileID), op.sliceID)
+					}
+					for _, fileID := range files {
+						pipe.SAdd(ctx, s.key("file_index", fileID), op.sliceID)
+					}
+					if patterns {
+						return pipe.SAdd(ctx, s.key("pattern_slices"), op.sliceID).Err()
+					}
+					return pipe.SRem(ctx, s.key("pattern_slices"), op.sliceID).Err()
+				})
+
+			case opArchiveSlice, opDeleteSlice:
+				released, err := releaseFiles(op.sliceID)
+				if err != nil {
+					return err
+				}
+				deleted := op.kind == opDeleteSlice
+				if deleted {
+					delete(slices, op.sliceID)
+					delete(dirtySlices, op.sliceID)
+					delete(state.Slices, op.sliceID)
+					delete(state.Metadata, op.sliceID)
+					delete(state.SliceCommits, op.sliceID)
+				} else {
+					slice := slices[op.sliceID]
+					slice.Files = nil
+					slice.Archived = true
+					slice.UpdatedAt = time.Now()
+					dirtySlices[op.sliceID] = true
+					copySlice := *slice
+					state.Slices[op.sliceID] = &copySlice
+				}
+				writes = append(writes, func(pipe redis.Pipeliner) error {
+					for _, fileID := range released {
+						pipe.SRem(ctx, s.key("file_index", fileID), op.sliceID)
+					}
+					if deleted {
+						pipe.Del(ctx, s.key("slice", op.sliceID), s.key("slice_metadata", op.sliceID), s.key("slice_commits", op.sliceID))
+						pipe.SRem(ctx, s.key("slices"), op.sliceID)
+					}
+					return pipe.SRem(ctx, s.key("pattern_slices"), op.sliceID).Err()
+				})
+
+			case opUpdateChangeset:
+				raw, err := marshal(op.changeset)
+				if err != nil {
+					return err
+				}
+				copyCS := *op.changeset
+				state.Changesets[op.changeset.ID] = &copyCS
+				key := s.key("changeset", op.changeset.ID)
+				writes = append(writes, func(pipe redis.Pipeliner) error {
+					return pipe.Set(ctx, key, raw, 0).Err()
+				})
+
+			case opUpdateSliceMetadata:
+				if op.metadata.LastModified.IsZero() {
+					op.metadata.LastModified = time.Now()
+				}
+				raw, err := marshal(op.metadata)
+				if err != nil {
+					return err
+				}
+				copyMeta := *op.metadata
+				state.Metadata[op.sliceID] = &copyMeta
+				key := s.key("slice_metadata", op.sliceID)
+				writes = append(writes, func(pipe redis.Pipeliner) error {
+					return pipe.Set(ctx, key, raw, 0).Err()
+				})
+
+			case opAddSliceCommit:
+				raw, err := marshal(op.commit)
+				if err != nil {
+					return err
+				}
+				copyCommit := *op.commit
+				state.SliceCommits[op.sliceID] = append([]*models.Commit{&copyCommit}, state.SliceCommits[op.sliceID]...)
+				key := s.key("slice_commits", op.sliceID)
+				writes = append(writes, func(pipe redis.Pipeliner) error {
+					return pipe.LPush(ctx, key, raw).Err()
+				})
+
+			case opAppendGlobalCommit:
+				current := state.GlobalState
+				raw, err := tx.Get(ctx, s.key("global_state")).Result()
+				if err != nil && err != redis.Nil {
+					return err
+				}
+				if err == nil {
+					var existing models.GlobalState
+					if err := unmarshal(raw, &existing); err != nil {
+						return err
+					}
+					current = &existing
+				}
+				next := applyGlobalCommit(current, op.globalCommit)
+				state.GlobalState = next
+				rawNext, err := marshal(next)
+				if err != nil {
+					return err
+				}
+				key := s.key("global_state")
+				writes = append(writes, func(pipe redis.Pipeliner) error {
+					return pipe.Set(ctx, key, rawNext, 0).Err()
+				})
+			}
+		}
+		for sliceID := range dirtySlices {
+			raw, err := marshal(slices[sliceID])
+			if err != nil {
+				return err
+			}
+			key := s.key("slice", sliceID)
+			writes = append(writes, func(pipe redis.Pipeliner) error {
+				return pipe.Set(ctx, key, raw, 0).Err()
+			})
+		}
+
+		if err := s.saveDurableState(ctx, state); err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, write := range writes {
+				if err := write(pipe); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			// Nothing was applied in Redis; put the durable snapshot back
+			if restoreErr := s.restoreDurableState(ctx, previous); restoreErr != nil {
+				return fmt.Errorf("%w (restoring durable state: %v)", err, restoreErr)
+			}
+			return err
+		}
+		return nil
+	}, watched...)
+}
+
+// restoreDurableState writes back a snapshot read before a failed batch.
+func (s *RedisStorage) restoreDurableState(ctx context.Context, previous []byte) error {
+	if previous == nil {
+		return s.objectStore.DeleteObject(ctx, s.durableKey("state"))
+	}
+	return s.objectStore.PutObject(ctx, s.durableKey("state"), previous)
+}
+
+func containsID(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+// RemoveString returns values without value, leaving the input untouched.
+func RemoveString(values []string, value string) []string {
+	kept := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != value {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
+
 // AddSliceCommit appends a commit to the slice history (newest first).
 func (s *RedisStorage) AddSliceCommit(ctx context.Context, sliceID string, commit *models.Commit) error {
 	ctx = ensureCtx(ctx)
@@ -631,8 +1244,50 @@ func (s *RedisStorage) GetActiveSlicesForFile(ctx context.Context, fileID string
 	if err != nil {
 		return nil, err
 	}
+
+	patternSlices, err := s.patternSlices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return withPatternClaims(fileID, ids, patternSlices), nil
+}
+
+// patternSlices loads the slices defined by path patterns.
+func (s *RedisStorage) patternSlices(ctx context.Context) ([]*models.Slice, error) {
+	ids, err := s.rdb.SMembers(ctx, s.key("pattern_slices")).Result()
+	if err != nil {
+		return nil, err
+	}
+	var slices []*models.Slice
+	for _, id := range ids {
+		slice, err := s.GetSlice(ctx, id)
+		if errors.Is(err, ErrSliceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		slices = append(slices, slice)
+	}
+	return slices, nil
+}
+
+// withPatternClaims adds the pattern slices matching a file to the slices
+// indexed for it, sorted by ID.
+func withPatternClaims(fileID string, indexedIDs []string, patternSlices []*models.Slice) []string {
+	ids := append([]string(nil), indexedIDs...)
+	indexed := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		indexed[id] = true
+	}
+	// Slices defined by patterns claim every file they match
+	for _, slice := range patternSlices {
+		if !indexed[slice.ID] && slice.PathSpec().Match(fileID) {
+			ids = append(ids, slice.ID)
+		}
+	}
 	sort.Strings(ids)
-	return ids, nil
+	return ids
 }
 
 // RemoveFileFromSlice removes a file mapping for a slice.
@@ -669,25 +1324,31 @@ func (s *RedisStorage) RemoveFileFromSlice(ctx context.Context, fileID, sliceID
 	return s.rdb.SRem(ctx, s.key("file_index", fileID), sliceID).Err()
 }
 
-// ListConflicts returns files mapped to multiple slices.
+// ListConflicts returns indexed files claimed by multiple slices, counting
+// slices whose patterns match the file.
 func (s *RedisStorage) ListConflicts(ctx context.Context) ([]*models.FileConflict, error) {
 	ctx = ensureCtx(ctx)
 	keys, err := s.rdb.Keys(ctx, s.key("file_index", "*")).Result()
 	if err != nil {
 		return nil, err
 	}
+	patternSlices, err := s.patternSlices(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	var conflicts []*models.FileConflict
 	for _, key := range keys {
-		ids, err := s.rdb.SMembers(ctx, key).Result()
+		indexed, err := s.rdb.SMembers(ctx, key).Result()
 		if err != nil {
 			return nil, err
 		}
+		fileID := lastKeySegment(key)
+		ids := withPatternClaims(fileID, indexed, patternSlices)
 		if len(ids) < 2 {
 			continue
 		}
-		sort.Strings(ids)
-		conflict := &models.FileConflict{FileID: lastKeySegment(key), ConflictingSlices: ids}
+		conflict := &models.FileConflict{FileID: fileID, ConflictingSlices: ids}
 		conflicts = append(conflicts, conflict)
 	}
 
@@ -717,8 +1378,46 @@ func (s *RedisStorage) ResolveConflict(ctx context.Context, fileID, preferredSli
 		allowed[ids[0]] = struct{}{}
 	}
 
+	// The slices losing the file stop listing it, so a rebuild from the
+	// durable snapshot does not bring their claims back
+	var losers []*models.Slice
+	for _, id := range ids {
+		if _, ok := allowed[id]; ok {
+			continue
+		}
+		slice, err := s.GetSlice(ctx, id)
+		if errors.Is(err, ErrSliceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		slice.Files = RemoveString(slice.Files, fileID)
+		losers = append(losers, slice)
+	}
+	if err := s.withDurableState(ctx, func(state *durableState) error {
+		for _, slice := range losers {
+			if saved, ok := state.Slices[slice.ID]; ok {
+				copySlice := *saved
+				copySlice.Files = RemoveString(copySlice.Files, fileID)
+				state.Slices[slice.ID] = &copySlice
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
 	pipe := s.rdb.TxPipeline()
 	pipe.Del(ctx, key)
+	for _, slice := range losers {
+		raw, err := marshal(slice)
+		if err != nil {
+			pipe.Discard()
+			return nil, err
+		}
+		pipe.Set(ctx, s.key("slice", slice.ID), raw, 0)
+	}
 	var remaining []string
 	for id := range allowed {
 		pipe.SAdd(ctx, key, id)
@@ -747,9 +1446,14 @@ func (s *RedisStorage) RebuildIndexes(ctx context.Context) error {
 		s.key("slice", "*"),
 		s.key("slice_metadata", "*"),
 		s.key("file_index", "*"),
+		s.key("pattern_slices"),
 		s.key("slice_commits", "*"),
 		s.key("slice_changesets", "*"),
 		s.key("changeset", "*"),
+		s.key("comment", "*"),
+		s.key("changeset_comments", "*"),
+		s.key("merge_queue", "*"),
+		s.key("merge_queue_entry", "*"),
 		s.key("entry", "*"),
 		s.key("entry_path", "*"),
 		s.key("entries_by_parent", "*"),
@@ -816,6 +1520,38 @@ func (s *RedisStorage) RebuildIndexes(ctx context.Context) error {
 		pipe.RPush(ctx, s.key("slice_changesets", sliceID), members...)
 	}
 
+	for id, comment := range state.Comments {
+		raw, err := marshal(comment)
+		if err != nil {
+			pipe.Discard()
+			return err
+		}
+		pipe.Set(ctx, s.key("comment", id), raw, 0)
+	}
+	for changesetID, ids := range state.ChangesetComments {
+		if len(ids) == 0 {
+			continue
+		}
+		members := make([]any, 0, len(ids))
+		for _, id := range ids {
+			members = append(members, id)
+		}
+		pipe.Del(ctx, s.key("changeset_comments", changesetID))
+		pipe.RPush(ctx, s.key("changeset_comments", changesetID), members...)
+	}
+
+	for sliceID, entries := range state.MergeQueues {
+		for _, entry := range entries {
+			raw, err := marshal(entry)
+			if err != nil {
+				pipe.Discard()
+				return err
+			}
+			pipe.Set(ctx, s.key("merge_queue_entry", entry.ChangesetID), raw, 0)
+			pipe.ZAdd(ctx, s.key("merge_queue", sliceID), redis.Z{Score: float64(entry.EnqueuedAt.UnixNano()), Member: entry.ChangesetID})
+		}
+	}
+
 	for _, entry := range state.Entries {
 		raw, err := marshal(entry)
 		if err != nil {
@@ -951,6 +1687,201 @@ func (s *RedisStorage) UpdateChangeset(ctx context.Context, changeset *models.Ch
 	return s.rdb.Set(ctx, s.key("changeset", changeset.ID), raw, 0).Err()
 }
 
+// AddComment stores a new review comment on an existing changeset.
+func (s *RedisStorage) AddComment(ctx context.Context, comment *models.ReviewComment) error {
+	ctx = ensureCtx(ctx)
+	if comment.ID == "" {
+		return ErrInvalidInput
+	}
+	if _, err := s.GetChangeset(ctx, comment.ChangesetID); err != nil {
+		return err
+	}
+
+	if err := s.withDurableState(ctx, func(state *durableState) error {
+		copyComment := *comment
+		state.Comments[comment.ID] = &copyComment
+		state.ChangesetComments[comment.ChangesetID] = append(state.ChangesetComments[comment.ChangesetID], comment.ID)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	raw, err := marshal(comment)
+	if err != nil {
+		return err
+	}
+
+	pipe := s.rdb.TxPipeline()
+	pipe.Set(ctx, s.key("comment", comment.ID), raw, 0)
+	pipe.RPush(ctx, s.key("changeset_comments", comment.ChangesetID), comment.ID)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// GetComment returns a stored review comment by ID.
+func (s *RedisStorage) GetComment(ctx context.Context, commentID string) (*models.ReviewComment, error) {
+	ctx = ensureCtx(ctx)
+	raw, err := s.rdb.Get(ctx, s.key("comment", commentID)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			state, loadErr := s.loadDurableState(ctx)
+			if loadErr == nil {
+				if comment, ok := state.Comments[commentID]; ok {
+					copyComment := *comment
+					return &copyComment, nil
+				}
+			}
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	var comment models.ReviewComment
+	if err := unmarshal(raw, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// ListComments lists a changeset's review comments in the order they were added.
+func (s *RedisStorage) ListComments(ctx context.Context, changesetID string) ([]*models.ReviewComment, error) {
+	ctx = ensureCtx(ctx)
+	ids, err := s.rdb.LRange(ctx, s.key("changeset_comments", changesetID), 0, -1).Result()
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		state, loadErr := s.loadDurableState(ctx)
+		if loadErr == nil {
+			ids = append(ids, state.ChangesetComments[changesetID]...)
+		}
+	}
+	result := []*models.ReviewComment{}
+	for _, id := range ids {
+		comment, err := s.GetComment(ctx, id)
+		if err != nil {
+			continue
+		}
+		result = append(result, comment)
+	}
+	return result, nil
+}
+
+// UpdateComment replaces a stored review comment.
+func (s *RedisStorage) UpdateComment(ctx context.Context, comment *models.ReviewComment) error {
+	ctx = ensureCtx(ctx)
+	if _, err := s.GetComment(ctx, comment.ID); err != nil {
+		return err
+	}
+	raw, err := marshal(comment)
+	if err != nil {
+		return err
+	}
+	if err := s.withDurableState(ctx, func(state *durableState) error {
+		copyComment := *comment
+		state.Comments[comment.ID] = &copyComment
+		return nil
+	}); err != nil {
+		return err
+	}
+	return s.rdb.Set(ctx, s.key("comment", comment.ID), raw, 0).Err()
+}
+
+// EnqueueMerge adds a changeset to its slice's pending-merge sorted set,
+// scored by the time it was enqueued.
+func (s *RedisStorage) EnqueueMerge(ctx context.Context, entry *models.MergeQueueEntry) error {
+	ctx = ensureCtx(ctx)
+	if _, err := s.GetSlice(ctx, entry.SliceID); err != nil {
+		return err
+	}
+
+	if err := s.withDurableState(ctx, func(state *durableState) error {
+		for _, queued := range state.MergeQueues[entry.SliceID] {
+			if queued.ChangesetID == entry.ChangesetID {
+				return ErrEntryExists
+			}
+		}
+		copyEntry := *entry
+		state.MergeQueues[entry.SliceID] = append(state.MergeQueues[entry.SliceID], &copyEntry)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	raw, err := marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	pipe := s.rdb.TxPipeline()
+	pipe.Set(ctx, s.key("merge_queue_entry", entry.ChangesetID), raw, 0)
+	pipe.ZAdd(ctx, s.key("merge_queue", entry.SliceID), redis.Z{Score: float64(entry.EnqueuedAt.UnixNano()), Member: entry.ChangesetID})
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// ListMergeQueue returns a slice's queued merges in the order they were enqueued.
+func (s *RedisStorage) ListMergeQueue(ctx context.Context, sliceID string) ([]*models.MergeQueueEntry, error) {
+	ctx = ensureCtx(ctx)
+	ids, err := s.rdb.ZRange(ctx, s.key("merge_queue", sliceID), 0, -1).Result()
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	result := []*models.MergeQueueEntry{}
+	if len(ids) == 0 {
+		state, loadErr := s.loadDurableState(ctx)
+		if loadErr == nil {
+			for _, entry := range state.MergeQueues[sliceID] {
+				copyEntry := *entry
+				result = append(result, &copyEntry)
+			}
+		}
+		return result, nil
+	}
+
+	for _, id := range ids {
+		raw, err := s.rdb.Get(ctx, s.key("merge_queue_entry", id)).Result()
+		if err != nil {
+			if err == redis.Nil {
+				continue
+			}
+			return nil, err
+		}
+		var entry models.MergeQueueEntry
+		if err := unmarshal(raw, &entry); err != nil {
+			return nil, err
+		}
+		result = append(result, &entry)
+	}
+	return result, nil
+}
+
+// RemoveFromMergeQueue drops a changeset from its slice's merge queue.
+func (s *RedisStorage) RemoveFromMergeQueue(ctx context.Context, sliceID, changesetID string) error {
+	ctx = ensureCtx(ctx)
+	if err := s.withDurableState(ctx, func(state *durableState) error {
+		queue := state.MergeQueues[sliceID]
+		for i, entry := range queue {
+			if entry.ChangesetID == changesetID {
+				state.MergeQueues[sliceID] = append(queue[:i:i], queue[i+1:]...)
+				if len(state.MergeQueues[sliceID]) == 0 {
+					delete(state.MergeQueues, sliceID)
+				}
+				return nil
+			}
+		}
+		return ErrEntryNotFound
+	}); err != nil {
+		return err
+	}
+
+	pipe := s.rdb.TxPipeline()
+	pipe.ZRem(ctx, s.key("merge_queue", sliceID), changesetID)
+	pipe.Del(ctx, s.key("merge_queue_entry", changesetID))
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
 // GetSliceFiles reads file content entries for a slice.
 func (s *RedisStorage) GetSliceFiles(ctx context.Context, sliceID string) ([]*models.FileContent, error) {
 	ctx = ensureCtx(ctx)
@@ -984,6 +1915,35 @@ func (s *RedisStorage) AddFileContent(ctx context.Context, content *models.FileC
 	return s.objectStore.PutObject(ctx, s.key("file_content", content.FileID), raw)
 }
 
+// PutObject validates an object and writes it to the object store under its hash.
+func (s *RedisStorage) PutObject(ctx context.Context, object *models.Object) error {
+	ctx = ensureCtx(ctx)
+	return putObject(ctx, s.objectStore, s.objectKey, object)
+}
+
+// GetObject reads an object from the object store by hash.
+func (s *RedisStorage) GetObject(ctx context.Context, hash string) (*models.Object, error) {
+	ctx = ensureCtx(ctx)
+	return getObject(ctx, s.objectStore, s.objectKey(hash), hash)
+}
+
+// HasObject reports whether the object store already holds the given hash.
+func (s *RedisStorage) HasObject(ctx context.Context, hash string) (bool, error) {
+	ctx = ensureCtx(ctx)
+	return hasObject(ctx, s.objectStore, s.objectKey(hash))
+}
+
+// StatObject reads an object's type and data size from the object store
+// without downloading its data.
+func (s *RedisStorage) StatObject(ctx context.Context, hash string) (models.ObjectType, int64, error) {
+	ctx = ensureCtx(ctx)
+	return statObject(ctx, s.objectStore, s.objectKey(hash))
+}
+
+func (s *RedisStorage) objectKey(hash string) string {
+	return s.key("objects", hash)
+}
+
 // GetRootSlice returns the root slice if present.
 func (s *RedisStorage) GetRootSlice(ctx context.Context) (*models.Slice, error) {
 	ctx = ensureCtx(ctx)
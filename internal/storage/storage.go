@@ -15,6 +15,9 @@ var (
 	ErrEntryNotFound      = errors.New("entry not found")
 	ErrEntryExists        = errors.New("entry already exists")
 	ErrLockHeld           = errors.New("resource locked")
+	ErrObjectNotFound     = errors.New("object not found")
+	ErrHeadMoved          = errors.New("slice head has moved")
+	ErrCommentNotFound    = errors.New("comment not found")
 )
 
 // Storage defines the interface for data storage operations
@@ -23,11 +26,21 @@ type Storage interface {
 	// Slice operations
 	CreateSlice(ctx context.Context, slice *models.Slice) error
 	GetSlice(ctx context.Context, sliceID string) (*models.Slice, error)
+	// UpdateSlice replaces a slice's definition, keeping its creation time.
+	// Files it no longer lists lose its claim in the file index and files it
+	// newly lists gain one.
+	UpdateSlice(ctx context.Context, slice *models.Slice) error
+	// DeleteSlice removes a slice with its metadata, history and every file
+	// index entry naming it. Its changesets are kept.
+	DeleteSlice(ctx context.Context, sliceID string) error
 	ListSlices(ctx context.Context, limit, offset int) ([]*models.Slice, error)
 	ListSlicesByOwner(ctx context.Context, owner string, limit, offset int) ([]*models.Slice, error)
 	SearchSlices(ctx context.Context, query string, limit, offset int) ([]*models.Slice, error)
 	GetSliceMetadata(ctx context.Context, sliceID string) (*models.SliceMetadata, error)
 	UpdateSliceMetadata(ctx context.Context, sliceID string, metadata *models.SliceMetadata) error
+	// CommitBatch applies every write in the batch, or none of them if any
+	// expectation fails or a referenced slice or changeset does not exist.
+	CommitBatch(ctx context.Context, batch *Batch) error
 	GetRootSlice(ctx context.Context) (*models.Slice, error)
 	InitializeRootSlice(ctx context.Context) error
 	AddSliceCommit(ctx context.Context, sliceID string, commit *models.Commit) error
@@ -51,6 +64,24 @@ type Storage interface {
 	ListChangesets(ctx context.Context, sliceID string, status *models.ChangesetStatus, limit int) ([]*models.Changeset, error)
 	UpdateChangeset(ctx context.Context, changeset *models.Changeset) error
 
+	// Review comments, listed oldest first
+	AddComment(ctx context.Context, comment *models.ReviewComment) error
+	GetComment(ctx context.Context, commentID string) (*models.ReviewComment, error)
+	ListComments(ctx context.Context, changesetID string) ([]*models.ReviewComment, error)
+	UpdateComment(ctx context.Context, comment *models.ReviewComment) error
+
+	// Merge queues, listed in the order changesets were enqueued
+	EnqueueMerge(ctx context.Context, entry *models.MergeQueueEntry) error
+	ListMergeQueue(ctx context.Context, sliceID string) ([]*models.MergeQueueEntry, error)
+	RemoveFromMergeQueue(ctx context.Context, sliceID, changesetID string) error
+
+	// Content-addressable objects
+	PutObject(ctx context.Context, object *models.Object) error
+	GetObject(ctx context.Context, hash string) (*models.Object, error)
+	HasObject(ctx context.Context, hash string) (bool, error)
+	// StatObject returns an object's type and data size without reading its data
+	StatObject(ctx context.Context, hash string) (models.ObjectType, int64, error)
+
 	// File content for checkout
 	GetSliceFiles(ctx context.Context, sliceID string) ([]*models.FileContent, error)
 	GetSliceFileByPath(ctx context.Context, sliceID, path string) (*models.FileContent, error)
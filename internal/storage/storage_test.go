@@ -2,11 +2,14 @@ package storage
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/alicebob/miniredis/v2"
 	"github.com/niczy/gitslice/internal/models"
+	"github.com/niczy/gitslice/internal/objects"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -74,6 +77,31 @@ func runStorageContract(ctx context.Context, t *testing.T, st Storage) {
 		t.Fatalf("UpdateSliceMetadata failed: %v", err)
 	}
 
+	// Head expectations
+	moveHead := func(sliceID, expectedHead string, metadata *models.SliceMetadata) error {
+		batch := NewBatch()
+		batch.ExpectHead(sliceID, expectedHead)
+		batch.UpdateSliceMetadata(sliceID, metadata)
+		return st.CommitBatch(ctx, batch)
+	}
+	next := *meta
+	next.HeadCommitHash = "commit-2"
+	if err := moveHead(slice.ID, "", &next); !errors.Is(err, ErrHeadMoved) {
+		t.Fatalf("expected ErrHeadMoved for stale head, got %v", err)
+	}
+	if err := moveHead(slice.ID, "commit-1", &next); err != nil {
+		t.Fatalf("CommitBatch moving the head failed: %v", err)
+	}
+	if err := moveHead(slice.ID, "commit-1", meta); !errors.Is(err, ErrHeadMoved) {
+		t.Fatalf("expected second move from the same head to fail, got %v", err)
+	}
+	if current, err := st.GetSliceMetadata(ctx, slice.ID); err != nil || current.HeadCommitHash != "commit-2" {
+		t.Fatalf("expected head commit-2 after the move: %v %+v", err, current)
+	}
+	if err := moveHead("missing", "", meta); !errors.Is(err, ErrSliceNotFound) {
+		t.Fatalf("expected ErrSliceNotFound, got %v", err)
+	}
+
 	// Commit history
 	commit := &models.Commit{CommitHash: "commit-1", ParentHash: "", Message: "init", Timestamp: time.Now()}
 	if err := st.AddSliceCommit(ctx, slice.ID, commit); err != nil {
@@ -108,6 +136,38 @@ func runStorageContract(ctx context.Context, t *testing.T, st Storage) {
 		t.Fatalf("ResolveConflict result mismatch: %+v", resolved)
 	}
 
+	// Slices defined by patterns claim the files they match
+	patterned := &models.Slice{ID: "slice-payments", Name: "Payments", Include: []string{"services/payments/"}, Exclude: []string{"**/*_test.go"}}
+	if err := st.CreateSlice(ctx, patterned); err != nil {
+		t.Fatalf("CreateSlice with patterns failed: %v", err)
+	}
+	for file, want := range map[string]bool{
+		"services/payments/api/handler.go":      true,
+		"services/payments/api/handler_test.go": false,
+		"services/billing/invoice.go":           false,
+	} {
+		owners, err := st.GetActiveSlicesForFile(ctx, file)
+		if err != nil {
+			t.Fatalf("GetActiveSlicesForFile failed: %v", err)
+		}
+		if got := len(owners) == 1 && owners[0] == patterned.ID; got != want {
+			t.Fatalf("expected %s claimed=%v, got owners %v", file, want, owners)
+		}
+	}
+
+	// A file listed by one slice and matched by another's patterns conflicts
+	if err := st.AddFileToSlice(ctx, "services/payments/api/handler.go", slice.ID); err != nil {
+		t.Fatalf("AddFileToSlice failed: %v", err)
+	}
+	conflicts, err = st.ListConflicts(ctx)
+	if err != nil || len(conflicts) != 1 || conflicts[0].FileID != "services/payments/api/handler.go" ||
+		strings.Join(conflicts[0].ConflictingSlices, ",") != slice.ID+","+patterned.ID {
+		t.Fatalf("expected the pattern overlap to be listed, got %v %+v", err, conflicts)
+	}
+	if err := st.RemoveFileFromSlice(ctx, "services/payments/api/handler.go", slice.ID); err != nil {
+		t.Fatalf("RemoveFileFromSlice failed: %v", err)
+	}
+
 	// Locking
 	if err := st.LockSliceAndFiles(ctx, slice.ID, []string{"file-1"}); err != nil {
 		t.Fatalf("LockSliceAndFiles failed: %v", err)
@@ -175,6 +235,280 @@ func runStorageContract(ctx context.Context, t *testing.T, st Storage) {
 		t.Fatalf("GetGlobalState mismatch: %v", err)
 	}
 
+	// Batches apply fully or not at all
+	mergedCS := *cs
+	mergedCS.Message = "batched"
+	batchMeta := *meta
+	batchMeta.HeadCommitHash = "commit-3"
+	stale := NewBatch()
+	stale.ExpectHead(slice.ID, "commit-1")
+	stale.UpdateChangeset(&mergedCS)
+	stale.UpdateSliceMetadata(slice.ID, &batchMeta)
+	stale.AddSliceCommit(slice.ID, &models.Commit{CommitHash: "commit-3", Timestamp: time.Now()})
+	if err := st.CommitBatch(ctx, stale); !errors.Is(err, ErrHeadMoved) {
+		t.Fatalf("expected ErrHeadMoved for stale batch, got %v", err)
+	}
+	if got, _ := st.GetChangeset(ctx, cs.ID); got.Message != cs.Message {
+		t.Fatalf("failed batch should not update changeset, got %q", got.Message)
+	}
+	if history, _ := st.ListSliceCommits(ctx, slice.ID, 0, ""); len(history) != 1 {
+		t.Fatalf("failed batch should not add commits, got %d", len(history))
+	}
+
+	batch := NewBatch()
+	batch.ExpectHead(slice.ID, "commit-2")
+	batch.ResolveConflict("file-2", slice.ID)
+	batch.AddFileToSlice("file-2", slice.ID)
+	batch.UpdateChangeset(&mergedCS)
+	batch.UpdateSliceMetadata(slice.ID, &batchMeta)
+	batch.AddSliceCommit(slice.ID, &models.Commit{CommitHash: "commit-3", Timestamp: time.Now()})
+	batch.AppendGlobalCommit(&models.GlobalCommit{CommitHash: "commit-3", Timestamp: time.Now(), MergedSliceIDs: []string{slice.ID}})
+	if err := st.CommitBatch(ctx, batch); err != nil {
+		t.Fatalf("CommitBatch failed: %v", err)
+	}
+	if got, _ := st.GetChangeset(ctx, cs.ID); got.Message != "batched" {
+		t.Fatalf("expected batched changeset update, got %q", got.Message)
+	}
+	if current, _ := st.GetSliceMetadata(ctx, slice.ID); current.HeadCommitHash != "commit-3" {
+		t.Fatalf("expected head commit-3, got %s", current.HeadCommitHash)
+	}
+	if history, _ := st.ListSliceCommits(ctx, slice.ID, 0, ""); len(history) != 2 || history[0].CommitHash != "commit-3" {
+		t.Fatalf("expected commit-3 at the front of history, got %d commits", len(history))
+	}
+	if owners, _ := st.GetActiveSlicesForFile(ctx, "file-2"); len(owners) != 1 || owners[0] != slice.ID {
+		t.Fatalf("expected file-2 to belong to %s, got %v", slice.ID, owners)
+	}
+	if global, _ := st.GetGlobalState(ctx); global.GlobalCommitHash != "commit-3" || len(global.History) != 2 {
+		t.Fatalf("expected global head commit-3 on top of history, got %+v", global)
+	}
+	missing := NewBatch()
+	missing.UpdateChangeset(&models.Changeset{ID: "cs-missing", SliceID: slice.ID})
+	missing.AddSliceCommit(slice.ID, &models.Commit{CommitHash: "commit-4", Timestamp: time.Now()})
+	if err := st.CommitBatch(ctx, missing); !errors.Is(err, ErrChangesetNotFound) {
+		t.Fatalf("expected ErrChangesetNotFound, got %v", err)
+	}
+	if history, _ := st.ListSliceCommits(ctx, slice.ID, 0, ""); len(history) != 2 {
+		t.Fatalf("failed batch should not add commits, got %d", len(history))
+	}
+	release := NewBatch()
+	release.RemoveFileFromSlice("file-2", slice.ID)
+	if err := st.CommitBatch(ctx, release); err != nil {
+		t.Fatalf("CommitBatch removing a file failed: %v", err)
+	}
+	if owners, _ := st.GetActiveSlicesForFile(ctx, "file-2"); len(owners) != 0 {
+		t.Fatalf("expected file-2 to be released, got %v", owners)
+	}
+	if err := st.AddFileToSlice(ctx, "file-2", slice.ID); err != nil {
+		t.Fatalf("AddFileToSlice failed: %v", err)
+	}
+	reparent := NewBatch()
+	reparent.SetSliceParent(slice2.ID, slice.ID)
+	if err := st.CommitBatch(ctx, reparent); err != nil {
+		t.Fatalf("CommitBatch reparenting a slice failed: %v", err)
+	}
+	if moved, _ := st.GetSlice(ctx, slice2.ID); moved.ParentSlice != slice.ID {
+		t.Fatalf("expected %s under %s, got parent %q", slice2.ID, slice.ID, moved.ParentSlice)
+	}
+	orphan := NewBatch()
+	orphan.SetSliceParent(slice2.ID, "missing")
+	if err := st.CommitBatch(ctx, orphan); !errors.Is(err, ErrSliceNotFound) {
+		t.Fatalf("expected ErrSliceNotFound for a missing parent, got %v", err)
+	}
+	carve := NewBatch()
+	carve.CreateSlice(&models.Slice{ID: "slice-carved", Name: "Carved", Files: []string{"file-8"}, ParentSlice: slice.ID})
+	carve.UpdateSliceMetadata("slice-carved", &models.SliceMetadata{SliceID: "slice-carved", HeadCommitHash: "commit-5"})
+	carve.AddSliceCommit("slice-carved", &models.Commit{CommitHash: "commit-5", Timestamp: time.Now()})
+	if err := st.CommitBatch(ctx, carve); err != nil {
+		t.Fatalf("CommitBatch creating a slice failed: %v", err)
+	}
+	if carved, err := st.GetSlice(ctx, "slice-carved"); err != nil || carved.ParentSlice != slice.ID || carved.CreatedAt.IsZero() {
+		t.Fatalf("expected the batch to create slice-carved: %v %+v", err, carved)
+	}
+	if current, _ := st.GetSliceMetadata(ctx, "slice-carved"); current.HeadCommitHash != "commit-5" {
+		t.Fatalf("expected head commit-5 for the created slice, got %s", current.HeadCommitHash)
+	}
+	if history, _ := st.ListSliceCommits(ctx, "slice-carved", 0, ""); len(history) != 1 {
+		t.Fatalf("expected one commit for the created slice, got %d", len(history))
+	}
+	if owners, _ := st.GetActiveSlicesForFile(ctx, "file-8"); len(owners) != 1 || owners[0] != "slice-carved" {
+		t.Fatalf("expected file-8 to belong to slice-carved, got %v", owners)
+	}
+	taken := NewBatch()
+	taken.CreateSlice(&models.Slice{ID: "slice-carved", Name: "Again"})
+	taken.AddFileToSlice("file-9", slice.ID)
+	if err := st.CommitBatch(ctx, taken); !errors.Is(err, ErrSliceAlreadyExists) {
+		t.Fatalf("expected ErrSliceAlreadyExists, got %v", err)
+	}
+	if owners, _ := st.GetActiveSlicesForFile(ctx, "file-9"); len(owners) != 0 {
+		t.Fatalf("failed batch should not claim files, got %v", owners)
+	}
+	if err := st.DeleteSlice(ctx, "slice-carved"); err != nil {
+		t.Fatalf("DeleteSlice failed: %v", err)
+	}
+
+	// Updating and deleting slices keeps the file index in step
+	slice3 := &models.Slice{ID: "slice-3", Name: "Gamma", Files: []string{"file-7", "file-1"}}
+	if err := st.CreateSlice(ctx, slice3); err != nil {
+		t.Fatalf("CreateSlice third failed: %v", err)
+	}
+	if _, err := st.ResolveConflict(ctx, "file-1", slice.ID); err != nil {
+		t.Fatalf("ResolveConflict failed: %v", err)
+	}
+	if loser, _ := st.GetSlice(ctx, slice3.ID); len(loser.Files) != 1 || loser.Files[0] != "file-7" {
+		t.Fatalf("expected %s to stop listing file-1, got %v", slice3.ID, loser.Files)
+	}
+	renamed, _ := st.GetSlice(ctx, slice3.ID)
+	renamed.Name = "Gamma 2"
+	renamed.Files = []string{"file-8"}
+	if err := st.UpdateSlice(ctx, renamed); err != nil {
+		t.Fatalf("UpdateSlice failed: %v", err)
+	}
+	if updated, _ := st.GetSlice(ctx, slice3.ID); updated.Name != "Gamma 2" || updated.CreatedAt.IsZero() {
+		t.Fatalf("expected the rename to keep the creation time, got %+v", updated)
+	}
+	if owners, _ := st.GetActiveSlicesForFile(ctx, "file-7"); len(owners) != 0 {
+		t.Fatalf("expected file-7 to be released, got %v", owners)
+	}
+	if owners, _ := st.GetActiveSlicesForFile(ctx, "file-8"); len(owners) != 1 || owners[0] != slice3.ID {
+		t.Fatalf("expected file-8 to belong to %s, got %v", slice3.ID, owners)
+	}
+	if err := st.UpdateSlice(ctx, &models.Slice{ID: "missing"}); !errors.Is(err, ErrSliceNotFound) {
+		t.Fatalf("expected ErrSliceNotFound, got %v", err)
+	}
+	if err := st.DeleteSlice(ctx, slice3.ID); err != nil {
+		t.Fatalf("DeleteSlice failed: %v", err)
+	}
+	if _, err := st.GetSlice(ctx, slice3.ID); !errors.Is(err, ErrSliceNotFound) {
+		t.Fatalf("expected deleted slice to be gone, got %v", err)
+	}
+	if owners, _ := st.GetActiveSlicesForFile(ctx, "file-8"); len(owners) != 0 {
+		t.Fatalf("expected file-8 to be released, got %v", owners)
+	}
+	if err := st.DeleteSlice(ctx, slice3.ID); !errors.Is(err, ErrSliceNotFound) {
+		t.Fatalf("expected ErrSliceNotFound deleting twice, got %v", err)
+	}
+
+	// Batches edit, archive and delete slices together with other writes
+	for _, created := range []*models.Slice{
+		{ID: "slice-4", Name: "Delta", Files: []string{"file-10"}},
+		{ID: "slice-5", Name: "Epsilon", Files: []string{"file-11"}},
+	} {
+		if err := st.CreateSlice(ctx, created); err != nil {
+			t.Fatalf("CreateSlice %s failed: %v", created.ID, err)
+		}
+	}
+	edited, _ := st.GetSlice(ctx, "slice-4")
+	edited.Name = "Delta 2"
+	edited.Files = []string{"file-12"}
+	edit := NewBatch()
+	edit.UpdateSlice(edited)
+	edit.AddFileToSlice("file-10", "slice-5")
+	if err := st.CommitBatch(ctx, edit); err != nil {
+		t.Fatalf("CommitBatch updating a slice failed: %v", err)
+	}
+	if updated, _ := st.GetSlice(ctx, "slice-4"); updated.Name != "Delta 2" || updated.CreatedAt.IsZero() {
+		t.Fatalf("expected the batch to rename slice-4 and keep its creation time, got %+v", updated)
+	}
+	for file, want := range map[string]string{"file-10": "slice-5", "file-12": "slice-4"} {
+		if owners, _ := st.GetActiveSlicesForFile(ctx, file); strings.Join(owners, ",") != want {
+			t.Fatalf("expected %s owned by %q after the update, got %v", file, want, owners)
+		}
+	}
+	archive := NewBatch()
+	archive.AddFileToSlice("file-12", "slice-5")
+	archive.ArchiveSlice("slice-4")
+	if err := st.CommitBatch(ctx, archive); err != nil {
+		t.Fatalf("CommitBatch archiving a slice failed: %v", err)
+	}
+	if archived, err := st.GetSlice(ctx, "slice-4"); err != nil || !archived.Archived || len(archived.Files) != 0 {
+		t.Fatalf("expected slice-4 archived with no files: %v %+v", err, archived)
+	}
+	if owners, _ := st.GetActiveSlicesForFile(ctx, "file-12"); strings.Join(owners, ",") != "slice-5" {
+		t.Fatalf("expected file-12 to pass to slice-5, got %v", owners)
+	}
+	if err := st.AddFileToSlice(ctx, "file-13", "slice-5"); err != nil {
+		t.Fatalf("AddFileToSlice failed: %v", err)
+	}
+	drop := NewBatch()
+	drop.DeleteSlice("slice-5")
+	if err := st.CommitBatch(ctx, drop); err != nil {
+		t.Fatalf("CommitBatch deleting a slice failed: %v", err)
+	}
+	if _, err := st.GetSlice(ctx, "slice-5"); !errors.Is(err, ErrSliceNotFound) {
+		t.Fatalf("expected the batch to delete slice-5, got %v", err)
+	}
+	if _, err := st.GetSliceMetadata(ctx, "slice-5"); !errors.Is(err, ErrSliceNotFound) {
+		t.Fatalf("expected slice-5's metadata to go with it, got %v", err)
+	}
+	for _, file := range []string{"file-10", "file-11", "file-12", "file-13"} {
+		if owners, _ := st.GetActiveSlicesForFile(ctx, file); len(owners) != 0 {
+			t.Fatalf("expected %s to be released, got %v", file, owners)
+		}
+	}
+	gone := NewBatch()
+	gone.ArchiveSlice("missing")
+	if err := st.CommitBatch(ctx, gone); !errors.Is(err, ErrSliceNotFound) {
+		t.Fatalf("expected ErrSliceNotFound archiving a missing slice, got %v", err)
+	}
+
+	// Review comments
+	comment := &models.ReviewComment{ID: "comment-1", ChangesetID: cs.ID, Path: "app/main.go", Line: 3, Author: "bob", Body: "nit", CreatedAt: time.Now()}
+	if err := st.AddComment(ctx, comment); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	reply := &models.ReviewComment{ID: "comment-2", ChangesetID: cs.ID, ParentID: comment.ID, Author: "alice", Body: "fixed", CreatedAt: time.Now()}
+	if err := st.AddComment(ctx, reply); err != nil {
+		t.Fatalf("AddComment reply failed: %v", err)
+	}
+	if err := st.AddComment(ctx, &models.ReviewComment{ID: "comment-3", ChangesetID: "cs-missing"}); !errors.Is(err, ErrChangesetNotFound) {
+		t.Fatalf("expected ErrChangesetNotFound, got %v", err)
+	}
+	comment.Resolved = true
+	if err := st.UpdateComment(ctx, comment); err != nil {
+		t.Fatalf("UpdateComment failed: %v", err)
+	}
+	if got, err := st.GetComment(ctx, comment.ID); err != nil || !got.Resolved || got.Line != 3 {
+		t.Fatalf("GetComment mismatch: %v %+v", err, got)
+	}
+	if _, err := st.GetComment(ctx, "comment-missing"); !errors.Is(err, ErrCommentNotFound) {
+		t.Fatalf("expected ErrCommentNotFound, got %v", err)
+	}
+	if err := st.UpdateComment(ctx, &models.ReviewComment{ID: "comment-missing"}); !errors.Is(err, ErrCommentNotFound) {
+		t.Fatalf("expected ErrCommentNotFound on update, got %v", err)
+	}
+	if thread, err := st.ListComments(ctx, cs.ID); err != nil || len(thread) != 2 || thread[0].ID != comment.ID || thread[1].ParentID != comment.ID {
+		t.Fatalf("ListComments mismatch: %v %+v", err, thread)
+	}
+
+	// Merge queue
+	enqueued := time.Now()
+	for i, id := range []string{"cs-queued-1", "cs-queued-2"} {
+		if err := st.EnqueueMerge(ctx, &models.MergeQueueEntry{SliceID: slice.ID, ChangesetID: id, EnqueuedBy: "bob", EnqueuedAt: enqueued.Add(time.Duration(i) * time.Second)}); err != nil {
+			t.Fatalf("EnqueueMerge %s failed: %v", id, err)
+		}
+	}
+	if err := st.EnqueueMerge(ctx, &models.MergeQueueEntry{SliceID: slice.ID, ChangesetID: "cs-queued-1", EnqueuedAt: time.Now()}); !errors.Is(err, ErrEntryExists) {
+		t.Fatalf("expected ErrEntryExists, got %v", err)
+	}
+	if err := st.EnqueueMerge(ctx, &models.MergeQueueEntry{SliceID: "slice-missing", ChangesetID: "cs-queued-3", EnqueuedAt: time.Now()}); !errors.Is(err, ErrSliceNotFound) {
+		t.Fatalf("expected ErrSliceNotFound, got %v", err)
+	}
+	if queue, err := st.ListMergeQueue(ctx, slice.ID); err != nil || len(queue) != 2 || queue[0].ChangesetID != "cs-queued-1" || queue[0].EnqueuedBy != "bob" {
+		t.Fatalf("ListMergeQueue mismatch: %v %+v", err, queue)
+	}
+	if err := st.RemoveFromMergeQueue(ctx, slice.ID, "cs-queued-1"); err != nil {
+		t.Fatalf("RemoveFromMergeQueue failed: %v", err)
+	}
+	if err := st.RemoveFromMergeQueue(ctx, slice.ID, "cs-queued-1"); !errors.Is(err, ErrEntryNotFound) {
+		t.Fatalf("expected ErrEntryNotFound, got %v", err)
+	}
+	if queue, err := st.ListMergeQueue(ctx, slice.ID); err != nil || len(queue) != 1 || queue[0].ChangesetID != "cs-queued-2" {
+		t.Fatalf("expected only cs-queued-2 left in queue: %v %+v", err, queue)
+	}
+	if err := st.RemoveFromMergeQueue(ctx, slice.ID, "cs-queued-2"); err != nil {
+		t.Fatalf("RemoveFromMergeQueue failed: %v", err)
+	}
+
 	// Root slice init
 	if err := st.InitializeRootSlice(ctx); err != nil {
 		t.Fatalf("InitializeRootSlice failed: %v", err)
@@ -183,6 +517,53 @@ func runStorageContract(ctx context.Context, t *testing.T, st Storage) {
 		t.Fatalf("GetRootSlice failed: %v", err)
 	}
 
+	// Content-addressable objects
+	blobHash, err := WriteBlob(ctx, st, []byte("package main\n"))
+	if err != nil {
+		t.Fatalf("WriteBlob failed: %v", err)
+	}
+	if exists, err := st.HasObject(ctx, blobHash); err != nil || !exists {
+		t.Fatalf("HasObject expected blob to exist: %v", err)
+	}
+	if err := st.PutObject(ctx, &models.Object{Type: models.ObjectTypeBlob, Hash: blobHash, Data: []byte("tampered")}); !errors.Is(err, objects.ErrHashMismatch) {
+		t.Fatalf("expected hash mismatch for tampered blob, got %v", err)
+	}
+	if _, err := st.GetObject(ctx, "missing"); !errors.Is(err, ErrObjectNotFound) {
+		t.Fatalf("expected ErrObjectNotFound, got %v", err)
+	}
+	if size, err := BlobSize(ctx, st, blobHash); err != nil || size != int64(len("package main\n")) {
+		t.Fatalf("BlobSize returned %d, %v", size, err)
+	}
+	if _, _, err := st.StatObject(ctx, "missing"); !errors.Is(err, ErrObjectNotFound) {
+		t.Fatalf("expected ErrObjectNotFound from StatObject, got %v", err)
+	}
+
+	snapshot := map[string]string{"app/main.go": blobHash, "README.md": blobHash}
+	treeHash, err := WriteSnapshot(ctx, st, snapshot)
+	if err != nil {
+		t.Fatalf("WriteSnapshot failed: %v", err)
+	}
+	sameTree, err := WriteSnapshot(ctx, st, map[string]string{"README.md": blobHash, "app/main.go": blobHash})
+	if err != nil || sameTree != treeHash {
+		t.Fatalf("expected identical snapshots to share a tree hash: %s vs %s (%v)", treeHash, sameTree, err)
+	}
+	readBack, err := ReadSnapshot(ctx, st, treeHash)
+	if err != nil || len(readBack) != 2 || readBack["app/main.go"] != blobHash {
+		t.Fatalf("ReadSnapshot mismatch: %v %+v", err, readBack)
+	}
+	objCommit := &models.Commit{TreeHash: treeHash, SliceID: slice.ID, Author: "alice", Timestamp: time.Now(), Message: "snapshot"}
+	commitHash, err := WriteCommit(ctx, st, objCommit)
+	if err != nil {
+		t.Fatalf("WriteCommit failed: %v", err)
+	}
+	loadedCommit, err := ReadCommit(ctx, st, commitHash)
+	if err != nil || loadedCommit.TreeHash != treeHash || loadedCommit.Message != "snapshot" {
+		t.Fatalf("ReadCommit mismatch: %v %+v", err, loadedCommit)
+	}
+	if _, err := ReadTree(ctx, st, blobHash); !errors.Is(err, objects.ErrInvalidObject) {
+		t.Fatalf("expected ReadTree on a blob to fail, got %v", err)
+	}
+
 	// Basic health
 	if err := st.Ping(ctx); err != nil {
 		t.Fatalf("Ping failed: %v", err)
@@ -201,7 +582,7 @@ func TestRedisStorageRebuildIndexes(t *testing.T) {
 	})
 
 	slice1 := &models.Slice{ID: "slice-1", Name: "Alpha", Files: []string{"file-1"}}
-	slice2 := &models.Slice{ID: "slice-2", Name: "Beta", Files: []string{"file-1", "file-2"}}
+	slice2 := &models.Slice{ID: "slice-2", Name: "Beta", Files: []string{"file-1", "file-2"}, Include: []string{"docs/**/*.md"}}
 	if err := rs.CreateSlice(ctx, slice1); err != nil {
 		t.Fatalf("CreateSlice 1 failed: %v", err)
 	}
@@ -213,6 +594,12 @@ func TestRedisStorageRebuildIndexes(t *testing.T) {
 	if err := rs.CreateChangeset(ctx, cs); err != nil {
 		t.Fatalf("CreateChangeset failed: %v", err)
 	}
+	if err := rs.AddComment(ctx, &models.ReviewComment{ID: "comment-rebuild", ChangesetID: cs.ID, Body: "looks good"}); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if err := rs.EnqueueMerge(ctx, &models.MergeQueueEntry{SliceID: slice1.ID, ChangesetID: cs.ID, EnqueuedAt: time.Now()}); err != nil {
+		t.Fatalf("EnqueueMerge failed: %v", err)
+	}
 	entry := &models.DirectoryEntry{ID: "entry-1", Path: "app/main.go", Type: "file", ParentID: slice1.ID, Content: []byte("hi"), Size: 2}
 	if err := rs.AddEntry(ctx, entry); err != nil {
 		t.Fatalf("AddEntry failed: %v", err)
@@ -242,11 +629,20 @@ func TestRedisStorageRebuildIndexes(t *testing.T) {
 	if len(mapped) != 2 {
 		t.Fatalf("expected file-1 to map to 2 slices after rebuild, got %d", len(mapped))
 	}
+	if mapped, err := rs.GetActiveSlicesForFile(ctx, "docs/guide/setup.md"); err != nil || len(mapped) != 1 || mapped[0] != slice2.ID {
+		t.Fatalf("expected patterns to claim files after rebuild: %v %v", err, mapped)
+	}
 
 	restoredCS, err := rs.GetChangeset(ctx, cs.ID)
 	if err != nil || restoredCS.ID != cs.ID {
 		t.Fatalf("expected changeset restored after rebuild: %v", err)
 	}
+	if comments, err := rs.ListComments(ctx, cs.ID); err != nil || len(comments) != 1 || comments[0].Body != "looks good" {
+		t.Fatalf("expected comments restored after rebuild: %v %+v", err, comments)
+	}
+	if queue, err := rs.ListMergeQueue(ctx, slice1.ID); err != nil || len(queue) != 1 || queue[0].ChangesetID != cs.ID {
+		t.Fatalf("expected merge queue restored after rebuild: %v %+v", err, queue)
+	}
 	restoredEntry, err := rs.GetEntry(ctx, entry.ID)
 	if err != nil || restoredEntry.Path != entry.Path {
 		t.Fatalf("expected entry restored after rebuild: %v", err)
@@ -256,3 +652,125 @@ func TestRedisStorageRebuildIndexes(t *testing.T) {
 		t.Fatalf("expected global state restored, got %#v err=%v", restoredState, err)
 	}
 }
+
+func TestRedisStorageBatchIsDurable(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	rs := NewRedisStorage(client, NewInMemoryObjectStore(), "batch")
+	t.Cleanup(func() {
+		_ = client.Close()
+		mr.Close()
+	})
+
+	if err := rs.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "Alpha", Files: []string{"file-2"}, ParentSlice: "retired"}); err != nil {
+		t.Fatalf("CreateSlice failed: %v", err)
+	}
+	meta, err := rs.GetSliceMetadata(ctx, "slice-1")
+	if err != nil {
+		t.Fatalf("GetSliceMetadata failed: %v", err)
+	}
+	meta.HeadCommitHash = "commit-1"
+
+	batch := NewBatch()
+	batch.ExpectHead("slice-1", "")
+	batch.AddFileToSlice("file-1", "slice-1")
+	batch.RemoveFileFromSlice("file-2", "slice-1")
+	batch.SetSliceParent("slice-1", "")
+	batch.CreateSlice(&models.Slice{ID: "slice-2", Name: "Beta", Files: []string{"file-3"}, ParentSlice: "slice-1"})
+	batch.UpdateSliceMetadata("slice-1", meta)
+	batch.AddSliceCommit("slice-1", &models.Commit{CommitHash: "commit-1", Timestamp: time.Now()})
+	if err := rs.CommitBatch(ctx, batch); err != nil {
+		t.Fatalf("CommitBatch failed: %v", err)
+	}
+
+	mr.FlushAll()
+	if err := rs.RebuildIndexes(ctx); err != nil {
+		t.Fatalf("RebuildIndexes failed: %v", err)
+	}
+
+	restored, err := rs.GetSliceMetadata(ctx, "slice-1")
+	if err != nil || restored.HeadCommitHash != "commit-1" {
+		t.Fatalf("expected head to survive a flush: %v %+v", err, restored)
+	}
+	commits, err := rs.ListSliceCommits(ctx, "slice-1", 0, "")
+	if err != nil || len(commits) != 1 {
+		t.Fatalf("expected commit to survive a flush: %v len=%d", err, len(commits))
+	}
+	owners, err := rs.GetActiveSlicesForFile(ctx, "file-1")
+	if err != nil || len(owners) != 1 || owners[0] != "slice-1" {
+		t.Fatalf("expected file index to be rebuilt: %v %v", err, owners)
+	}
+	if owners, err := rs.GetActiveSlicesForFile(ctx, "file-2"); err != nil || len(owners) != 0 {
+		t.Fatalf("expected removed file to stay released: %v %v", err, owners)
+	}
+	if slice, err := rs.GetSlice(ctx, "slice-1"); err != nil || slice.ParentSlice != "" {
+		t.Fatalf("expected the new parent to survive a flush: %v %+v", err, slice)
+	}
+	if slice, err := rs.GetSlice(ctx, "slice-2"); err != nil || slice.ParentSlice != "slice-1" {
+		t.Fatalf("expected the created slice to survive a flush: %v %+v", err, slice)
+	}
+	if owners, err := rs.GetActiveSlicesForFile(ctx, "file-3"); err != nil || len(owners) != 1 || owners[0] != "slice-2" {
+		t.Fatalf("expected the created slice's files to be re-indexed: %v %v", err, owners)
+	}
+}
+
+func TestRedisStorageSliceUpdatesAreDurable(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	rs := NewRedisStorage(client, NewInMemoryObjectStore(), "updates")
+	t.Cleanup(func() {
+		_ = client.Close()
+		mr.Close()
+	})
+
+	for _, slice := range []*models.Slice{
+		{ID: "slice-1", Name: "Alpha", Files: []string{"file-1"}},
+		{ID: "slice-2", Name: "Beta", Files: []string{"file-1", "file-2"}},
+		{ID: "slice-3", Name: "Gamma", Files: []string{"file-3"}},
+	} {
+		if err := rs.CreateSlice(ctx, slice); err != nil {
+			t.Fatalf("CreateSlice failed: %v", err)
+		}
+	}
+	batch := NewBatch()
+	batch.ResolveConflict("file-1", "slice-1")
+	if err := rs.CommitBatch(ctx, batch); err != nil {
+		t.Fatalf("CommitBatch failed: %v", err)
+	}
+	if err := rs.UpdateSlice(ctx, &models.Slice{ID: "slice-2", Name: "Beta 2", Files: []string{"file-4"}}); err != nil {
+		t.Fatalf("UpdateSlice failed: %v", err)
+	}
+	// An index entry the slice no longer lists still goes with it
+	if err := client.SAdd(ctx, "updates:file_index:file-5", "slice-3").Err(); err != nil {
+		t.Fatalf("SAdd failed: %v", err)
+	}
+	if err := rs.DeleteSlice(ctx, "slice-3"); err != nil {
+		t.Fatalf("DeleteSlice failed: %v", err)
+	}
+	if owners, err := rs.GetActiveSlicesForFile(ctx, "file-5"); err != nil || len(owners) != 0 {
+		t.Fatalf("expected every index entry naming slice-3 to be dropped: %v %v", err, owners)
+	}
+
+	mr.FlushAll()
+	if err := rs.RebuildIndexes(ctx); err != nil {
+		t.Fatalf("RebuildIndexes failed: %v", err)
+	}
+
+	if slice, err := rs.GetSlice(ctx, "slice-2"); err != nil || slice.Name != "Beta 2" {
+		t.Fatalf("expected the rename to survive a flush: %v %+v", err, slice)
+	}
+	if _, err := rs.GetSlice(ctx, "slice-3"); !errors.Is(err, ErrSliceNotFound) {
+		t.Fatalf("expected the deletion to survive a flush, got %v", err)
+	}
+	for file, want := range map[string]string{"file-1": "slice-1", "file-2": "", "file-3": "", "file-4": "slice-2"} {
+		owners, err := rs.GetActiveSlicesForFile(ctx, file)
+		if err != nil {
+			t.Fatalf("GetActiveSlicesForFile failed: %v", err)
+		}
+		if got := strings.Join(owners, ","); got != want {
+			t.Fatalf("expected %s owned by %q after a rebuild, got %q", file, want, got)
+		}
+	}
+}
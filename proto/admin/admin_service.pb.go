@@ -134,15 +134,23 @@ func (x *BatchMergeResponse) GetTimestamp() int64 {
 }
 
 type CreateSliceRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SliceId       string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Files         []string               `protobuf:"bytes,4,rep,name=files,proto3" json:"files,omitempty"`
-	Owners        []string               `protobuf:"bytes,5,rep,name=owners,proto3" json:"owners,omitempty"`
-	CreatedBy     string                 `protobuf:"bytes,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	SliceId            string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	Name               string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description        string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Files              []string               `protobuf:"bytes,4,rep,name=files,proto3" json:"files,omitempty"`
+	Owners             []string               `protobuf:"bytes,5,rep,name=owners,proto3" json:"owners,omitempty"`
+	CreatedBy          string                 `protobuf:"bytes,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	RequiredApprovals  int32                  `protobuf:"varint,7,opt,name=required_approvals,json=requiredApprovals,proto3" json:"required_approvals,omitempty"`
+	OwnerApprovalsOnly bool                   `protobuf:"varint,8,opt,name=owner_approvals_only,json=ownerApprovalsOnly,proto3" json:"owner_approvals_only,omitempty"`
+	// Status checks that must succeed before a changeset can merge
+	RequiredChecks []string `protobuf:"bytes,9,rep,name=required_checks,json=requiredChecks,proto3" json:"required_checks,omitempty"`
+	// Path patterns defining the slice: globs ("**" spans directories) or
+	// directory prefixes. Files matching an exclude pattern are left out.
+	IncludePatterns []string `protobuf:"bytes,10,rep,name=include_patterns,json=includePatterns,proto3" json:"include_patterns,omitempty"`
+	ExcludePatterns []string `protobuf:"bytes,11,rep,name=exclude_patterns,json=excludePatterns,proto3" json:"exclude_patterns,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *CreateSliceRequest) Reset() {
@@ -217,10 +225,47 @@ func (x *CreateSliceRequest) GetCreatedBy() string {
 	return ""
 }
 
+func (x *CreateSliceRequest) GetRequiredApprovals() int32 {
+	if x != nil {
+		return x.RequiredApprovals
+	}
+	return 0
+}
+
+func (x *CreateSliceRequest) GetOwnerApprovalsOnly() bool {
+	if x != nil {
+		return x.OwnerApprovalsOnly
+	}
+	return false
+}
+
+func (x *CreateSliceRequest) GetRequiredChecks() []string {
+	if x != nil {
+		return x.RequiredChecks
+	}
+	return nil
+}
+
+func (x *CreateSliceRequest) GetIncludePatterns() []string {
+	if x != nil {
+		return x.IncludePatterns
+	}
+	return nil
+}
+
+func (x *CreateSliceRequest) GetExcludePatterns() []string {
+	if x != nil {
+		return x.ExcludePatterns
+	}
+	return nil
+}
+
 type CreateSliceResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SliceId       string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	SliceId string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	Status  string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Existing slices that can claim the same paths as the new one
+	Overlaps      []*SliceOverlap `protobuf:"bytes,3,rep,name=overlaps,proto3" json:"overlaps,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -269,6 +314,74 @@ func (x *CreateSliceResponse) GetStatus() string {
 	return ""
 }
 
+func (x *CreateSliceResponse) GetOverlaps() []*SliceOverlap {
+	if x != nil {
+		return x.Overlaps
+	}
+	return nil
+}
+
+// A pair of patterns through which two slices can claim the same paths
+type SliceOverlap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SliceId       string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	Pattern       string                 `protobuf:"bytes,2,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	OtherPattern  string                 `protobuf:"bytes,3,opt,name=other_pattern,json=otherPattern,proto3" json:"other_pattern,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SliceOverlap) Reset() {
+	*x = SliceOverlap{}
+	mi := &file_admin_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SliceOverlap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SliceOverlap) ProtoMessage() {}
+
+func (x *SliceOverlap) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SliceOverlap.ProtoReflect.Descriptor instead.
+func (*SliceOverlap) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SliceOverlap) GetSliceId() string {
+	if x != nil {
+		return x.SliceId
+	}
+	return ""
+}
+
+func (x *SliceOverlap) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *SliceOverlap) GetOtherPattern() string {
+	if x != nil {
+		return x.OtherPattern
+	}
+	return ""
+}
+
 type ListSlicesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
@@ -279,7 +392,7 @@ type ListSlicesRequest struct {
 
 func (x *ListSlicesRequest) Reset() {
 	*x = ListSlicesRequest{}
-	mi := &file_admin_service_proto_msgTypes[4]
+	mi := &file_admin_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -291,7 +404,7 @@ func (x *ListSlicesRequest) String() string {
 func (*ListSlicesRequest) ProtoMessage() {}
 
 func (x *ListSlicesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_service_proto_msgTypes[4]
+	mi := &file_admin_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -304,7 +417,7 @@ func (x *ListSlicesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSlicesRequest.ProtoReflect.Descriptor instead.
 func (*ListSlicesRequest) Descriptor() ([]byte, []int) {
-	return file_admin_service_proto_rawDescGZIP(), []int{4}
+	return file_admin_service_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ListSlicesRequest) GetLimit() int32 {
@@ -330,7 +443,7 @@ type ListSlicesResponse struct {
 
 func (x *ListSlicesResponse) Reset() {
 	*x = ListSlicesResponse{}
-	mi := &file_admin_service_proto_msgTypes[5]
+	mi := &file_admin_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -342,7 +455,7 @@ func (x *ListSlicesResponse) String() string {
 func (*ListSlicesResponse) ProtoMessage() {}
 
 func (x *ListSlicesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_service_proto_msgTypes[5]
+	mi := &file_admin_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -355,7 +468,7 @@ func (x *ListSlicesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSlicesResponse.ProtoReflect.Descriptor instead.
 func (*ListSlicesResponse) Descriptor() ([]byte, []int) {
-	return file_admin_service_proto_rawDescGZIP(), []int{5}
+	return file_admin_service_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListSlicesResponse) GetSlices() []*SliceInfo {
@@ -371,13 +484,14 @@ type SliceInfo struct {
 	LatestCommitHash   string                 `protobuf:"bytes,2,opt,name=latest_commit_hash,json=latestCommitHash,proto3" json:"latest_commit_hash,omitempty"`
 	ModifiedFilesCount int32                  `protobuf:"varint,3,opt,name=modified_files_count,json=modifiedFilesCount,proto3" json:"modified_files_count,omitempty"`
 	LastModified       int64                  `protobuf:"varint,4,opt,name=last_modified,json=lastModified,proto3" json:"last_modified,omitempty"`
+	Archived           bool                   `protobuf:"varint,5,opt,name=archived,proto3" json:"archived,omitempty"`
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
 
 func (x *SliceInfo) Reset() {
 	*x = SliceInfo{}
-	mi := &file_admin_service_proto_msgTypes[6]
+	mi := &file_admin_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -389,7 +503,7 @@ func (x *SliceInfo) String() string {
 func (*SliceInfo) ProtoMessage() {}
 
 func (x *SliceInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_service_proto_msgTypes[6]
+	mi := &file_admin_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -402,7 +516,7 @@ func (x *SliceInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SliceInfo.ProtoReflect.Descriptor instead.
 func (*SliceInfo) Descriptor() ([]byte, []int) {
-	return file_admin_service_proto_rawDescGZIP(), []int{6}
+	return file_admin_service_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *SliceInfo) GetSliceId() string {
@@ -433,6 +547,421 @@ func (x *SliceInfo) GetLastModified() int64 {
 	return 0
 }
 
+func (x *SliceInfo) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+// Request to edit a slice. Unset fields are left as they are.
+type UpdateSliceRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	SliceId     string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	Name        *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	AddFiles    []string               `protobuf:"bytes,4,rep,name=add_files,json=addFiles,proto3" json:"add_files,omitempty"`
+	// Removed files pass to the slice's parent, or the root slice
+	RemoveFiles   []string `protobuf:"bytes,5,rep,name=remove_files,json=removeFiles,proto3" json:"remove_files,omitempty"`
+	AddOwners     []string `protobuf:"bytes,6,rep,name=add_owners,json=addOwners,proto3" json:"add_owners,omitempty"`
+	RemoveOwners  []string `protobuf:"bytes,7,rep,name=remove_owners,json=removeOwners,proto3" json:"remove_owners,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSliceRequest) Reset() {
+	*x = UpdateSliceRequest{}
+	mi := &file_admin_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSliceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSliceRequest) ProtoMessage() {}
+
+func (x *UpdateSliceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSliceRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSliceRequest) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateSliceRequest) GetSliceId() string {
+	if x != nil {
+		return x.SliceId
+	}
+	return ""
+}
+
+func (x *UpdateSliceRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateSliceRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateSliceRequest) GetAddFiles() []string {
+	if x != nil {
+		return x.AddFiles
+	}
+	return nil
+}
+
+func (x *UpdateSliceRequest) GetRemoveFiles() []string {
+	if x != nil {
+		return x.RemoveFiles
+	}
+	return nil
+}
+
+func (x *UpdateSliceRequest) GetAddOwners() []string {
+	if x != nil {
+		return x.AddOwners
+	}
+	return nil
+}
+
+func (x *UpdateSliceRequest) GetRemoveOwners() []string {
+	if x != nil {
+		return x.RemoveOwners
+	}
+	return nil
+}
+
+type UpdateSliceResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	SliceId     string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// The slice's files and owners after the update, sorted
+	Files  []string `protobuf:"bytes,4,rep,name=files,proto3" json:"files,omitempty"`
+	Owners []string `protobuf:"bytes,5,rep,name=owners,proto3" json:"owners,omitempty"`
+	// The slice that took over the removed files
+	HeirSliceId   string `protobuf:"bytes,6,opt,name=heir_slice_id,json=heirSliceId,proto3" json:"heir_slice_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSliceResponse) Reset() {
+	*x = UpdateSliceResponse{}
+	mi := &file_admin_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSliceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSliceResponse) ProtoMessage() {}
+
+func (x *UpdateSliceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSliceResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSliceResponse) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateSliceResponse) GetSliceId() string {
+	if x != nil {
+		return x.SliceId
+	}
+	return ""
+}
+
+func (x *UpdateSliceResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateSliceResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateSliceResponse) GetFiles() []string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *UpdateSliceResponse) GetOwners() []string {
+	if x != nil {
+		return x.Owners
+	}
+	return nil
+}
+
+func (x *UpdateSliceResponse) GetHeirSliceId() string {
+	if x != nil {
+		return x.HeirSliceId
+	}
+	return ""
+}
+
+type DeleteSliceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SliceId       string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSliceRequest) Reset() {
+	*x = DeleteSliceRequest{}
+	mi := &file_admin_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSliceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSliceRequest) ProtoMessage() {}
+
+func (x *DeleteSliceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSliceRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSliceRequest) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteSliceRequest) GetSliceId() string {
+	if x != nil {
+		return x.SliceId
+	}
+	return ""
+}
+
+type DeleteSliceResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	SliceId string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	// The parent, or the root slice, that took over the slice's files
+	HeirSliceId     string   `protobuf:"bytes,2,opt,name=heir_slice_id,json=heirSliceId,proto3" json:"heir_slice_id,omitempty"`
+	ReassignedFiles []string `protobuf:"bytes,3,rep,name=reassigned_files,json=reassignedFiles,proto3" json:"reassigned_files,omitempty"`
+	// Child slices moved under the heir
+	ReparentedSlices []string `protobuf:"bytes,4,rep,name=reparented_slices,json=reparentedSlices,proto3" json:"reparented_slices,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DeleteSliceResponse) Reset() {
+	*x = DeleteSliceResponse{}
+	mi := &file_admin_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSliceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSliceResponse) ProtoMessage() {}
+
+func (x *DeleteSliceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSliceResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSliceResponse) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteSliceResponse) GetSliceId() string {
+	if x != nil {
+		return x.SliceId
+	}
+	return ""
+}
+
+func (x *DeleteSliceResponse) GetHeirSliceId() string {
+	if x != nil {
+		return x.HeirSliceId
+	}
+	return ""
+}
+
+func (x *DeleteSliceResponse) GetReassignedFiles() []string {
+	if x != nil {
+		return x.ReassignedFiles
+	}
+	return nil
+}
+
+func (x *DeleteSliceResponse) GetReparentedSlices() []string {
+	if x != nil {
+		return x.ReparentedSlices
+	}
+	return nil
+}
+
+type ArchiveSliceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SliceId       string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchiveSliceRequest) Reset() {
+	*x = ArchiveSliceRequest{}
+	mi := &file_admin_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveSliceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveSliceRequest) ProtoMessage() {}
+
+func (x *ArchiveSliceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveSliceRequest.ProtoReflect.Descriptor instead.
+func (*ArchiveSliceRequest) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ArchiveSliceRequest) GetSliceId() string {
+	if x != nil {
+		return x.SliceId
+	}
+	return ""
+}
+
+type ArchiveSliceResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	SliceId string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	// The parent, or the root slice, that took over the slice's files
+	HeirSliceId     string   `protobuf:"bytes,2,opt,name=heir_slice_id,json=heirSliceId,proto3" json:"heir_slice_id,omitempty"`
+	ReassignedFiles []string `protobuf:"bytes,3,rep,name=reassigned_files,json=reassignedFiles,proto3" json:"reassigned_files,omitempty"`
+	// Child slices moved under the heir
+	ReparentedSlices []string `protobuf:"bytes,4,rep,name=reparented_slices,json=reparentedSlices,proto3" json:"reparented_slices,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ArchiveSliceResponse) Reset() {
+	*x = ArchiveSliceResponse{}
+	mi := &file_admin_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveSliceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveSliceResponse) ProtoMessage() {}
+
+func (x *ArchiveSliceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveSliceResponse.ProtoReflect.Descriptor instead.
+func (*ArchiveSliceResponse) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ArchiveSliceResponse) GetSliceId() string {
+	if x != nil {
+		return x.SliceId
+	}
+	return ""
+}
+
+func (x *ArchiveSliceResponse) GetHeirSliceId() string {
+	if x != nil {
+		return x.HeirSliceId
+	}
+	return ""
+}
+
+func (x *ArchiveSliceResponse) GetReassignedFiles() []string {
+	if x != nil {
+		return x.ReassignedFiles
+	}
+	return nil
+}
+
+func (x *ArchiveSliceResponse) GetReparentedSlices() []string {
+	if x != nil {
+		return x.ReparentedSlices
+	}
+	return nil
+}
+
 type ConflictsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SliceId       string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
@@ -442,7 +971,7 @@ type ConflictsRequest struct {
 
 func (x *ConflictsRequest) Reset() {
 	*x = ConflictsRequest{}
-	mi := &file_admin_service_proto_msgTypes[7]
+	mi := &file_admin_service_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -454,7 +983,7 @@ func (x *ConflictsRequest) String() string {
 func (*ConflictsRequest) ProtoMessage() {}
 
 func (x *ConflictsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_service_proto_msgTypes[7]
+	mi := &file_admin_service_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -467,7 +996,7 @@ func (x *ConflictsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConflictsRequest.ProtoReflect.Descriptor instead.
 func (*ConflictsRequest) Descriptor() ([]byte, []int) {
-	return file_admin_service_proto_rawDescGZIP(), []int{7}
+	return file_admin_service_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ConflictsRequest) GetSliceId() string {
@@ -487,7 +1016,7 @@ type ConflictsResponse struct {
 
 func (x *ConflictsResponse) Reset() {
 	*x = ConflictsResponse{}
-	mi := &file_admin_service_proto_msgTypes[8]
+	mi := &file_admin_service_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -499,7 +1028,7 @@ func (x *ConflictsResponse) String() string {
 func (*ConflictsResponse) ProtoMessage() {}
 
 func (x *ConflictsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_service_proto_msgTypes[8]
+	mi := &file_admin_service_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -512,7 +1041,7 @@ func (x *ConflictsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConflictsResponse.ProtoReflect.Descriptor instead.
 func (*ConflictsResponse) Descriptor() ([]byte, []int) {
-	return file_admin_service_proto_rawDescGZIP(), []int{8}
+	return file_admin_service_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ConflictsResponse) GetConflicts() []*Conflict {
@@ -539,7 +1068,7 @@ type ResolveConflictRequest struct {
 
 func (x *ResolveConflictRequest) Reset() {
 	*x = ResolveConflictRequest{}
-	mi := &file_admin_service_proto_msgTypes[9]
+	mi := &file_admin_service_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -551,7 +1080,7 @@ func (x *ResolveConflictRequest) String() string {
 func (*ResolveConflictRequest) ProtoMessage() {}
 
 func (x *ResolveConflictRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_service_proto_msgTypes[9]
+	mi := &file_admin_service_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -564,7 +1093,7 @@ func (x *ResolveConflictRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResolveConflictRequest.ProtoReflect.Descriptor instead.
 func (*ResolveConflictRequest) Descriptor() ([]byte, []int) {
-	return file_admin_service_proto_rawDescGZIP(), []int{9}
+	return file_admin_service_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *ResolveConflictRequest) GetFileId() string {
@@ -590,7 +1119,7 @@ type ResolveConflictResponse struct {
 
 func (x *ResolveConflictResponse) Reset() {
 	*x = ResolveConflictResponse{}
-	mi := &file_admin_service_proto_msgTypes[10]
+	mi := &file_admin_service_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -602,7 +1131,7 @@ func (x *ResolveConflictResponse) String() string {
 func (*ResolveConflictResponse) ProtoMessage() {}
 
 func (x *ResolveConflictResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_service_proto_msgTypes[10]
+	mi := &file_admin_service_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -615,7 +1144,7 @@ func (x *ResolveConflictResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResolveConflictResponse.ProtoReflect.Descriptor instead.
 func (*ResolveConflictResponse) Descriptor() ([]byte, []int) {
-	return file_admin_service_proto_rawDescGZIP(), []int{10}
+	return file_admin_service_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ResolveConflictResponse) GetResolvedConflict() *Conflict {
@@ -635,7 +1164,7 @@ type Conflict struct {
 
 func (x *Conflict) Reset() {
 	*x = Conflict{}
-	mi := &file_admin_service_proto_msgTypes[11]
+	mi := &file_admin_service_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -647,7 +1176,7 @@ func (x *Conflict) String() string {
 func (*Conflict) ProtoMessage() {}
 
 func (x *Conflict) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_service_proto_msgTypes[11]
+	mi := &file_admin_service_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -660,7 +1189,7 @@ func (x *Conflict) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Conflict.ProtoReflect.Descriptor instead.
 func (*Conflict) Descriptor() ([]byte, []int) {
-	return file_admin_service_proto_rawDescGZIP(), []int{11}
+	return file_admin_service_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *Conflict) GetFileId() string {
@@ -686,7 +1215,7 @@ type GlobalStateRequest struct {
 
 func (x *GlobalStateRequest) Reset() {
 	*x = GlobalStateRequest{}
-	mi := &file_admin_service_proto_msgTypes[12]
+	mi := &file_admin_service_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -698,7 +1227,7 @@ func (x *GlobalStateRequest) String() string {
 func (*GlobalStateRequest) ProtoMessage() {}
 
 func (x *GlobalStateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_service_proto_msgTypes[12]
+	mi := &file_admin_service_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -711,7 +1240,7 @@ func (x *GlobalStateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GlobalStateRequest.ProtoReflect.Descriptor instead.
 func (*GlobalStateRequest) Descriptor() ([]byte, []int) {
-	return file_admin_service_proto_rawDescGZIP(), []int{12}
+	return file_admin_service_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *GlobalStateRequest) GetIncludeHistory() bool {
@@ -732,7 +1261,7 @@ type GlobalStateResponse struct {
 
 func (x *GlobalStateResponse) Reset() {
 	*x = GlobalStateResponse{}
-	mi := &file_admin_service_proto_msgTypes[13]
+	mi := &file_admin_service_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -744,7 +1273,7 @@ func (x *GlobalStateResponse) String() string {
 func (*GlobalStateResponse) ProtoMessage() {}
 
 func (x *GlobalStateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_service_proto_msgTypes[13]
+	mi := &file_admin_service_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -757,7 +1286,7 @@ func (x *GlobalStateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GlobalStateResponse.ProtoReflect.Descriptor instead.
 func (*GlobalStateResponse) Descriptor() ([]byte, []int) {
-	return file_admin_service_proto_rawDescGZIP(), []int{13}
+	return file_admin_service_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *GlobalStateResponse) GetGlobalCommitHash() string {
@@ -792,7 +1321,7 @@ type GlobalCommitHistory struct {
 
 func (x *GlobalCommitHistory) Reset() {
 	*x = GlobalCommitHistory{}
-	mi := &file_admin_service_proto_msgTypes[14]
+	mi := &file_admin_service_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -804,7 +1333,7 @@ func (x *GlobalCommitHistory) String() string {
 func (*GlobalCommitHistory) ProtoMessage() {}
 
 func (x *GlobalCommitHistory) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_service_proto_msgTypes[14]
+	mi := &file_admin_service_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -817,7 +1346,7 @@ func (x *GlobalCommitHistory) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GlobalCommitHistory.ProtoReflect.Descriptor instead.
 func (*GlobalCommitHistory) Descriptor() ([]byte, []int) {
-	return file_admin_service_proto_rawDescGZIP(), []int{14}
+	return file_admin_service_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *GlobalCommitHistory) GetCommitHash() string {
@@ -850,7 +1379,7 @@ type WatchConflictsRequest struct {
 
 func (x *WatchConflictsRequest) Reset() {
 	*x = WatchConflictsRequest{}
-	mi := &file_admin_service_proto_msgTypes[15]
+	mi := &file_admin_service_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -862,7 +1391,7 @@ func (x *WatchConflictsRequest) String() string {
 func (*WatchConflictsRequest) ProtoMessage() {}
 
 func (x *WatchConflictsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_service_proto_msgTypes[15]
+	mi := &file_admin_service_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -875,7 +1404,7 @@ func (x *WatchConflictsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WatchConflictsRequest.ProtoReflect.Descriptor instead.
 func (*WatchConflictsRequest) Descriptor() ([]byte, []int) {
-	return file_admin_service_proto_rawDescGZIP(), []int{15}
+	return file_admin_service_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *WatchConflictsRequest) GetSliceId() string {
@@ -895,7 +1424,7 @@ type ConflictUpdate struct {
 
 func (x *ConflictUpdate) Reset() {
 	*x = ConflictUpdate{}
-	mi := &file_admin_service_proto_msgTypes[16]
+	mi := &file_admin_service_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -907,7 +1436,7 @@ func (x *ConflictUpdate) String() string {
 func (*ConflictUpdate) ProtoMessage() {}
 
 func (x *ConflictUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_service_proto_msgTypes[16]
+	mi := &file_admin_service_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -920,7 +1449,7 @@ func (x *ConflictUpdate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConflictUpdate.ProtoReflect.Descriptor instead.
 func (*ConflictUpdate) Descriptor() ([]byte, []int) {
-	return file_admin_service_proto_rawDescGZIP(), []int{16}
+	return file_admin_service_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *ConflictUpdate) GetNewConflicts() []*Conflict {
@@ -949,7 +1478,7 @@ const file_admin_service_proto_rawDesc = "" +
 	"\x12global_commit_hash\x18\x01 \x01(\tR\x10globalCommitHash\x12,\n" +
 	"\x12merged_slice_count\x18\x02 \x01(\x05R\x10mergedSliceCount\x12(\n" +
 	"\x10merged_slice_ids\x18\x03 \x03(\tR\x0emergedSliceIds\x12\x1c\n" +
-	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\"\xb2\x01\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\"\x92\x03\n" +
 	"\x12CreateSliceRequest\x12\x19\n" +
 	"\bslice_id\x18\x01 \x01(\tR\asliceId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -957,20 +1486,64 @@ const file_admin_service_proto_rawDesc = "" +
 	"\x05files\x18\x04 \x03(\tR\x05files\x12\x16\n" +
 	"\x06owners\x18\x05 \x03(\tR\x06owners\x12\x1d\n" +
 	"\n" +
-	"created_by\x18\x06 \x01(\tR\tcreatedBy\"H\n" +
+	"created_by\x18\x06 \x01(\tR\tcreatedBy\x12-\n" +
+	"\x12required_approvals\x18\a \x01(\x05R\x11requiredApprovals\x120\n" +
+	"\x14owner_approvals_only\x18\b \x01(\bR\x12ownerApprovalsOnly\x12'\n" +
+	"\x0frequired_checks\x18\t \x03(\tR\x0erequiredChecks\x12)\n" +
+	"\x10include_patterns\x18\n" +
+	" \x03(\tR\x0fincludePatterns\x12)\n" +
+	"\x10exclude_patterns\x18\v \x03(\tR\x0fexcludePatterns\"|\n" +
 	"\x13CreateSliceResponse\x12\x19\n" +
 	"\bslice_id\x18\x01 \x01(\tR\asliceId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\"A\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x122\n" +
+	"\boverlaps\x18\x03 \x03(\v2\x16.admin.v1.SliceOverlapR\boverlaps\"h\n" +
+	"\fSliceOverlap\x12\x19\n" +
+	"\bslice_id\x18\x01 \x01(\tR\asliceId\x12\x18\n" +
+	"\apattern\x18\x02 \x01(\tR\apattern\x12#\n" +
+	"\rother_pattern\x18\x03 \x01(\tR\fotherPattern\"A\n" +
 	"\x11ListSlicesRequest\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
 	"\x06offset\x18\x02 \x01(\x05R\x06offset\"A\n" +
 	"\x12ListSlicesResponse\x12+\n" +
-	"\x06slices\x18\x01 \x03(\v2\x13.admin.v1.SliceInfoR\x06slices\"\xab\x01\n" +
+	"\x06slices\x18\x01 \x03(\v2\x13.admin.v1.SliceInfoR\x06slices\"\xc7\x01\n" +
 	"\tSliceInfo\x12\x19\n" +
 	"\bslice_id\x18\x01 \x01(\tR\asliceId\x12,\n" +
 	"\x12latest_commit_hash\x18\x02 \x01(\tR\x10latestCommitHash\x120\n" +
 	"\x14modified_files_count\x18\x03 \x01(\x05R\x12modifiedFilesCount\x12#\n" +
-	"\rlast_modified\x18\x04 \x01(\x03R\flastModified\"-\n" +
+	"\rlast_modified\x18\x04 \x01(\x03R\flastModified\x12\x1a\n" +
+	"\barchived\x18\x05 \x01(\bR\barchived\"\x8c\x02\n" +
+	"\x12UpdateSliceRequest\x12\x19\n" +
+	"\bslice_id\x18\x01 \x01(\tR\asliceId\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x1b\n" +
+	"\tadd_files\x18\x04 \x03(\tR\baddFiles\x12!\n" +
+	"\fremove_files\x18\x05 \x03(\tR\vremoveFiles\x12\x1d\n" +
+	"\n" +
+	"add_owners\x18\x06 \x03(\tR\taddOwners\x12#\n" +
+	"\rremove_owners\x18\a \x03(\tR\fremoveOwnersB\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_description\"\xb8\x01\n" +
+	"\x13UpdateSliceResponse\x12\x19\n" +
+	"\bslice_id\x18\x01 \x01(\tR\asliceId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05files\x18\x04 \x03(\tR\x05files\x12\x16\n" +
+	"\x06owners\x18\x05 \x03(\tR\x06owners\x12\"\n" +
+	"\rheir_slice_id\x18\x06 \x01(\tR\vheirSliceId\"/\n" +
+	"\x12DeleteSliceRequest\x12\x19\n" +
+	"\bslice_id\x18\x01 \x01(\tR\asliceId\"\xac\x01\n" +
+	"\x13DeleteSliceResponse\x12\x19\n" +
+	"\bslice_id\x18\x01 \x01(\tR\asliceId\x12\"\n" +
+	"\rheir_slice_id\x18\x02 \x01(\tR\vheirSliceId\x12)\n" +
+	"\x10reassigned_files\x18\x03 \x03(\tR\x0freassignedFiles\x12+\n" +
+	"\x11reparented_slices\x18\x04 \x03(\tR\x10reparentedSlices\"0\n" +
+	"\x13ArchiveSliceRequest\x12\x19\n" +
+	"\bslice_id\x18\x01 \x01(\tR\asliceId\"\xad\x01\n" +
+	"\x14ArchiveSliceResponse\x12\x19\n" +
+	"\bslice_id\x18\x01 \x01(\tR\asliceId\x12\"\n" +
+	"\rheir_slice_id\x18\x02 \x01(\tR\vheirSliceId\x12)\n" +
+	"\x10reassigned_files\x18\x03 \x03(\tR\x0freassignedFiles\x12+\n" +
+	"\x11reparented_slices\x18\x04 \x03(\tR\x10reparentedSlices\"-\n" +
 	"\x10ConflictsRequest\x12\x19\n" +
 	"\bslice_id\x18\x01 \x01(\tR\asliceId\"n\n" +
 	"\x11ConflictsResponse\x120\n" +
@@ -999,13 +1572,16 @@ const file_admin_service_proto_rawDesc = "" +
 	"\bslice_id\x18\x01 \x01(\tR\asliceId\"\x8c\x01\n" +
 	"\x0eConflictUpdate\x127\n" +
 	"\rnew_conflicts\x18\x01 \x03(\v2\x12.admin.v1.ConflictR\fnewConflicts\x12A\n" +
-	"\x12resolved_conflicts\x18\x02 \x03(\v2\x12.admin.v1.ConflictR\x11resolvedConflicts2\xab\x04\n" +
+	"\x12resolved_conflicts\x18\x02 \x03(\v2\x12.admin.v1.ConflictR\x11resolvedConflicts2\x92\x06\n" +
 	"\fAdminService\x12G\n" +
 	"\n" +
 	"BatchMerge\x12\x1b.admin.v1.BatchMergeRequest\x1a\x1c.admin.v1.BatchMergeResponse\x12J\n" +
 	"\vCreateSlice\x12\x1c.admin.v1.CreateSliceRequest\x1a\x1d.admin.v1.CreateSliceResponse\x12G\n" +
 	"\n" +
-	"ListSlices\x12\x1b.admin.v1.ListSlicesRequest\x1a\x1c.admin.v1.ListSlicesResponse\x12G\n" +
+	"ListSlices\x12\x1b.admin.v1.ListSlicesRequest\x1a\x1c.admin.v1.ListSlicesResponse\x12J\n" +
+	"\vUpdateSlice\x12\x1c.admin.v1.UpdateSliceRequest\x1a\x1d.admin.v1.UpdateSliceResponse\x12J\n" +
+	"\vDeleteSlice\x12\x1c.admin.v1.DeleteSliceRequest\x1a\x1d.admin.v1.DeleteSliceResponse\x12M\n" +
+	"\fArchiveSlice\x12\x1d.admin.v1.ArchiveSliceRequest\x1a\x1e.admin.v1.ArchiveSliceResponse\x12G\n" +
 	"\fGetConflicts\x12\x1a.admin.v1.ConflictsRequest\x1a\x1b.admin.v1.ConflictsResponse\x12V\n" +
 	"\x0fResolveConflict\x12 .admin.v1.ResolveConflictRequest\x1a!.admin.v1.ResolveConflictResponse\x12M\n" +
 	"\x0eGetGlobalState\x12\x1c.admin.v1.GlobalStateRequest\x1a\x1d.admin.v1.GlobalStateResponse\x12M\n" +
@@ -1023,52 +1599,66 @@ func file_admin_service_proto_rawDescGZIP() []byte {
 	return file_admin_service_proto_rawDescData
 }
 
-var file_admin_service_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_admin_service_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_admin_service_proto_goTypes = []any{
 	(*BatchMergeRequest)(nil),       // 0: admin.v1.BatchMergeRequest
 	(*BatchMergeResponse)(nil),      // 1: admin.v1.BatchMergeResponse
 	(*CreateSliceRequest)(nil),      // 2: admin.v1.CreateSliceRequest
 	(*CreateSliceResponse)(nil),     // 3: admin.v1.CreateSliceResponse
-	(*ListSlicesRequest)(nil),       // 4: admin.v1.ListSlicesRequest
-	(*ListSlicesResponse)(nil),      // 5: admin.v1.ListSlicesResponse
-	(*SliceInfo)(nil),               // 6: admin.v1.SliceInfo
-	(*ConflictsRequest)(nil),        // 7: admin.v1.ConflictsRequest
-	(*ConflictsResponse)(nil),       // 8: admin.v1.ConflictsResponse
-	(*ResolveConflictRequest)(nil),  // 9: admin.v1.ResolveConflictRequest
-	(*ResolveConflictResponse)(nil), // 10: admin.v1.ResolveConflictResponse
-	(*Conflict)(nil),                // 11: admin.v1.Conflict
-	(*GlobalStateRequest)(nil),      // 12: admin.v1.GlobalStateRequest
-	(*GlobalStateResponse)(nil),     // 13: admin.v1.GlobalStateResponse
-	(*GlobalCommitHistory)(nil),     // 14: admin.v1.GlobalCommitHistory
-	(*WatchConflictsRequest)(nil),   // 15: admin.v1.WatchConflictsRequest
-	(*ConflictUpdate)(nil),          // 16: admin.v1.ConflictUpdate
+	(*SliceOverlap)(nil),            // 4: admin.v1.SliceOverlap
+	(*ListSlicesRequest)(nil),       // 5: admin.v1.ListSlicesRequest
+	(*ListSlicesResponse)(nil),      // 6: admin.v1.ListSlicesResponse
+	(*SliceInfo)(nil),               // 7: admin.v1.SliceInfo
+	(*UpdateSliceRequest)(nil),      // 8: admin.v1.UpdateSliceRequest
+	(*UpdateSliceResponse)(nil),     // 9: admin.v1.UpdateSliceResponse
+	(*DeleteSliceRequest)(nil),      // 10: admin.v1.DeleteSliceRequest
+	(*DeleteSliceResponse)(nil),     // 11: admin.v1.DeleteSliceResponse
+	(*ArchiveSliceRequest)(nil),     // 12: admin.v1.ArchiveSliceRequest
+	(*ArchiveSliceResponse)(nil),    // 13: admin.v1.ArchiveSliceResponse
+	(*ConflictsRequest)(nil),        // 14: admin.v1.ConflictsRequest
+	(*ConflictsResponse)(nil),       // 15: admin.v1.ConflictsResponse
+	(*ResolveConflictRequest)(nil),  // 16: admin.v1.ResolveConflictRequest
+	(*ResolveConflictResponse)(nil), // 17: admin.v1.ResolveConflictResponse
+	(*Conflict)(nil),                // 18: admin.v1.Conflict
+	(*GlobalStateRequest)(nil),      // 19: admin.v1.GlobalStateRequest
+	(*GlobalStateResponse)(nil),     // 20: admin.v1.GlobalStateResponse
+	(*GlobalCommitHistory)(nil),     // 21: admin.v1.GlobalCommitHistory
+	(*WatchConflictsRequest)(nil),   // 22: admin.v1.WatchConflictsRequest
+	(*ConflictUpdate)(nil),          // 23: admin.v1.ConflictUpdate
 }
 var file_admin_service_proto_depIdxs = []int32{
-	6,  // 0: admin.v1.ListSlicesResponse.slices:type_name -> admin.v1.SliceInfo
-	11, // 1: admin.v1.ConflictsResponse.conflicts:type_name -> admin.v1.Conflict
-	11, // 2: admin.v1.ResolveConflictResponse.resolved_conflict:type_name -> admin.v1.Conflict
-	14, // 3: admin.v1.GlobalStateResponse.history:type_name -> admin.v1.GlobalCommitHistory
-	11, // 4: admin.v1.ConflictUpdate.new_conflicts:type_name -> admin.v1.Conflict
-	11, // 5: admin.v1.ConflictUpdate.resolved_conflicts:type_name -> admin.v1.Conflict
-	0,  // 6: admin.v1.AdminService.BatchMerge:input_type -> admin.v1.BatchMergeRequest
-	2,  // 7: admin.v1.AdminService.CreateSlice:input_type -> admin.v1.CreateSliceRequest
-	4,  // 8: admin.v1.AdminService.ListSlices:input_type -> admin.v1.ListSlicesRequest
-	7,  // 9: admin.v1.AdminService.GetConflicts:input_type -> admin.v1.ConflictsRequest
-	9,  // 10: admin.v1.AdminService.ResolveConflict:input_type -> admin.v1.ResolveConflictRequest
-	12, // 11: admin.v1.AdminService.GetGlobalState:input_type -> admin.v1.GlobalStateRequest
-	15, // 12: admin.v1.AdminService.WatchConflicts:input_type -> admin.v1.WatchConflictsRequest
-	1,  // 13: admin.v1.AdminService.BatchMerge:output_type -> admin.v1.BatchMergeResponse
-	3,  // 14: admin.v1.AdminService.CreateSlice:output_type -> admin.v1.CreateSliceResponse
-	5,  // 15: admin.v1.AdminService.ListSlices:output_type -> admin.v1.ListSlicesResponse
-	8,  // 16: admin.v1.AdminService.GetConflicts:output_type -> admin.v1.ConflictsResponse
-	10, // 17: admin.v1.AdminService.ResolveConflict:output_type -> admin.v1.ResolveConflictResponse
-	13, // 18: admin.v1.AdminService.GetGlobalState:output_type -> admin.v1.GlobalStateResponse
-	16, // 19: admin.v1.AdminService.WatchConflicts:output_type -> admin.v1.ConflictUpdate
-	13, // [13:20] is the sub-list for method output_type
-	6,  // [6:13] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	4,  // 0: admin.v1.CreateSliceResponse.overlaps:type_name -> admin.v1.SliceOverlap
+	7,  // 1: admin.v1.ListSlicesResponse.slices:type_name -> admin.v1.SliceInfo
+	18, // 2: admin.v1.ConflictsResponse.conflicts:type_name -> admin.v1.Conflict
+	18, // 3: admin.v1.ResolveConflictResponse.resolved_conflict:type_name -> admin.v1.Conflict
+	21, // 4: admin.v1.GlobalStateResponse.history:type_name -> admin.v1.GlobalCommitHistory
+	18, // 5: admin.v1.ConflictUpdate.new_conflicts:type_name -> admin.v1.Conflict
+	18, // 6: admin.v1.ConflictUpdate.resolved_conflicts:type_name -> admin.v1.Conflict
+	0,  // 7: admin.v1.AdminService.BatchMerge:input_type -> admin.v1.BatchMergeRequest
+	2,  // 8: admin.v1.AdminService.CreateSlice:input_type -> admin.v1.CreateSliceRequest
+	5,  // 9: admin.v1.AdminService.ListSlices:input_type -> admin.v1.ListSlicesRequest
+	8,  // 10: admin.v1.AdminService.UpdateSlice:input_type -> admin.v1.UpdateSliceRequest
+	10, // 11: admin.v1.AdminService.DeleteSlice:input_type -> admin.v1.DeleteSliceRequest
+	12, // 12: admin.v1.AdminService.ArchiveSlice:input_type -> admin.v1.ArchiveSliceRequest
+	14, // 13: admin.v1.AdminService.GetConflicts:input_type -> admin.v1.ConflictsRequest
+	16, // 14: admin.v1.AdminService.ResolveConflict:input_type -> admin.v1.ResolveConflictRequest
+	19, // 15: admin.v1.AdminService.GetGlobalState:input_type -> admin.v1.GlobalStateRequest
+	22, // 16: admin.v1.AdminService.WatchConflicts:input_type -> admin.v1.WatchConflictsRequest
+	1,  // 17: admin.v1.AdminService.BatchMerge:output_type -> admin.v1.BatchMergeResponse
+	3,  // 18: admin.v1.AdminService.CreateSlice:output_type -> admin.v1.CreateSliceResponse
+	6,  // 19: admin.v1.AdminService.ListSlices:output_type -> admin.v1.ListSlicesResponse
+	9,  // 20: admin.v1.AdminService.UpdateSlice:output_type -> admin.v1.UpdateSliceResponse
+	11, // 21: admin.v1.AdminService.DeleteSlice:output_type -> admin.v1.DeleteSliceResponse
+	13, // 22: admin.v1.AdminService.ArchiveSlice:output_type -> admin.v1.ArchiveSliceResponse
+	15, // 23: admin.v1.AdminService.GetConflicts:output_type -> admin.v1.ConflictsResponse
+	17, // 24: admin.v1.AdminService.ResolveConflict:output_type -> admin.v1.ResolveConflictResponse
+	20, // 25: admin.v1.AdminService.GetGlobalState:output_type -> admin.v1.GlobalStateResponse
+	23, // 26: admin.v1.AdminService.WatchConflicts:output_type -> admin.v1.ConflictUpdate
+	17, // [17:27] is the sub-list for method output_type
+	7,  // [7:17] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_admin_service_proto_init() }
@@ -1076,13 +1666,14 @@ func file_admin_service_proto_init() {
 	if File_admin_service_proto != nil {
 		return
 	}
+	file_admin_service_proto_msgTypes[8].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_admin_service_proto_rawDesc), len(file_admin_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
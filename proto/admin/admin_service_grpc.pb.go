@@ -22,6 +22,9 @@ const (
 	AdminService_BatchMerge_FullMethodName      = "/admin.v1.AdminService/BatchMerge"
 	AdminService_CreateSlice_FullMethodName     = "/admin.v1.AdminService/CreateSlice"
 	AdminService_ListSlices_FullMethodName      = "/admin.v1.AdminService/ListSlices"
+	AdminService_UpdateSlice_FullMethodName     = "/admin.v1.AdminService/UpdateSlice"
+	AdminService_DeleteSlice_FullMethodName     = "/admin.v1.AdminService/DeleteSlice"
+	AdminService_ArchiveSlice_FullMethodName    = "/admin.v1.AdminService/ArchiveSlice"
 	AdminService_GetConflicts_FullMethodName    = "/admin.v1.AdminService/GetConflicts"
 	AdminService_ResolveConflict_FullMethodName = "/admin.v1.AdminService/ResolveConflict"
 	AdminService_GetGlobalState_FullMethodName  = "/admin.v1.AdminService/GetGlobalState"
@@ -38,6 +41,13 @@ type AdminServiceClient interface {
 	CreateSlice(ctx context.Context, in *CreateSliceRequest, opts ...grpc.CallOption) (*CreateSliceResponse, error)
 	// List all active slices
 	ListSlices(ctx context.Context, in *ListSlicesRequest, opts ...grpc.CallOption) (*ListSlicesResponse, error)
+	// Rename a slice, edit its description, or add and remove files and owners
+	UpdateSlice(ctx context.Context, in *UpdateSliceRequest, opts ...grpc.CallOption) (*UpdateSliceResponse, error)
+	// Delete a slice, handing its files to its parent or the root slice
+	DeleteSlice(ctx context.Context, in *DeleteSliceRequest, opts ...grpc.CallOption) (*DeleteSliceResponse, error)
+	// Archive a slice, keeping its history but handing its files to its parent
+	// or the root slice
+	ArchiveSlice(ctx context.Context, in *ArchiveSliceRequest, opts ...grpc.CallOption) (*ArchiveSliceResponse, error)
 	// Get current conflicts across slices
 	GetConflicts(ctx context.Context, in *ConflictsRequest, opts ...grpc.CallOption) (*ConflictsResponse, error)
 	// Resolve a conflict by choosing a preferred slice
@@ -83,6 +93,33 @@ func (c *adminServiceClient) ListSlices(ctx context.Context, in *ListSlicesReque
 	return out, nil
 }
 
+func (c *adminServiceClient) UpdateSlice(ctx context.Context, in *UpdateSliceRequest, opts ...grpc.CallOption) (*UpdateSliceResponse, error) {
+	out := new(UpdateSliceResponse)
+	err := c.cc.Invoke(ctx, AdminService_UpdateSlice_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DeleteSlice(ctx context.Context, in *DeleteSliceRequest, opts ...grpc.CallOption) (*DeleteSliceResponse, error) {
+	out := new(DeleteSliceResponse)
+	err := c.cc.Invoke(ctx, AdminService_DeleteSlice_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ArchiveSlice(ctx context.Context, in *ArchiveSliceRequest, opts ...grpc.CallOption) (*ArchiveSliceResponse, error) {
+	out := new(ArchiveSliceResponse)
+	err := c.cc.Invoke(ctx, AdminService_ArchiveSlice_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *adminServiceClient) GetConflicts(ctx context.Context, in *ConflictsRequest, opts ...grpc.CallOption) (*ConflictsResponse, error) {
 	out := new(ConflictsResponse)
 	err := c.cc.Invoke(ctx, AdminService_GetConflicts_FullMethodName, in, out, opts...)
@@ -152,6 +189,13 @@ type AdminServiceServer interface {
 	CreateSlice(context.Context, *CreateSliceRequest) (*CreateSliceResponse, error)
 	// List all active slices
 	ListSlices(context.Context, *ListSlicesRequest) (*ListSlicesResponse, error)
+	// Rename a slice, edit its description, or add and remove files and owners
+	UpdateSlice(context.Context, *UpdateSliceRequest) (*UpdateSliceResponse, error)
+	// Delete a slice, handing its files to its parent or the root slice
+	DeleteSlice(context.Context, *DeleteSliceRequest) (*DeleteSliceResponse, error)
+	// Archive a slice, keeping its history but handing its files to its parent
+	// or the root slice
+	ArchiveSlice(context.Context, *ArchiveSliceRequest) (*ArchiveSliceResponse, error)
 	// Get current conflicts across slices
 	GetConflicts(context.Context, *ConflictsRequest) (*ConflictsResponse, error)
 	// Resolve a conflict by choosing a preferred slice
@@ -176,6 +220,15 @@ func (UnimplementedAdminServiceServer) CreateSlice(context.Context, *CreateSlice
 func (UnimplementedAdminServiceServer) ListSlices(context.Context, *ListSlicesRequest) (*ListSlicesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListSlices not implemented")
 }
+func (UnimplementedAdminServiceServer) UpdateSlice(context.Context, *UpdateSliceRequest) (*UpdateSliceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSlice not implemented")
+}
+func (UnimplementedAdminServiceServer) DeleteSlice(context.Context, *DeleteSliceRequest) (*DeleteSliceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSlice not implemented")
+}
+func (UnimplementedAdminServiceServer) ArchiveSlice(context.Context, *ArchiveSliceRequest) (*ArchiveSliceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveSlice not implemented")
+}
 func (UnimplementedAdminServiceServer) GetConflicts(context.Context, *ConflictsRequest) (*ConflictsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetConflicts not implemented")
 }
@@ -255,6 +308,60 @@ func _AdminService_ListSlices_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_UpdateSlice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSliceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UpdateSlice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_UpdateSlice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UpdateSlice(ctx, req.(*UpdateSliceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DeleteSlice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSliceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DeleteSlice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_DeleteSlice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DeleteSlice(ctx, req.(*DeleteSliceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ArchiveSlice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveSliceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ArchiveSlice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ArchiveSlice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ArchiveSlice(ctx, req.(*ArchiveSliceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AdminService_GetConflicts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ConflictsRequest)
 	if err := dec(in); err != nil {
@@ -349,6 +456,18 @@ var AdminService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListSlices",
 			Handler:    _AdminService_ListSlices_Handler,
 		},
+		{
+			MethodName: "UpdateSlice",
+			Handler:    _AdminService_UpdateSlice_Handler,
+		},
+		{
+			MethodName: "DeleteSlice",
+			Handler:    _AdminService_DeleteSlice_Handler,
+		},
+		{
+			MethodName: "ArchiveSlice",
+			Handler:    _AdminService_ArchiveSlice_Handler,
+		},
 		{
 			MethodName: "GetConflicts",
 			Handler:    _AdminService_GetConflicts_Handler,
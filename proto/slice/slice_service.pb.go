@@ -177,13 +177,115 @@ func (RebaseStatus) EnumDescriptor() ([]byte, []int) {
 	return file_slice_service_proto_rawDescGZIP(), []int{2}
 }
 
+type CheckState int32
+
+const (
+	CheckState_CHECK_STATE_PENDING CheckState = 0
+	CheckState_CHECK_STATE_SUCCESS CheckState = 1
+	CheckState_CHECK_STATE_FAILURE CheckState = 2
+)
+
+// Enum value maps for CheckState.
+var (
+	CheckState_name = map[int32]string{
+		0: "CHECK_STATE_PENDING",
+		1: "CHECK_STATE_SUCCESS",
+		2: "CHECK_STATE_FAILURE",
+	}
+	CheckState_value = map[string]int32{
+		"CHECK_STATE_PENDING": 0,
+		"CHECK_STATE_SUCCESS": 1,
+		"CHECK_STATE_FAILURE": 2,
+	}
+)
+
+func (x CheckState) Enum() *CheckState {
+	p := new(CheckState)
+	*p = x
+	return p
+}
+
+func (x CheckState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CheckState) Descriptor() protoreflect.EnumDescriptor {
+	return file_slice_service_proto_enumTypes[3].Descriptor()
+}
+
+func (CheckState) Type() protoreflect.EnumType {
+	return &file_slice_service_proto_enumTypes[3]
+}
+
+func (x CheckState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CheckState.Descriptor instead.
+func (CheckState) EnumDescriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{3}
+}
+
+type MergeQueueState int32
+
+const (
+	MergeQueueState_MERGE_QUEUE_STATE_QUEUED  MergeQueueState = 0
+	MergeQueueState_MERGE_QUEUE_STATE_MERGING MergeQueueState = 1
+	MergeQueueState_MERGE_QUEUE_STATE_MERGED  MergeQueueState = 2
+	MergeQueueState_MERGE_QUEUE_STATE_FAILED  MergeQueueState = 3
+)
+
+// Enum value maps for MergeQueueState.
+var (
+	MergeQueueState_name = map[int32]string{
+		0: "MERGE_QUEUE_STATE_QUEUED",
+		1: "MERGE_QUEUE_STATE_MERGING",
+		2: "MERGE_QUEUE_STATE_MERGED",
+		3: "MERGE_QUEUE_STATE_FAILED",
+	}
+	MergeQueueState_value = map[string]int32{
+		"MERGE_QUEUE_STATE_QUEUED":  0,
+		"MERGE_QUEUE_STATE_MERGING": 1,
+		"MERGE_QUEUE_STATE_MERGED":  2,
+		"MERGE_QUEUE_STATE_FAILED":  3,
+	}
+)
+
+func (x MergeQueueState) Enum() *MergeQueueState {
+	p := new(MergeQueueState)
+	*p = x
+	return p
+}
+
+func (x MergeQueueState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MergeQueueState) Descriptor() protoreflect.EnumDescriptor {
+	return file_slice_service_proto_enumTypes[4].Descriptor()
+}
+
+func (MergeQueueState) Type() protoreflect.EnumType {
+	return &file_slice_service_proto_enumTypes[4]
+}
+
+func (x MergeQueueState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MergeQueueState.Descriptor instead.
+func (MergeQueueState) EnumDescriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{4}
+}
+
 type ChangesetStatus int32
 
 const (
-	ChangesetStatus_PENDING  ChangesetStatus = 0
-	ChangesetStatus_APPROVED ChangesetStatus = 1
-	ChangesetStatus_REJECTED ChangesetStatus = 2
-	ChangesetStatus_MERGED   ChangesetStatus = 3
+	ChangesetStatus_PENDING   ChangesetStatus = 0
+	ChangesetStatus_APPROVED  ChangesetStatus = 1
+	ChangesetStatus_REJECTED  ChangesetStatus = 2
+	ChangesetStatus_MERGED    ChangesetStatus = 3
+	ChangesetStatus_ABANDONED ChangesetStatus = 4
 )
 
 // Enum value maps for ChangesetStatus.
@@ -193,12 +295,14 @@ var (
 		1: "APPROVED",
 		2: "REJECTED",
 		3: "MERGED",
+		4: "ABANDONED",
 	}
 	ChangesetStatus_value = map[string]int32{
-		"PENDING":  0,
-		"APPROVED": 1,
-		"REJECTED": 2,
-		"MERGED":   3,
+		"PENDING":   0,
+		"APPROVED":  1,
+		"REJECTED":  2,
+		"MERGED":    3,
+		"ABANDONED": 4,
 	}
 )
 
@@ -213,11 +317,11 @@ func (x ChangesetStatus) String() string {
 }
 
 func (ChangesetStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_slice_service_proto_enumTypes[3].Descriptor()
+	return file_slice_service_proto_enumTypes[5].Descriptor()
 }
 
 func (ChangesetStatus) Type() protoreflect.EnumType {
-	return &file_slice_service_proto_enumTypes[3]
+	return &file_slice_service_proto_enumTypes[5]
 }
 
 func (x ChangesetStatus) Number() protoreflect.EnumNumber {
@@ -226,7 +330,56 @@ func (x ChangesetStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ChangesetStatus.Descriptor instead.
 func (ChangesetStatus) EnumDescriptor() ([]byte, []int) {
-	return file_slice_service_proto_rawDescGZIP(), []int{3}
+	return file_slice_service_proto_rawDescGZIP(), []int{5}
+}
+
+type FileChangeType int32
+
+const (
+	FileChangeType_FILE_CHANGE_TYPE_MODIFIED FileChangeType = 0
+	FileChangeType_FILE_CHANGE_TYPE_ADDED    FileChangeType = 1
+	FileChangeType_FILE_CHANGE_TYPE_DELETED  FileChangeType = 2
+)
+
+// Enum value maps for FileChangeType.
+var (
+	FileChangeType_name = map[int32]string{
+		0: "FILE_CHANGE_TYPE_MODIFIED",
+		1: "FILE_CHANGE_TYPE_ADDED",
+		2: "FILE_CHANGE_TYPE_DELETED",
+	}
+	FileChangeType_value = map[string]int32{
+		"FILE_CHANGE_TYPE_MODIFIED": 0,
+		"FILE_CHANGE_TYPE_ADDED":    1,
+		"FILE_CHANGE_TYPE_DELETED":  2,
+	}
+)
+
+func (x FileChangeType) Enum() *FileChangeType {
+	p := new(FileChangeType)
+	*p = x
+	return p
+}
+
+func (x FileChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FileChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_slice_service_proto_enumTypes[6].Descriptor()
+}
+
+func (FileChangeType) Type() protoreflect.EnumType {
+	return &file_slice_service_proto_enumTypes[6]
+}
+
+func (x FileChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FileChangeType.Descriptor instead.
+func (FileChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{6}
 }
 
 type ReviewStatus int32
@@ -262,11 +415,11 @@ func (x ReviewStatus) String() string {
 }
 
 func (ReviewStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_slice_service_proto_enumTypes[4].Descriptor()
+	return file_slice_service_proto_enumTypes[7].Descriptor()
 }
 
 func (ReviewStatus) Type() protoreflect.EnumType {
-	return &file_slice_service_proto_enumTypes[4]
+	return &file_slice_service_proto_enumTypes[7]
 }
 
 func (x ReviewStatus) Number() protoreflect.EnumNumber {
@@ -275,13 +428,16 @@ func (x ReviewStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ReviewStatus.Descriptor instead.
 func (ReviewStatus) EnumDescriptor() ([]byte, []int) {
-	return file_slice_service_proto_rawDescGZIP(), []int{4}
+	return file_slice_service_proto_rawDescGZIP(), []int{7}
 }
 
 type CheckoutRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SliceId       string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
-	CommitHash    string                 `protobuf:"bytes,2,opt,name=commit_hash,json=commitHash,proto3" json:"commit_hash,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	SliceId    string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	CommitHash string                 `protobuf:"bytes,2,opt,name=commit_hash,json=commitHash,proto3" json:"commit_hash,omitempty"`
+	// Return only the manifest, so clients can size a checkout before
+	// fetching file contents
+	ManifestOnly  bool `protobuf:"varint,3,opt,name=manifest_only,json=manifestOnly,proto3" json:"manifest_only,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -330,6 +486,13 @@ func (x *CheckoutRequest) GetCommitHash() string {
 	return ""
 }
 
+func (x *CheckoutRequest) GetManifestOnly() bool {
+	if x != nil {
+		return x.ManifestOnly
+	}
+	return false
+}
+
 type CheckoutResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Manifest      *SliceManifest         `protobuf:"bytes,1,opt,name=manifest,proto3" json:"manifest,omitempty"`
@@ -652,8 +815,10 @@ type CreateChangesetRequest struct {
 	ModifiedFiles  []string               `protobuf:"bytes,4,rep,name=modified_files,json=modifiedFiles,proto3" json:"modified_files,omitempty"`
 	Author         string                 `protobuf:"bytes,5,opt,name=author,proto3" json:"author,omitempty"`
 	Message        string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// Stack the changeset on another open changeset of the same slice
+	ParentChangesetId string `protobuf:"bytes,7,opt,name=parent_changeset_id,json=parentChangesetId,proto3" json:"parent_changeset_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *CreateChangesetRequest) Reset() {
@@ -728,6 +893,13 @@ func (x *CreateChangesetRequest) GetMessage() string {
 	return ""
 }
 
+func (x *CreateChangesetRequest) GetParentChangesetId() string {
+	if x != nil {
+		return x.ParentChangesetId
+	}
+	return ""
+}
+
 type CreateChangesetResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChangesetId   string                 `protobuf:"bytes,1,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
@@ -871,13 +1043,15 @@ func (*ChangesetChunk_Metadata) isChangesetChunk_Chunk() {}
 func (*ChangesetChunk_Object) isChangesetChunk_Chunk() {}
 
 type ChangesetMetadata struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	SliceId        string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
-	BaseCommitHash string                 `protobuf:"bytes,2,opt,name=base_commit_hash,json=baseCommitHash,proto3" json:"base_commit_hash,omitempty"`
-	Author         string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
-	Message        string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	SliceId           string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	BaseCommitHash    string                 `protobuf:"bytes,2,opt,name=base_commit_hash,json=baseCommitHash,proto3" json:"base_commit_hash,omitempty"`
+	Author            string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Message           string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	ModifiedFiles     []string               `protobuf:"bytes,5,rep,name=modified_files,json=modifiedFiles,proto3" json:"modified_files,omitempty"`
+	ParentChangesetId string                 `protobuf:"bytes,6,opt,name=parent_changeset_id,json=parentChangesetId,proto3" json:"parent_changeset_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *ChangesetMetadata) Reset() {
@@ -938,18 +1112,126 @@ func (x *ChangesetMetadata) GetMessage() string {
 	return ""
 }
 
-type Object struct {
+func (x *ChangesetMetadata) GetModifiedFiles() []string {
+	if x != nil {
+		return x.ModifiedFiles
+	}
+	return nil
+}
+
+func (x *ChangesetMetadata) GetParentChangesetId() string {
+	if x != nil {
+		return x.ParentChangesetId
+	}
+	return ""
+}
+
+type FindMissingObjectsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hashes        []string               `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindMissingObjectsRequest) Reset() {
+	*x = FindMissingObjectsRequest{}
+	mi := &file_slice_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindMissingObjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindMissingObjectsRequest) ProtoMessage() {}
+
+func (x *FindMissingObjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindMissingObjectsRequest.ProtoReflect.Descriptor instead.
+func (*FindMissingObjectsRequest) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FindMissingObjectsRequest) GetHashes() []string {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+type FindMissingObjectsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          ObjectType             `protobuf:"varint,1,opt,name=type,proto3,enum=slice.v1.ObjectType" json:"type,omitempty"`
-	Hash          string                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
-	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	MissingHashes []string               `protobuf:"bytes,1,rep,name=missing_hashes,json=missingHashes,proto3" json:"missing_hashes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindMissingObjectsResponse) Reset() {
+	*x = FindMissingObjectsResponse{}
+	mi := &file_slice_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindMissingObjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindMissingObjectsResponse) ProtoMessage() {}
+
+func (x *FindMissingObjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindMissingObjectsResponse.ProtoReflect.Descriptor instead.
+func (*FindMissingObjectsResponse) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FindMissingObjectsResponse) GetMissingHashes() []string {
+	if x != nil {
+		return x.MissingHashes
+	}
+	return nil
+}
+
+type Object struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  ObjectType             `protobuf:"varint,1,opt,name=type,proto3,enum=slice.v1.ObjectType" json:"type,omitempty"`
+	Hash  string                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	Data  []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// Streamed uploads may split an object into parts that share its type and
+	// hash, up to 64 MiB in all; offset is where this part's data starts
+	// within the object
+	Offset int64 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Set on every part except the last
+	More          bool `protobuf:"varint,5,opt,name=more,proto3" json:"more,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Object) Reset() {
 	*x = Object{}
-	mi := &file_slice_service_proto_msgTypes[10]
+	mi := &file_slice_service_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -961,7 +1243,7 @@ func (x *Object) String() string {
 func (*Object) ProtoMessage() {}
 
 func (x *Object) ProtoReflect() protoreflect.Message {
-	mi := &file_slice_service_proto_msgTypes[10]
+	mi := &file_slice_service_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -974,7 +1256,7 @@ func (x *Object) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Object.ProtoReflect.Descriptor instead.
 func (*Object) Descriptor() ([]byte, []int) {
-	return file_slice_service_proto_rawDescGZIP(), []int{10}
+	return file_slice_service_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Object) GetType() ObjectType {
@@ -998,6 +1280,20 @@ func (x *Object) GetData() []byte {
 	return nil
 }
 
+func (x *Object) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *Object) GetMore() bool {
+	if x != nil {
+		return x.More
+	}
+	return false
+}
+
 type ReviewChangesetRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChangesetId   string                 `protobuf:"bytes,1,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
@@ -1007,7 +1303,7 @@ type ReviewChangesetRequest struct {
 
 func (x *ReviewChangesetRequest) Reset() {
 	*x = ReviewChangesetRequest{}
-	mi := &file_slice_service_proto_msgTypes[11]
+	mi := &file_slice_service_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1019,7 +1315,7 @@ func (x *ReviewChangesetRequest) String() string {
 func (*ReviewChangesetRequest) ProtoMessage() {}
 
 func (x *ReviewChangesetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_slice_service_proto_msgTypes[11]
+	mi := &file_slice_service_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1032,7 +1328,7 @@ func (x *ReviewChangesetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReviewChangesetRequest.ProtoReflect.Descriptor instead.
 func (*ReviewChangesetRequest) Descriptor() ([]byte, []int) {
-	return file_slice_service_proto_rawDescGZIP(), []int{11}
+	return file_slice_service_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ReviewChangesetRequest) GetChangesetId() string {
@@ -1054,7 +1350,7 @@ type ReviewChangesetResponse struct {
 
 func (x *ReviewChangesetResponse) Reset() {
 	*x = ReviewChangesetResponse{}
-	mi := &file_slice_service_proto_msgTypes[12]
+	mi := &file_slice_service_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1066,7 +1362,7 @@ func (x *ReviewChangesetResponse) String() string {
 func (*ReviewChangesetResponse) ProtoMessage() {}
 
 func (x *ReviewChangesetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_slice_service_proto_msgTypes[12]
+	mi := &file_slice_service_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1079,7 +1375,7 @@ func (x *ReviewChangesetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReviewChangesetResponse.ProtoReflect.Descriptor instead.
 func (*ReviewChangesetResponse) Descriptor() ([]byte, []int) {
-	return file_slice_service_proto_rawDescGZIP(), []int{12}
+	return file_slice_service_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ReviewChangesetResponse) GetChangeset() *ChangesetInfo {
@@ -1123,7 +1419,7 @@ type DiffSummary struct {
 
 func (x *DiffSummary) Reset() {
 	*x = DiffSummary{}
-	mi := &file_slice_service_proto_msgTypes[13]
+	mi := &file_slice_service_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1135,7 +1431,7 @@ func (x *DiffSummary) String() string {
 func (*DiffSummary) ProtoMessage() {}
 
 func (x *DiffSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_slice_service_proto_msgTypes[13]
+	mi := &file_slice_service_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1148,7 +1444,7 @@ func (x *DiffSummary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DiffSummary.ProtoReflect.Descriptor instead.
 func (*DiffSummary) Descriptor() ([]byte, []int) {
-	return file_slice_service_proto_rawDescGZIP(), []int{13}
+	return file_slice_service_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *DiffSummary) GetFilesAdded() int32 {
@@ -1186,28 +1482,31 @@ func (x *DiffSummary) GetLinesRemoved() int64 {
 	return 0
 }
 
-type MergeChangesetRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChangesetId   string                 `protobuf:"bytes,1,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
+type ChangesetDiffRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ChangesetId string                 `protobuf:"bytes,1,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
+	// Unchanged lines shown around each change; defaults to 3 when unset, and
+	// 0 shows only the changed lines.
+	ContextLines  *int32 `protobuf:"varint,2,opt,name=context_lines,json=contextLines,proto3,oneof" json:"context_lines,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MergeChangesetRequest) Reset() {
-	*x = MergeChangesetRequest{}
-	mi := &file_slice_service_proto_msgTypes[14]
+func (x *ChangesetDiffRequest) Reset() {
+	*x = ChangesetDiffRequest{}
+	mi := &file_slice_service_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MergeChangesetRequest) String() string {
+func (x *ChangesetDiffRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MergeChangesetRequest) ProtoMessage() {}
+func (*ChangesetDiffRequest) ProtoMessage() {}
 
-func (x *MergeChangesetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_slice_service_proto_msgTypes[14]
+func (x *ChangesetDiffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1218,43 +1517,50 @@ func (x *MergeChangesetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MergeChangesetRequest.ProtoReflect.Descriptor instead.
-func (*MergeChangesetRequest) Descriptor() ([]byte, []int) {
-	return file_slice_service_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ChangesetDiffRequest.ProtoReflect.Descriptor instead.
+func (*ChangesetDiffRequest) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *MergeChangesetRequest) GetChangesetId() string {
+func (x *ChangesetDiffRequest) GetChangesetId() string {
 	if x != nil {
 		return x.ChangesetId
 	}
 	return ""
 }
 
-type MergeChangesetResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        MergeStatus            `protobuf:"varint,1,opt,name=status,proto3,enum=slice.v1.MergeStatus" json:"status,omitempty"`
-	NewCommitHash string                 `protobuf:"bytes,2,opt,name=new_commit_hash,json=newCommitHash,proto3" json:"new_commit_hash,omitempty"`
-	ChangesetId   string                 `protobuf:"bytes,3,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
-	Conflicts     []*Conflict            `protobuf:"bytes,4,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *MergeChangesetResponse) Reset() {
-	*x = MergeChangesetResponse{}
-	mi := &file_slice_service_proto_msgTypes[15]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ChangesetDiffRequest) GetContextLines() int32 {
+	if x != nil && x.ContextLines != nil {
+		return *x.ContextLines
+	}
+	return 0
 }
 
-func (x *MergeChangesetResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+type ChangesetDiffResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ChangesetId    string                 `protobuf:"bytes,1,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
+	BaseCommitHash string                 `protobuf:"bytes,2,opt,name=base_commit_hash,json=baseCommitHash,proto3" json:"base_commit_hash,omitempty"`
+	Summary        *DiffSummary           `protobuf:"bytes,3,opt,name=summary,proto3" json:"summary,omitempty"`
+	Files          []*FileDiff            `protobuf:"bytes,4,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (*MergeChangesetResponse) ProtoMessage() {}
+func (x *ChangesetDiffResponse) Reset() {
+	*x = ChangesetDiffResponse{}
+	mi := &file_slice_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-func (x *MergeChangesetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_slice_service_proto_msgTypes[15]
+func (x *ChangesetDiffResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangesetDiffResponse) ProtoMessage() {}
+
+func (x *ChangesetDiffResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1265,113 +1571,69 @@ func (x *MergeChangesetResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MergeChangesetResponse.ProtoReflect.Descriptor instead.
-func (*MergeChangesetResponse) Descriptor() ([]byte, []int) {
-	return file_slice_service_proto_rawDescGZIP(), []int{15}
-}
-
-func (x *MergeChangesetResponse) GetStatus() MergeStatus {
-	if x != nil {
-		return x.Status
-	}
-	return MergeStatus_MERGE_STATUS_SUCCESS
+// Deprecated: Use ChangesetDiffResponse.ProtoReflect.Descriptor instead.
+func (*ChangesetDiffResponse) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *MergeChangesetResponse) GetNewCommitHash() string {
+func (x *ChangesetDiffResponse) GetChangesetId() string {
 	if x != nil {
-		return x.NewCommitHash
+		return x.ChangesetId
 	}
 	return ""
 }
 
-func (x *MergeChangesetResponse) GetChangesetId() string {
+func (x *ChangesetDiffResponse) GetBaseCommitHash() string {
 	if x != nil {
-		return x.ChangesetId
+		return x.BaseCommitHash
 	}
 	return ""
 }
 
-func (x *MergeChangesetResponse) GetConflicts() []*Conflict {
+func (x *ChangesetDiffResponse) GetSummary() *DiffSummary {
 	if x != nil {
-		return x.Conflicts
+		return x.Summary
 	}
 	return nil
 }
 
-type Conflict struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	FileId              string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
-	ConflictingSliceIds []string               `protobuf:"bytes,2,rep,name=conflicting_slice_ids,json=conflictingSliceIds,proto3" json:"conflicting_slice_ids,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
-}
-
-func (x *Conflict) Reset() {
-	*x = Conflict{}
-	mi := &file_slice_service_proto_msgTypes[16]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *Conflict) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*Conflict) ProtoMessage() {}
-
-func (x *Conflict) ProtoReflect() protoreflect.Message {
-	mi := &file_slice_service_proto_msgTypes[16]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use Conflict.ProtoReflect.Descriptor instead.
-func (*Conflict) Descriptor() ([]byte, []int) {
-	return file_slice_service_proto_rawDescGZIP(), []int{16}
-}
-
-func (x *Conflict) GetFileId() string {
-	if x != nil {
-		return x.FileId
-	}
-	return ""
-}
-
-func (x *Conflict) GetConflictingSliceIds() []string {
+func (x *ChangesetDiffResponse) GetFiles() []*FileDiff {
 	if x != nil {
-		return x.ConflictingSliceIds
+		return x.Files
 	}
 	return nil
 }
 
-type RebaseChangesetRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChangesetId   string                 `protobuf:"bytes,1,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
+type FileDiff struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Path         string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	ChangeType   FileChangeType         `protobuf:"varint,2,opt,name=change_type,json=changeType,proto3,enum=slice.v1.FileChangeType" json:"change_type,omitempty"`
+	LinesAdded   int64                  `protobuf:"varint,3,opt,name=lines_added,json=linesAdded,proto3" json:"lines_added,omitempty"`
+	LinesRemoved int64                  `protobuf:"varint,4,opt,name=lines_removed,json=linesRemoved,proto3" json:"lines_removed,omitempty"`
+	Binary       bool                   `protobuf:"varint,5,opt,name=binary,proto3" json:"binary,omitempty"`
+	Hunks        []*DiffHunk            `protobuf:"bytes,6,rep,name=hunks,proto3" json:"hunks,omitempty"`
+	// Either version is over the server's diff size limit, so no lines were
+	// compared
+	TooLarge      bool `protobuf:"varint,7,opt,name=too_large,json=tooLarge,proto3" json:"too_large,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RebaseChangesetRequest) Reset() {
-	*x = RebaseChangesetRequest{}
-	mi := &file_slice_service_proto_msgTypes[17]
+func (x *FileDiff) Reset() {
+	*x = FileDiff{}
+	mi := &file_slice_service_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RebaseChangesetRequest) String() string {
+func (x *FileDiff) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RebaseChangesetRequest) ProtoMessage() {}
+func (*FileDiff) ProtoMessage() {}
 
-func (x *RebaseChangesetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_slice_service_proto_msgTypes[17]
+func (x *FileDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1382,109 +1644,86 @@ func (x *RebaseChangesetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RebaseChangesetRequest.ProtoReflect.Descriptor instead.
-func (*RebaseChangesetRequest) Descriptor() ([]byte, []int) {
-	return file_slice_service_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use FileDiff.ProtoReflect.Descriptor instead.
+func (*FileDiff) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *RebaseChangesetRequest) GetChangesetId() string {
+func (x *FileDiff) GetPath() string {
 	if x != nil {
-		return x.ChangesetId
+		return x.Path
 	}
 	return ""
 }
 
-type RebaseChangesetResponse struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	Status              RebaseStatus           `protobuf:"varint,1,opt,name=status,proto3,enum=slice.v1.RebaseStatus" json:"status,omitempty"`
-	NewBaseCommitHash   string                 `protobuf:"bytes,2,opt,name=new_base_commit_hash,json=newBaseCommitHash,proto3" json:"new_base_commit_hash,omitempty"`
-	SliceCommitsToApply []string               `protobuf:"bytes,3,rep,name=slice_commits_to_apply,json=sliceCommitsToApply,proto3" json:"slice_commits_to_apply,omitempty"`
-	Conflicts           []*Conflict            `protobuf:"bytes,4,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
-}
-
-func (x *RebaseChangesetResponse) Reset() {
-	*x = RebaseChangesetResponse{}
-	mi := &file_slice_service_proto_msgTypes[18]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *RebaseChangesetResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*RebaseChangesetResponse) ProtoMessage() {}
-
-func (x *RebaseChangesetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_slice_service_proto_msgTypes[18]
+func (x *FileDiff) GetChangeType() FileChangeType {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.ChangeType
 	}
-	return mi.MessageOf(x)
+	return FileChangeType_FILE_CHANGE_TYPE_MODIFIED
 }
 
-// Deprecated: Use RebaseChangesetResponse.ProtoReflect.Descriptor instead.
-func (*RebaseChangesetResponse) Descriptor() ([]byte, []int) {
-	return file_slice_service_proto_rawDescGZIP(), []int{18}
+func (x *FileDiff) GetLinesAdded() int64 {
+	if x != nil {
+		return x.LinesAdded
+	}
+	return 0
 }
 
-func (x *RebaseChangesetResponse) GetStatus() RebaseStatus {
+func (x *FileDiff) GetLinesRemoved() int64 {
 	if x != nil {
-		return x.Status
+		return x.LinesRemoved
 	}
-	return RebaseStatus_REBASE_STATUS_SUCCESS
+	return 0
 }
 
-func (x *RebaseChangesetResponse) GetNewBaseCommitHash() string {
+func (x *FileDiff) GetBinary() bool {
 	if x != nil {
-		return x.NewBaseCommitHash
+		return x.Binary
 	}
-	return ""
+	return false
 }
 
-func (x *RebaseChangesetResponse) GetSliceCommitsToApply() []string {
+func (x *FileDiff) GetHunks() []*DiffHunk {
 	if x != nil {
-		return x.SliceCommitsToApply
+		return x.Hunks
 	}
 	return nil
 }
 
-func (x *RebaseChangesetResponse) GetConflicts() []*Conflict {
+func (x *FileDiff) GetTooLarge() bool {
 	if x != nil {
-		return x.Conflicts
+		return x.TooLarge
 	}
-	return nil
+	return false
 }
 
-type ListChangesetsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SliceId       string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
-	StatusFilter  ChangesetStatus        `protobuf:"varint,2,opt,name=status_filter,json=statusFilter,proto3,enum=slice.v1.ChangesetStatus" json:"status_filter,omitempty"`
-	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+type DiffHunk struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	OldStart int32                  `protobuf:"varint,1,opt,name=old_start,json=oldStart,proto3" json:"old_start,omitempty"`
+	OldLines int32                  `protobuf:"varint,2,opt,name=old_lines,json=oldLines,proto3" json:"old_lines,omitempty"`
+	NewStart int32                  `protobuf:"varint,3,opt,name=new_start,json=newStart,proto3" json:"new_start,omitempty"`
+	NewLines int32                  `protobuf:"varint,4,opt,name=new_lines,json=newLines,proto3" json:"new_lines,omitempty"`
+	// Lines prefixed with ' ', '+' or '-' as in a unified diff.
+	Lines         []string `protobuf:"bytes,5,rep,name=lines,proto3" json:"lines,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListChangesetsRequest) Reset() {
-	*x = ListChangesetsRequest{}
+func (x *DiffHunk) Reset() {
+	*x = DiffHunk{}
 	mi := &file_slice_service_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListChangesetsRequest) String() string {
+func (x *DiffHunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListChangesetsRequest) ProtoMessage() {}
+func (*DiffHunk) ProtoMessage() {}
 
-func (x *ListChangesetsRequest) ProtoReflect() protoreflect.Message {
+func (x *DiffHunk) ProtoReflect() protoreflect.Message {
 	mi := &file_slice_service_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1496,53 +1735,67 @@ func (x *ListChangesetsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListChangesetsRequest.ProtoReflect.Descriptor instead.
-func (*ListChangesetsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DiffHunk.ProtoReflect.Descriptor instead.
+func (*DiffHunk) Descriptor() ([]byte, []int) {
 	return file_slice_service_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *ListChangesetsRequest) GetSliceId() string {
+func (x *DiffHunk) GetOldStart() int32 {
 	if x != nil {
-		return x.SliceId
+		return x.OldStart
 	}
-	return ""
+	return 0
 }
 
-func (x *ListChangesetsRequest) GetStatusFilter() ChangesetStatus {
+func (x *DiffHunk) GetOldLines() int32 {
 	if x != nil {
-		return x.StatusFilter
+		return x.OldLines
 	}
-	return ChangesetStatus_PENDING
+	return 0
 }
 
-func (x *ListChangesetsRequest) GetLimit() int32 {
+func (x *DiffHunk) GetNewStart() int32 {
 	if x != nil {
-		return x.Limit
+		return x.NewStart
 	}
 	return 0
 }
 
-type ListChangesetsResponse struct {
+func (x *DiffHunk) GetNewLines() int32 {
+	if x != nil {
+		return x.NewLines
+	}
+	return 0
+}
+
+func (x *DiffHunk) GetLines() []string {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+type MergeChangesetRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Changesets    []*ChangesetInfo       `protobuf:"bytes,1,rep,name=changesets,proto3" json:"changesets,omitempty"`
+	ChangesetId   string                 `protobuf:"bytes,1,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListChangesetsResponse) Reset() {
-	*x = ListChangesetsResponse{}
+func (x *MergeChangesetRequest) Reset() {
+	*x = MergeChangesetRequest{}
 	mi := &file_slice_service_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListChangesetsResponse) String() string {
+func (x *MergeChangesetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListChangesetsResponse) ProtoMessage() {}
+func (*MergeChangesetRequest) ProtoMessage() {}
 
-func (x *ListChangesetsResponse) ProtoReflect() protoreflect.Message {
+func (x *MergeChangesetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_slice_service_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1554,48 +1807,42 @@ func (x *ListChangesetsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListChangesetsResponse.ProtoReflect.Descriptor instead.
-func (*ListChangesetsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use MergeChangesetRequest.ProtoReflect.Descriptor instead.
+func (*MergeChangesetRequest) Descriptor() ([]byte, []int) {
 	return file_slice_service_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *ListChangesetsResponse) GetChangesets() []*ChangesetInfo {
+func (x *MergeChangesetRequest) GetChangesetId() string {
 	if x != nil {
-		return x.Changesets
+		return x.ChangesetId
 	}
-	return nil
+	return ""
 }
 
-type ChangesetInfo struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	ChangesetId    string                 `protobuf:"bytes,1,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
-	ChangesetHash  string                 `protobuf:"bytes,2,opt,name=changeset_hash,json=changesetHash,proto3" json:"changeset_hash,omitempty"`
-	SliceId        string                 `protobuf:"bytes,3,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
-	BaseCommitHash string                 `protobuf:"bytes,4,opt,name=base_commit_hash,json=baseCommitHash,proto3" json:"base_commit_hash,omitempty"`
-	ModifiedFiles  []string               `protobuf:"bytes,5,rep,name=modified_files,json=modifiedFiles,proto3" json:"modified_files,omitempty"`
-	Status         ChangesetStatus        `protobuf:"varint,6,opt,name=status,proto3,enum=slice.v1.ChangesetStatus" json:"status,omitempty"`
-	Author         string                 `protobuf:"bytes,7,opt,name=author,proto3" json:"author,omitempty"`
-	CreatedAt      int64                  `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	MergedAt       int64                  `protobuf:"varint,9,opt,name=merged_at,json=mergedAt,proto3" json:"merged_at,omitempty"`
-	Message        string                 `protobuf:"bytes,10,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type MergeChangesetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        MergeStatus            `protobuf:"varint,1,opt,name=status,proto3,enum=slice.v1.MergeStatus" json:"status,omitempty"`
+	NewCommitHash string                 `protobuf:"bytes,2,opt,name=new_commit_hash,json=newCommitHash,proto3" json:"new_commit_hash,omitempty"`
+	ChangesetId   string                 `protobuf:"bytes,3,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
+	Conflicts     []*Conflict            `protobuf:"bytes,4,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChangesetInfo) Reset() {
-	*x = ChangesetInfo{}
+func (x *MergeChangesetResponse) Reset() {
+	*x = MergeChangesetResponse{}
 	mi := &file_slice_service_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChangesetInfo) String() string {
+func (x *MergeChangesetResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChangesetInfo) ProtoMessage() {}
+func (*MergeChangesetResponse) ProtoMessage() {}
 
-func (x *ChangesetInfo) ProtoReflect() protoreflect.Message {
+func (x *MergeChangesetResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_slice_service_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1607,105 +1854,2165 @@ func (x *ChangesetInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangesetInfo.ProtoReflect.Descriptor instead.
-func (*ChangesetInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use MergeChangesetResponse.ProtoReflect.Descriptor instead.
+func (*MergeChangesetResponse) Descriptor() ([]byte, []int) {
 	return file_slice_service_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *ChangesetInfo) GetChangesetId() string {
+func (x *MergeChangesetResponse) GetStatus() MergeStatus {
 	if x != nil {
-		return x.ChangesetId
+		return x.Status
 	}
-	return ""
+	return MergeStatus_MERGE_STATUS_SUCCESS
 }
 
-func (x *ChangesetInfo) GetChangesetHash() string {
+func (x *MergeChangesetResponse) GetNewCommitHash() string {
 	if x != nil {
-		return x.ChangesetHash
+		return x.NewCommitHash
 	}
 	return ""
 }
 
-func (x *ChangesetInfo) GetSliceId() string {
+func (x *MergeChangesetResponse) GetChangesetId() string {
 	if x != nil {
-		return x.SliceId
+		return x.ChangesetId
 	}
 	return ""
 }
 
-func (x *ChangesetInfo) GetBaseCommitHash() string {
+func (x *MergeChangesetResponse) GetConflicts() []*Conflict {
 	if x != nil {
-		return x.BaseCommitHash
+		return x.Conflicts
 	}
-	return ""
+	return nil
 }
 
-func (x *ChangesetInfo) GetModifiedFiles() []string {
+type Conflict struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	FileId              string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	ConflictingSliceIds []string               `protobuf:"bytes,2,rep,name=conflicting_slice_ids,json=conflictingSliceIds,proto3" json:"conflicting_slice_ids,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Conflict) Reset() {
+	*x = Conflict{}
+	mi := &file_slice_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Conflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Conflict) ProtoMessage() {}
+
+func (x *Conflict) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Conflict.ProtoReflect.Descriptor instead.
+func (*Conflict) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Conflict) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *Conflict) GetConflictingSliceIds() []string {
+	if x != nil {
+		return x.ConflictingSliceIds
+	}
+	return nil
+}
+
+type RebaseChangesetRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ChangesetId string                 `protobuf:"bytes,1,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
+	// Also rebase every changeset stacked on this one onto its new revision
+	Restack       bool `protobuf:"varint,2,opt,name=restack,proto3" json:"restack,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebaseChangesetRequest) Reset() {
+	*x = RebaseChangesetRequest{}
+	mi := &file_slice_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebaseChangesetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebaseChangesetRequest) ProtoMessage() {}
+
+func (x *RebaseChangesetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebaseChangesetRequest.ProtoReflect.Descriptor instead.
+func (*RebaseChangesetRequest) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RebaseChangesetRequest) GetChangesetId() string {
+	if x != nil {
+		return x.ChangesetId
+	}
+	return ""
+}
+
+func (x *RebaseChangesetRequest) GetRestack() bool {
+	if x != nil {
+		return x.Restack
+	}
+	return false
+}
+
+type RebaseChangesetResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Status              RebaseStatus           `protobuf:"varint,1,opt,name=status,proto3,enum=slice.v1.RebaseStatus" json:"status,omitempty"`
+	NewBaseCommitHash   string                 `protobuf:"bytes,2,opt,name=new_base_commit_hash,json=newBaseCommitHash,proto3" json:"new_base_commit_hash,omitempty"`
+	SliceCommitsToApply []string               `protobuf:"bytes,3,rep,name=slice_commits_to_apply,json=sliceCommitsToApply,proto3" json:"slice_commits_to_apply,omitempty"`
+	Conflicts           []*Conflict            `protobuf:"bytes,4,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	// Conflicted files merged line by line, with conflict markers around the
+	// regions both sides changed
+	ConflictedFiles []*ConflictedFile `protobuf:"bytes,5,rep,name=conflicted_files,json=conflictedFiles,proto3" json:"conflicted_files,omitempty"`
+	// Outcome for each stacked changeset moved by a restack
+	Restacked []*RestackResult `protobuf:"bytes,6,rep,name=restacked,proto3" json:"restacked,omitempty"`
+	// Open changesets stacked directly on this one
+	StackedChangesets []string `protobuf:"bytes,7,rep,name=stacked_changesets,json=stackedChangesets,proto3" json:"stacked_changesets,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RebaseChangesetResponse) Reset() {
+	*x = RebaseChangesetResponse{}
+	mi := &file_slice_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebaseChangesetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebaseChangesetResponse) ProtoMessage() {}
+
+func (x *RebaseChangesetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebaseChangesetResponse.ProtoReflect.Descriptor instead.
+func (*RebaseChangesetResponse) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RebaseChangesetResponse) GetStatus() RebaseStatus {
+	if x != nil {
+		return x.Status
+	}
+	return RebaseStatus_REBASE_STATUS_SUCCESS
+}
+
+func (x *RebaseChangesetResponse) GetNewBaseCommitHash() string {
+	if x != nil {
+		return x.NewBaseCommitHash
+	}
+	return ""
+}
+
+func (x *RebaseChangesetResponse) GetSliceCommitsToApply() []string {
+	if x != nil {
+		return x.SliceCommitsToApply
+	}
+	return nil
+}
+
+func (x *RebaseChangesetResponse) GetConflicts() []*Conflict {
+	if x != nil {
+		return x.Conflicts
+	}
+	return nil
+}
+
+func (x *RebaseChangesetResponse) GetConflictedFiles() []*ConflictedFile {
+	if x != nil {
+		return x.ConflictedFiles
+	}
+	return nil
+}
+
+func (x *RebaseChangesetResponse) GetRestacked() []*RestackResult {
+	if x != nil {
+		return x.Restacked
+	}
+	return nil
+}
+
+func (x *RebaseChangesetResponse) GetStackedChangesets() []string {
+	if x != nil {
+		return x.StackedChangesets
+	}
+	return nil
+}
+
+type RestackResult struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ChangesetId       string                 `protobuf:"bytes,1,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
+	ParentChangesetId string                 `protobuf:"bytes,2,opt,name=parent_changeset_id,json=parentChangesetId,proto3" json:"parent_changeset_id,omitempty"`
+	Status            RebaseStatus           `protobuf:"varint,3,opt,name=status,proto3,enum=slice.v1.RebaseStatus" json:"status,omitempty"`
+	Conflicts         []string               `protobuf:"bytes,4,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RestackResult) Reset() {
+	*x = RestackResult{}
+	mi := &file_slice_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestackResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestackResult) ProtoMessage() {}
+
+func (x *RestackResult) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestackResult.ProtoReflect.Descriptor instead.
+func (*RestackResult) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RestackResult) GetChangesetId() string {
+	if x != nil {
+		return x.ChangesetId
+	}
+	return ""
+}
+
+func (x *RestackResult) GetParentChangesetId() string {
+	if x != nil {
+		return x.ParentChangesetId
+	}
+	return ""
+}
+
+func (x *RestackResult) GetStatus() RebaseStatus {
+	if x != nil {
+		return x.Status
+	}
+	return RebaseStatus_REBASE_STATUS_SUCCESS
+}
+
+func (x *RestackResult) GetConflicts() []string {
+	if x != nil {
+		return x.Conflicts
+	}
+	return nil
+}
+
+type ConflictedFile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Content       []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	ConflictCount int32                  `protobuf:"varint,3,opt,name=conflict_count,json=conflictCount,proto3" json:"conflict_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConflictedFile) Reset() {
+	*x = ConflictedFile{}
+	mi := &file_slice_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConflictedFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConflictedFile) ProtoMessage() {}
+
+func (x *ConflictedFile) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConflictedFile.ProtoReflect.Descriptor instead.
+func (*ConflictedFile) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ConflictedFile) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ConflictedFile) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *ConflictedFile) GetConflictCount() int32 {
+	if x != nil {
+		return x.ConflictCount
+	}
+	return 0
+}
+
+type ListChangesetsRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	SliceId      string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	StatusFilter ChangesetStatus        `protobuf:"varint,2,opt,name=status_filter,json=statusFilter,proto3,enum=slice.v1.ChangesetStatus" json:"status_filter,omitempty"`
+	Limit        int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Unfiltered listings skip abandoned changesets unless this is set
+	IncludeAbandoned bool `protobuf:"varint,4,opt,name=include_abandoned,json=includeAbandoned,proto3" json:"include_abandoned,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListChangesetsRequest) Reset() {
+	*x = ListChangesetsRequest{}
+	mi := &file_slice_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChangesetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChangesetsRequest) ProtoMessage() {}
+
+func (x *ListChangesetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChangesetsRequest.ProtoReflect.Descriptor instead.
+func (*ListChangesetsRequest) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListChangesetsRequest) GetSliceId() string {
+	if x != nil {
+		return x.SliceId
+	}
+	return ""
+}
+
+func (x *ListChangesetsRequest) GetStatusFilter() ChangesetStatus {
+	if x != nil {
+		return x.StatusFilter
+	}
+	return ChangesetStatus_PENDING
+}
+
+func (x *ListChangesetsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListChangesetsRequest) GetIncludeAbandoned() bool {
+	if x != nil {
+		return x.IncludeAbandoned
+	}
+	return false
+}
+
+type ListChangesetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changesets    []*ChangesetInfo       `protobuf:"bytes,1,rep,name=changesets,proto3" json:"changesets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChangesetsResponse) Reset() {
+	*x = ListChangesetsResponse{}
+	mi := &file_slice_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChangesetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChangesetsResponse) ProtoMessage() {}
+
+func (x *ListChangesetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChangesetsResponse.ProtoReflect.Descriptor instead.
+func (*ListChangesetsResponse) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListChangesetsResponse) GetChangesets() []*ChangesetInfo {
+	if x != nil {
+		return x.Changesets
+	}
+	return nil
+}
+
+type ChangesetInfo struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ChangesetId       string                 `protobuf:"bytes,1,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
+	ChangesetHash     string                 `protobuf:"bytes,2,opt,name=changeset_hash,json=changesetHash,proto3" json:"changeset_hash,omitempty"`
+	SliceId           string                 `protobuf:"bytes,3,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	BaseCommitHash    string                 `protobuf:"bytes,4,opt,name=base_commit_hash,json=baseCommitHash,proto3" json:"base_commit_hash,omitempty"`
+	ModifiedFiles     []string               `protobuf:"bytes,5,rep,name=modified_files,json=modifiedFiles,proto3" json:"modified_files,omitempty"`
+	Status            ChangesetStatus        `protobuf:"varint,6,opt,name=status,proto3,enum=slice.v1.ChangesetStatus" json:"status,omitempty"`
+	Author            string                 `protobuf:"bytes,7,opt,name=author,proto3" json:"author,omitempty"`
+	CreatedAt         int64                  `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	MergedAt          int64                  `protobuf:"varint,9,opt,name=merged_at,json=mergedAt,proto3" json:"merged_at,omitempty"`
+	Message           string                 `protobuf:"bytes,10,opt,name=message,proto3" json:"message,omitempty"`
+	Votes             []*ReviewVote          `protobuf:"bytes,11,rep,name=votes,proto3" json:"votes,omitempty"`
+	AbandonReason     string                 `protobuf:"bytes,12,opt,name=abandon_reason,json=abandonReason,proto3" json:"abandon_reason,omitempty"`
+	ParentChangesetId string                 `protobuf:"bytes,13,opt,name=parent_changeset_id,json=parentChangesetId,proto3" json:"parent_changeset_id,omitempty"`
+	Checks            []*StatusCheck         `protobuf:"bytes,14,rep,name=checks,proto3" json:"checks,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ChangesetInfo) Reset() {
+	*x = ChangesetInfo{}
+	mi := &file_slice_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangesetInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangesetInfo) ProtoMessage() {}
+
+func (x *ChangesetInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangesetInfo.ProtoReflect.Descriptor instead.
+func (*ChangesetInfo) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ChangesetInfo) GetChangesetId() string {
+	if x != nil {
+		return x.ChangesetId
+	}
+	return ""
+}
+
+func (x *ChangesetInfo) GetChangesetHash() string {
+	if x != nil {
+		return x.ChangesetHash
+	}
+	return ""
+}
+
+func (x *ChangesetInfo) GetSliceId() string {
+	if x != nil {
+		return x.SliceId
+	}
+	return ""
+}
+
+func (x *ChangesetInfo) GetBaseCommitHash() string {
+	if x != nil {
+		return x.BaseCommitHash
+	}
+	return ""
+}
+
+func (x *ChangesetInfo) GetModifiedFiles() []string {
+	if x != nil {
+		return x.ModifiedFiles
+	}
+	return nil
+}
+
+func (x *ChangesetInfo) GetStatus() ChangesetStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ChangesetStatus_PENDING
+}
+
+func (x *ChangesetInfo) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *ChangesetInfo) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ChangesetInfo) GetMergedAt() int64 {
+	if x != nil {
+		return x.MergedAt
+	}
+	return 0
+}
+
+func (x *ChangesetInfo) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ChangesetInfo) GetVotes() []*ReviewVote {
+	if x != nil {
+		return x.Votes
+	}
+	return nil
+}
+
+func (x *ChangesetInfo) GetAbandonReason() string {
+	if x != nil {
+		return x.AbandonReason
+	}
+	return ""
+}
+
+func (x *ChangesetInfo) GetParentChangesetId() string {
+	if x != nil {
+		return x.ParentChangesetId
+	}
+	return ""
+}
+
+func (x *ChangesetInfo) GetChecks() []*StatusCheck {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+type StatusCheck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	State         CheckState             `protobuf:"varint,2,opt,name=state,proto3,enum=slice.v1.CheckState" json:"state,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	TargetUrl     string                 `protobuf:"bytes,4,opt,name=target_url,json=targetUrl,proto3" json:"target_url,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusCheck) Reset() {
+	*x = StatusCheck{}
+	mi := &file_slice_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusCheck) ProtoMessage() {}
+
+func (x *StatusCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusCheck.ProtoReflect.Descriptor instead.
+func (*StatusCheck) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *StatusCheck) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StatusCheck) GetState() CheckState {
+	if x != nil {
+		return x.State
+	}
+	return CheckState_CHECK_STATE_PENDING
+}
+
+func (x *StatusCheck) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *StatusCheck) GetTargetUrl() string {
+	if x != nil {
+		return x.TargetUrl
+	}
+	return ""
+}
+
+func (x *StatusCheck) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type SetChangesetCheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChangesetId   string                 `protobuf:"bytes,1,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	State         CheckState             `protobuf:"varint,3,opt,name=state,proto3,enum=slice.v1.CheckState" json:"state,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	TargetUrl     string                 `protobuf:"bytes,5,opt,name=target_url,json=targetUrl,proto3" json:"target_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetChangesetCheckRequest) Reset() {
+	*x = SetChangesetCheckRequest{}
+	mi := &file_slice_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetChangesetCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetChangesetCheckRequest) ProtoMessage() {}
+
+func (x *SetChangesetCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetChangesetCheckRequest.ProtoReflect.Descriptor instead.
+func (*SetChangesetCheckRequest) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SetChangesetCheckRequest) GetChangesetId() string {
+	if x != nil {
+		return x.ChangesetId
+	}
+	return ""
+}
+
+func (x *SetChangesetCheckRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetChangesetCheckRequest) GetState() CheckState {
+	if x != nil {
+		return x.State
+	}
+	return CheckState_CHECK_STATE_PENDING
+}
+
+func (x *SetChangesetCheckRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SetChangesetCheckRequest) GetTargetUrl() string {
+	if x != nil {
+		return x.TargetUrl
+	}
+	return ""
+}
+
+type SetChangesetCheckResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Changeset *ChangesetInfo         `protobuf:"bytes,1,opt,name=changeset,proto3" json:"changeset,omitempty"`
+	// Required checks of the changeset's slice that have not succeeded yet
+	PendingRequiredChecks []string `protobuf:"bytes,2,rep,name=pending_required_checks,json=pendingRequiredChecks,proto3" json:"pending_required_checks,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *SetChangesetCheckResponse) Reset() {
+	*x = SetChangesetCheckResponse{}
+	mi := &file_slice_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetChangesetCheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetChangesetCheckResponse) ProtoMessage() {}
+
+func (x *SetChangesetCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetChangesetCheckResponse.ProtoReflect.Descriptor instead.
+func (*SetChangesetCheckResponse) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SetChangesetCheckResponse) GetChangeset() *ChangesetInfo {
+	if x != nil {
+		return x.Changeset
+	}
+	return nil
+}
+
+func (x *SetChangesetCheckResponse) GetPendingRequiredChecks() []string {
+	if x != nil {
+		return x.PendingRequiredChecks
+	}
+	return nil
+}
+
+type ReviewVote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reviewer      string                 `protobuf:"bytes,1,opt,name=reviewer,proto3" json:"reviewer,omitempty"`
+	Approved      bool                   `protobuf:"varint,2,opt,name=approved,proto3" json:"approved,omitempty"`
+	Comment       string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewVote) Reset() {
+	*x = ReviewVote{}
+	mi := &file_slice_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewVote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewVote) ProtoMessage() {}
+
+func (x *ReviewVote) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewVote.ProtoReflect.Descriptor instead.
+func (*ReviewVote) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ReviewVote) GetReviewer() string {
+	if x != nil {
+		return x.Reviewer
+	}
+	return ""
+}
+
+func (x *ReviewVote) GetApproved() bool {
+	if x != nil {
+		return x.Approved
+	}
+	return false
+}
+
+func (x *ReviewVote) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *ReviewVote) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type AbandonChangesetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChangesetId   string                 `protobuf:"bytes,1,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbandonChangesetRequest) Reset() {
+	*x = AbandonChangesetRequest{}
+	mi := &file_slice_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbandonChangesetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbandonChangesetRequest) ProtoMessage() {}
+
+func (x *AbandonChangesetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbandonChangesetRequest.ProtoReflect.Descriptor instead.
+func (*AbandonChangesetRequest) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *AbandonChangesetRequest) GetChangesetId() string {
+	if x != nil {
+		return x.ChangesetId
+	}
+	return ""
+}
+
+func (x *AbandonChangesetRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type AbandonChangesetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changeset     *ChangesetInfo         `protobuf:"bytes,1,opt,name=changeset,proto3" json:"changeset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbandonChangesetResponse) Reset() {
+	*x = AbandonChangesetResponse{}
+	mi := &file_slice_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbandonChangesetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbandonChangesetResponse) ProtoMessage() {}
+
+func (x *AbandonChangesetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbandonChangesetResponse.ProtoReflect.Descriptor instead.
+func (*AbandonChangesetResponse) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *AbandonChangesetResponse) GetChangeset() *ChangesetInfo {
+	if x != nil {
+		return x.Changeset
+	}
+	return nil
+}
+
+type ReviewComment struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	CommentId   string                 `protobuf:"bytes,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	ChangesetId string                 `protobuf:"bytes,2,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
+	// Changeset revision the comment was written against
+	ChangesetHash string `protobuf:"bytes,3,opt,name=changeset_hash,json=changesetHash,proto3" json:"changeset_hash,omitempty"`
+	// Empty for comments on the changeset as a whole
+	Path string `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	// Line in the new version of the file; 0 for comments on the whole file
+	Line int32 `protobuf:"varint,5,opt,name=line,proto3" json:"line,omitempty"`
+	// Set on replies to the comment that opened the thread
+	ParentId      string `protobuf:"bytes,6,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Author        string `protobuf:"bytes,7,opt,name=author,proto3" json:"author,omitempty"`
+	Body          string `protobuf:"bytes,8,opt,name=body,proto3" json:"body,omitempty"`
+	Resolved      bool   `protobuf:"varint,9,opt,name=resolved,proto3" json:"resolved,omitempty"`
+	ResolvedBy    string `protobuf:"bytes,10,opt,name=resolved_by,json=resolvedBy,proto3" json:"resolved_by,omitempty"`
+	CreatedAt     int64  `protobuf:"varint,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ResolvedAt    int64  `protobuf:"varint,12,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewComment) Reset() {
+	*x = ReviewComment{}
+	mi := &file_slice_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewComment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewComment) ProtoMessage() {}
+
+func (x *ReviewComment) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewComment.ProtoReflect.Descriptor instead.
+func (*ReviewComment) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ReviewComment) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+func (x *ReviewComment) GetChangesetId() string {
+	if x != nil {
+		return x.ChangesetId
+	}
+	return ""
+}
+
+func (x *ReviewComment) GetChangesetHash() string {
+	if x != nil {
+		return x.ChangesetHash
+	}
+	return ""
+}
+
+func (x *ReviewComment) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ReviewComment) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ReviewComment) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *ReviewComment) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *ReviewComment) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *ReviewComment) GetResolved() bool {
+	if x != nil {
+		return x.Resolved
+	}
+	return false
+}
+
+func (x *ReviewComment) GetResolvedBy() string {
+	if x != nil {
+		return x.ResolvedBy
+	}
+	return ""
+}
+
+func (x *ReviewComment) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ReviewComment) GetResolvedAt() int64 {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return 0
+}
+
+type AddCommentRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ChangesetId string                 `protobuf:"bytes,1,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
+	Path        string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Line        int32                  `protobuf:"varint,3,opt,name=line,proto3" json:"line,omitempty"`
+	// Replies take their path and line from the thread they join
+	ParentId      string `protobuf:"bytes,4,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Author        string `protobuf:"bytes,5,opt,name=author,proto3" json:"author,omitempty"`
+	Body          string `protobuf:"bytes,6,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCommentRequest) Reset() {
+	*x = AddCommentRequest{}
+	mi := &file_slice_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCommentRequest) ProtoMessage() {}
+
+func (x *AddCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCommentRequest.ProtoReflect.Descriptor instead.
+func (*AddCommentRequest) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *AddCommentRequest) GetChangesetId() string {
+	if x != nil {
+		return x.ChangesetId
+	}
+	return ""
+}
+
+func (x *AddCommentRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *AddCommentRequest) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *AddCommentRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *AddCommentRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *AddCommentRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+type AddCommentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comment       *ReviewComment         `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCommentResponse) Reset() {
+	*x = AddCommentResponse{}
+	mi := &file_slice_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCommentResponse) ProtoMessage() {}
+
+func (x *AddCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCommentResponse.ProtoReflect.Descriptor instead.
+func (*AddCommentResponse) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *AddCommentResponse) GetComment() *ReviewComment {
+	if x != nil {
+		return x.Comment
+	}
+	return nil
+}
+
+type ListCommentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChangesetId   string                 `protobuf:"bytes,1,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsRequest) Reset() {
+	*x = ListCommentsRequest{}
+	mi := &file_slice_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsRequest) ProtoMessage() {}
+
+func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsRequest.ProtoReflect.Descriptor instead.
+func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListCommentsRequest) GetChangesetId() string {
+	if x != nil {
+		return x.ChangesetId
+	}
+	return ""
+}
+
+type ListCommentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comments      []*ReviewComment       `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsResponse) Reset() {
+	*x = ListCommentsResponse{}
+	mi := &file_slice_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsResponse) ProtoMessage() {}
+
+func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsResponse.ProtoReflect.Descriptor instead.
+func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListCommentsResponse) GetComments() []*ReviewComment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+type ResolveCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommentId     string                 `protobuf:"bytes,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	ResolvedBy    string                 `protobuf:"bytes,2,opt,name=resolved_by,json=resolvedBy,proto3" json:"resolved_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveCommentRequest) Reset() {
+	*x = ResolveCommentRequest{}
+	mi := &file_slice_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveCommentRequest) ProtoMessage() {}
+
+func (x *ResolveCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveCommentRequest.ProtoReflect.Descriptor instead.
+func (*ResolveCommentRequest) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ResolveCommentRequest) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+func (x *ResolveCommentRequest) GetResolvedBy() string {
+	if x != nil {
+		return x.ResolvedBy
+	}
+	return ""
+}
+
+type ResolveCommentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comment       *ReviewComment         `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveCommentResponse) Reset() {
+	*x = ResolveCommentResponse{}
+	mi := &file_slice_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveCommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveCommentResponse) ProtoMessage() {}
+
+func (x *ResolveCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveCommentResponse.ProtoReflect.Descriptor instead.
+func (*ResolveCommentResponse) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ResolveCommentResponse) GetComment() *ReviewComment {
+	if x != nil {
+		return x.Comment
+	}
+	return nil
+}
+
+type EnqueueMergeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChangesetId   string                 `protobuf:"bytes,1,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
+	RequestedBy   string                 `protobuf:"bytes,2,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnqueueMergeRequest) Reset() {
+	*x = EnqueueMergeRequest{}
+	mi := &file_slice_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnqueueMergeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnqueueMergeRequest) ProtoMessage() {}
+
+func (x *EnqueueMergeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnqueueMergeRequest.ProtoReflect.Descriptor instead.
+func (*EnqueueMergeRequest) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *EnqueueMergeRequest) GetChangesetId() string {
+	if x != nil {
+		return x.ChangesetId
+	}
+	return ""
+}
+
+func (x *EnqueueMergeRequest) GetRequestedBy() string {
+	if x != nil {
+		return x.RequestedBy
+	}
+	return ""
+}
+
+type EnqueueMergeResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ChangesetId string                 `protobuf:"bytes,1,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
+	SliceId     string                 `protobuf:"bytes,2,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	// 1-based position in the slice's merge queue
+	Position      int32 `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnqueueMergeResponse) Reset() {
+	*x = EnqueueMergeResponse{}
+	mi := &file_slice_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnqueueMergeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnqueueMergeResponse) ProtoMessage() {}
+
+func (x *EnqueueMergeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnqueueMergeResponse.ProtoReflect.Descriptor instead.
+func (*EnqueueMergeResponse) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *EnqueueMergeResponse) GetChangesetId() string {
+	if x != nil {
+		return x.ChangesetId
+	}
+	return ""
+}
+
+func (x *EnqueueMergeResponse) GetSliceId() string {
+	if x != nil {
+		return x.SliceId
+	}
+	return ""
+}
+
+func (x *EnqueueMergeResponse) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type WatchMergeQueueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChangesetId   string                 `protobuf:"bytes,1,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchMergeQueueRequest) Reset() {
+	*x = WatchMergeQueueRequest{}
+	mi := &file_slice_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchMergeQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchMergeQueueRequest) ProtoMessage() {}
+
+func (x *WatchMergeQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchMergeQueueRequest.ProtoReflect.Descriptor instead.
+func (*WatchMergeQueueRequest) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *WatchMergeQueueRequest) GetChangesetId() string {
+	if x != nil {
+		return x.ChangesetId
+	}
+	return ""
+}
+
+type MergeQueueEvent struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ChangesetId string                 `protobuf:"bytes,1,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
+	SliceId     string                 `protobuf:"bytes,2,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	State       MergeQueueState        `protobuf:"varint,3,opt,name=state,proto3,enum=slice.v1.MergeQueueState" json:"state,omitempty"`
+	// 1-based position while queued
+	Position      int32  `protobuf:"varint,4,opt,name=position,proto3" json:"position,omitempty"`
+	NewCommitHash string `protobuf:"bytes,5,opt,name=new_commit_hash,json=newCommitHash,proto3" json:"new_commit_hash,omitempty"`
+	// Why the merge failed
+	Message       string   `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	Conflicts     []string `protobuf:"bytes,7,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeQueueEvent) Reset() {
+	*x = MergeQueueEvent{}
+	mi := &file_slice_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeQueueEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeQueueEvent) ProtoMessage() {}
+
+func (x *MergeQueueEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeQueueEvent.ProtoReflect.Descriptor instead.
+func (*MergeQueueEvent) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *MergeQueueEvent) GetChangesetId() string {
+	if x != nil {
+		return x.ChangesetId
+	}
+	return ""
+}
+
+func (x *MergeQueueEvent) GetSliceId() string {
+	if x != nil {
+		return x.SliceId
+	}
+	return ""
+}
+
+func (x *MergeQueueEvent) GetState() MergeQueueState {
+	if x != nil {
+		return x.State
+	}
+	return MergeQueueState_MERGE_QUEUE_STATE_QUEUED
+}
+
+func (x *MergeQueueEvent) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *MergeQueueEvent) GetNewCommitHash() string {
+	if x != nil {
+		return x.NewCommitHash
+	}
+	return ""
+}
+
+func (x *MergeQueueEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *MergeQueueEvent) GetConflicts() []string {
+	if x != nil {
+		return x.Conflicts
+	}
+	return nil
+}
+
+type ChangesetVoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChangesetId   string                 `protobuf:"bytes,1,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
+	Reviewer      string                 `protobuf:"bytes,2,opt,name=reviewer,proto3" json:"reviewer,omitempty"`
+	Comment       string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangesetVoteRequest) Reset() {
+	*x = ChangesetVoteRequest{}
+	mi := &file_slice_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangesetVoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangesetVoteRequest) ProtoMessage() {}
+
+func (x *ChangesetVoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangesetVoteRequest.ProtoReflect.Descriptor instead.
+func (*ChangesetVoteRequest) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ChangesetVoteRequest) GetChangesetId() string {
+	if x != nil {
+		return x.ChangesetId
+	}
+	return ""
+}
+
+func (x *ChangesetVoteRequest) GetReviewer() string {
+	if x != nil {
+		return x.Reviewer
+	}
+	return ""
+}
+
+func (x *ChangesetVoteRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type ChangesetVoteResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Changeset         *ChangesetInfo         `protobuf:"bytes,1,opt,name=changeset,proto3" json:"changeset,omitempty"`
+	Approvals         int32                  `protobuf:"varint,2,opt,name=approvals,proto3" json:"approvals,omitempty"`
+	RequiredApprovals int32                  `protobuf:"varint,3,opt,name=required_approvals,json=requiredApprovals,proto3" json:"required_approvals,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ChangesetVoteResponse) Reset() {
+	*x = ChangesetVoteResponse{}
+	mi := &file_slice_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangesetVoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangesetVoteResponse) ProtoMessage() {}
+
+func (x *ChangesetVoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangesetVoteResponse.ProtoReflect.Descriptor instead.
+func (*ChangesetVoteResponse) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ChangesetVoteResponse) GetChangeset() *ChangesetInfo {
+	if x != nil {
+		return x.Changeset
+	}
+	return nil
+}
+
+func (x *ChangesetVoteResponse) GetApprovals() int32 {
+	if x != nil {
+		return x.Approvals
+	}
+	return 0
+}
+
+func (x *ChangesetVoteResponse) GetRequiredApprovals() int32 {
+	if x != nil {
+		return x.RequiredApprovals
+	}
+	return 0
+}
+
+type CommitHistoryRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SliceId        string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	Limit          int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	FromCommitHash string                 `protobuf:"bytes,3,opt,name=from_commit_hash,json=fromCommitHash,proto3" json:"from_commit_hash,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CommitHistoryRequest) Reset() {
+	*x = CommitHistoryRequest{}
+	mi := &file_slice_service_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitHistoryRequest) ProtoMessage() {}
+
+func (x *CommitHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitHistoryRequest.ProtoReflect.Descriptor instead.
+func (*CommitHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *CommitHistoryRequest) GetSliceId() string {
+	if x != nil {
+		return x.SliceId
+	}
+	return ""
+}
+
+func (x *CommitHistoryRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *CommitHistoryRequest) GetFromCommitHash() string {
+	if x != nil {
+		return x.FromCommitHash
+	}
+	return ""
+}
+
+type CommitHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Commits       []*CommitInfo          `protobuf:"bytes,1,rep,name=commits,proto3" json:"commits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitHistoryResponse) Reset() {
+	*x = CommitHistoryResponse{}
+	mi := &file_slice_service_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitHistoryResponse) ProtoMessage() {}
+
+func (x *CommitHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitHistoryResponse.ProtoReflect.Descriptor instead.
+func (*CommitHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *CommitHistoryResponse) GetCommits() []*CommitInfo {
+	if x != nil {
+		return x.Commits
+	}
+	return nil
+}
+
+type CommitInfo struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	CommitHash string                 `protobuf:"bytes,1,opt,name=commit_hash,json=commitHash,proto3" json:"commit_hash,omitempty"`
+	Timestamp  int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	ParentHash string                 `protobuf:"bytes,3,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
+	Message    string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	// Head of the child slice a fold commit brought in, whose history
+	// continues from there
+	FoldedHash    string `protobuf:"bytes,5,opt,name=folded_hash,json=foldedHash,proto3" json:"folded_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitInfo) Reset() {
+	*x = CommitInfo{}
+	mi := &file_slice_service_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitInfo) ProtoMessage() {}
+
+func (x *CommitInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitInfo.ProtoReflect.Descriptor instead.
+func (*CommitInfo) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *CommitInfo) GetCommitHash() string {
+	if x != nil {
+		return x.CommitHash
+	}
+	return ""
+}
+
+func (x *CommitInfo) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *CommitInfo) GetParentHash() string {
+	if x != nil {
+		return x.ParentHash
+	}
+	return ""
+}
+
+func (x *CommitInfo) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CommitInfo) GetFoldedHash() string {
+	if x != nil {
+		return x.FoldedHash
+	}
+	return ""
+}
+
+type StateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SliceId       string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StateRequest) Reset() {
+	*x = StateRequest{}
+	mi := &file_slice_service_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateRequest) ProtoMessage() {}
+
+func (x *StateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateRequest.ProtoReflect.Descriptor instead.
+func (*StateRequest) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *StateRequest) GetSliceId() string {
 	if x != nil {
-		return x.ModifiedFiles
+		return x.SliceId
 	}
-	return nil
+	return ""
 }
 
-func (x *ChangesetInfo) GetStatus() ChangesetStatus {
+type StateResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	LatestCommitHash string                 `protobuf:"bytes,1,opt,name=latest_commit_hash,json=latestCommitHash,proto3" json:"latest_commit_hash,omitempty"`
+	ModifiedFiles    []string               `protobuf:"bytes,2,rep,name=modified_files,json=modifiedFiles,proto3" json:"modified_files,omitempty"`
+	LastModified     int64                  `protobuf:"varint,3,opt,name=last_modified,json=lastModified,proto3" json:"last_modified,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *StateResponse) Reset() {
+	*x = StateResponse{}
+	mi := &file_slice_service_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateResponse) ProtoMessage() {}
+
+func (x *StateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[53]
 	if x != nil {
-		return x.Status
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ChangesetStatus_PENDING
+	return mi.MessageOf(x)
 }
 
-func (x *ChangesetInfo) GetAuthor() string {
+// Deprecated: Use StateResponse.ProtoReflect.Descriptor instead.
+func (*StateResponse) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *StateResponse) GetLatestCommitHash() string {
 	if x != nil {
-		return x.Author
+		return x.LatestCommitHash
 	}
 	return ""
 }
 
-func (x *ChangesetInfo) GetCreatedAt() int64 {
+func (x *StateResponse) GetModifiedFiles() []string {
 	if x != nil {
-		return x.CreatedAt
+		return x.ModifiedFiles
 	}
-	return 0
+	return nil
 }
 
-func (x *ChangesetInfo) GetMergedAt() int64 {
+func (x *StateResponse) GetLastModified() int64 {
 	if x != nil {
-		return x.MergedAt
+		return x.LastModified
 	}
 	return 0
 }
 
-func (x *ChangesetInfo) GetMessage() string {
+// Request to get root slice info
+type GetRootSliceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRootSliceRequest) Reset() {
+	*x = GetRootSliceRequest{}
+	mi := &file_slice_service_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRootSliceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRootSliceRequest) ProtoMessage() {}
+
+func (x *GetRootSliceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[54]
 	if x != nil {
-		return x.Message
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-type CommitHistoryRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	SliceId        string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
-	Limit          int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	FromCommitHash string                 `protobuf:"bytes,3,opt,name=from_commit_hash,json=fromCommitHash,proto3" json:"from_commit_hash,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+// Deprecated: Use GetRootSliceRequest.ProtoReflect.Descriptor instead.
+func (*GetRootSliceRequest) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *CommitHistoryRequest) Reset() {
-	*x = CommitHistoryRequest{}
-	mi := &file_slice_service_proto_msgTypes[22]
+// Response with root slice info
+type GetRootSliceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SliceId       string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	CommitHash    string                 `protobuf:"bytes,2,opt,name=commit_hash,json=commitHash,proto3" json:"commit_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRootSliceResponse) Reset() {
+	*x = GetRootSliceResponse{}
+	mi := &file_slice_service_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CommitHistoryRequest) String() string {
+func (x *GetRootSliceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CommitHistoryRequest) ProtoMessage() {}
+func (*GetRootSliceResponse) ProtoMessage() {}
 
-func (x *CommitHistoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_slice_service_proto_msgTypes[22]
+func (x *GetRootSliceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1716,54 +4023,138 @@ func (x *CommitHistoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CommitHistoryRequest.ProtoReflect.Descriptor instead.
-func (*CommitHistoryRequest) Descriptor() ([]byte, []int) {
-	return file_slice_service_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use GetRootSliceResponse.ProtoReflect.Descriptor instead.
+func (*GetRootSliceResponse) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *CommitHistoryRequest) GetSliceId() string {
+func (x *GetRootSliceResponse) GetSliceId() string {
 	if x != nil {
 		return x.SliceId
 	}
 	return ""
 }
 
-func (x *CommitHistoryRequest) GetLimit() int64 {
+func (x *GetRootSliceResponse) GetCommitHash() string {
 	if x != nil {
-		return x.Limit
+		return x.CommitHash
 	}
-	return 0
+	return ""
 }
 
-func (x *CommitHistoryRequest) GetFromCommitHash() string {
+// Request to create a slice from an existing folder
+type CreateSliceFromFolderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ParentSliceId string                 `protobuf:"bytes,1,opt,name=parent_slice_id,json=parentSliceId,proto3" json:"parent_slice_id,omitempty"`
+	FolderPath    string                 `protobuf:"bytes,2,opt,name=folder_path,json=folderPath,proto3" json:"folder_path,omitempty"`
+	NewSliceId    string                 `protobuf:"bytes,3,opt,name=new_slice_id,json=newSliceId,proto3" json:"new_slice_id,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSliceFromFolderRequest) Reset() {
+	*x = CreateSliceFromFolderRequest{}
+	mi := &file_slice_service_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSliceFromFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSliceFromFolderRequest) ProtoMessage() {}
+
+func (x *CreateSliceFromFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[56]
 	if x != nil {
-		return x.FromCommitHash
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSliceFromFolderRequest.ProtoReflect.Descriptor instead.
+func (*CreateSliceFromFolderRequest) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *CreateSliceFromFolderRequest) GetParentSliceId() string {
+	if x != nil {
+		return x.ParentSliceId
 	}
 	return ""
 }
 
-type CommitHistoryResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Commits       []*CommitInfo          `protobuf:"bytes,1,rep,name=commits,proto3" json:"commits,omitempty"`
+func (x *CreateSliceFromFolderRequest) GetFolderPath() string {
+	if x != nil {
+		return x.FolderPath
+	}
+	return ""
+}
+
+func (x *CreateSliceFromFolderRequest) GetNewSliceId() string {
+	if x != nil {
+		return x.NewSliceId
+	}
+	return ""
+}
+
+func (x *CreateSliceFromFolderRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSliceFromFolderRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateSliceFromFolderRequest) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+// Response for slice creation from folder
+type CreateSliceFromFolderResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	SliceId string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	Status  string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Files moved from the parent slice, sorted by path
+	Files []string `protobuf:"bytes,3,rep,name=files,proto3" json:"files,omitempty"`
+	// The new slice's first commit, holding the folder's files
+	CommitHash    string `protobuf:"bytes,4,opt,name=commit_hash,json=commitHash,proto3" json:"commit_hash,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CommitHistoryResponse) Reset() {
-	*x = CommitHistoryResponse{}
-	mi := &file_slice_service_proto_msgTypes[23]
+func (x *CreateSliceFromFolderResponse) Reset() {
+	*x = CreateSliceFromFolderResponse{}
+	mi := &file_slice_service_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CommitHistoryResponse) String() string {
+func (x *CreateSliceFromFolderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CommitHistoryResponse) ProtoMessage() {}
+func (*CreateSliceFromFolderResponse) ProtoMessage() {}
 
-func (x *CommitHistoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_slice_service_proto_msgTypes[23]
+func (x *CreateSliceFromFolderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1774,43 +4165,68 @@ func (x *CommitHistoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CommitHistoryResponse.ProtoReflect.Descriptor instead.
-func (*CommitHistoryResponse) Descriptor() ([]byte, []int) {
-	return file_slice_service_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use CreateSliceFromFolderResponse.ProtoReflect.Descriptor instead.
+func (*CreateSliceFromFolderResponse) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *CreateSliceFromFolderResponse) GetSliceId() string {
+	if x != nil {
+		return x.SliceId
+	}
+	return ""
+}
+
+func (x *CreateSliceFromFolderResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreateSliceFromFolderResponse) GetFiles() []string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
 }
 
-func (x *CommitHistoryResponse) GetCommits() []*CommitInfo {
+func (x *CreateSliceFromFolderResponse) GetCommitHash() string {
 	if x != nil {
-		return x.Commits
+		return x.CommitHash
 	}
-	return nil
+	return ""
 }
 
-type CommitInfo struct {
+// A slice's place in the slice hierarchy. Slices created without a parent
+// hang off the root slice.
+type SliceNode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CommitHash    string                 `protobuf:"bytes,1,opt,name=commit_hash,json=commitHash,proto3" json:"commit_hash,omitempty"`
-	Timestamp     int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	ParentHash    string                 `protobuf:"bytes,3,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
-	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	SliceId       string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ParentSliceId string                 `protobuf:"bytes,3,opt,name=parent_slice_id,json=parentSliceId,proto3" json:"parent_slice_id,omitempty"`
+	// Distance from the slice the listing started at
+	Depth         int32 `protobuf:"varint,4,opt,name=depth,proto3" json:"depth,omitempty"`
+	FileCount     int32 `protobuf:"varint,5,opt,name=file_count,json=fileCount,proto3" json:"file_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CommitInfo) Reset() {
-	*x = CommitInfo{}
-	mi := &file_slice_service_proto_msgTypes[24]
+func (x *SliceNode) Reset() {
+	*x = SliceNode{}
+	mi := &file_slice_service_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CommitInfo) String() string {
+func (x *SliceNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CommitInfo) ProtoMessage() {}
+func (*SliceNode) ProtoMessage() {}
 
-func (x *CommitInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_slice_service_proto_msgTypes[24]
+func (x *SliceNode) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1821,61 +4237,71 @@ func (x *CommitInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CommitInfo.ProtoReflect.Descriptor instead.
-func (*CommitInfo) Descriptor() ([]byte, []int) {
-	return file_slice_service_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use SliceNode.ProtoReflect.Descriptor instead.
+func (*SliceNode) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *CommitInfo) GetCommitHash() string {
+func (x *SliceNode) GetSliceId() string {
 	if x != nil {
-		return x.CommitHash
+		return x.SliceId
 	}
 	return ""
 }
 
-func (x *CommitInfo) GetTimestamp() int64 {
+func (x *SliceNode) GetName() string {
 	if x != nil {
-		return x.Timestamp
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *CommitInfo) GetParentHash() string {
+func (x *SliceNode) GetParentSliceId() string {
 	if x != nil {
-		return x.ParentHash
+		return x.ParentSliceId
 	}
 	return ""
 }
 
-func (x *CommitInfo) GetMessage() string {
+func (x *SliceNode) GetDepth() int32 {
 	if x != nil {
-		return x.Message
+		return x.Depth
 	}
-	return ""
+	return 0
 }
 
-type StateRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SliceId       string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+func (x *SliceNode) GetFileCount() int32 {
+	if x != nil {
+		return x.FileCount
+	}
+	return 0
+}
+
+// Request to list the slices under a slice
+type ListSliceChildrenRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	SliceId string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	// Include grandchildren and below, not just direct children
+	Recursive     bool `protobuf:"varint,2,opt,name=recursive,proto3" json:"recursive,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StateRequest) Reset() {
-	*x = StateRequest{}
-	mi := &file_slice_service_proto_msgTypes[25]
+func (x *ListSliceChildrenRequest) Reset() {
+	*x = ListSliceChildrenRequest{}
+	mi := &file_slice_service_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StateRequest) String() string {
+func (x *ListSliceChildrenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StateRequest) ProtoMessage() {}
+func (*ListSliceChildrenRequest) ProtoMessage() {}
 
-func (x *StateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_slice_service_proto_msgTypes[25]
+func (x *ListSliceChildrenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1886,42 +4312,48 @@ func (x *StateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StateRequest.ProtoReflect.Descriptor instead.
-func (*StateRequest) Descriptor() ([]byte, []int) {
-	return file_slice_service_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use ListSliceChildrenRequest.ProtoReflect.Descriptor instead.
+func (*ListSliceChildrenRequest) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *StateRequest) GetSliceId() string {
+func (x *ListSliceChildrenRequest) GetSliceId() string {
 	if x != nil {
 		return x.SliceId
 	}
 	return ""
 }
 
-type StateResponse struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	LatestCommitHash string                 `protobuf:"bytes,1,opt,name=latest_commit_hash,json=latestCommitHash,proto3" json:"latest_commit_hash,omitempty"`
-	ModifiedFiles    []string               `protobuf:"bytes,2,rep,name=modified_files,json=modifiedFiles,proto3" json:"modified_files,omitempty"`
-	LastModified     int64                  `protobuf:"varint,3,opt,name=last_modified,json=lastModified,proto3" json:"last_modified,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+func (x *ListSliceChildrenRequest) GetRecursive() bool {
+	if x != nil {
+		return x.Recursive
+	}
+	return false
 }
 
-func (x *StateResponse) Reset() {
-	*x = StateResponse{}
-	mi := &file_slice_service_proto_msgTypes[26]
+// Response listing child slices depth first, siblings sorted by ID
+type ListSliceChildrenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Children      []*SliceNode           `protobuf:"bytes,1,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSliceChildrenResponse) Reset() {
+	*x = ListSliceChildrenResponse{}
+	mi := &file_slice_service_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StateResponse) String() string {
+func (x *ListSliceChildrenResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StateResponse) ProtoMessage() {}
+func (*ListSliceChildrenResponse) ProtoMessage() {}
 
-func (x *StateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_slice_service_proto_msgTypes[26]
+func (x *ListSliceChildrenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1932,54 +4364,86 @@ func (x *StateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StateResponse.ProtoReflect.Descriptor instead.
-func (*StateResponse) Descriptor() ([]byte, []int) {
-	return file_slice_service_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use ListSliceChildrenResponse.ProtoReflect.Descriptor instead.
+func (*ListSliceChildrenResponse) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *StateResponse) GetLatestCommitHash() string {
+func (x *ListSliceChildrenResponse) GetChildren() []*SliceNode {
 	if x != nil {
-		return x.LatestCommitHash
+		return x.Children
 	}
-	return ""
+	return nil
 }
 
-func (x *StateResponse) GetModifiedFiles() []string {
+// Request to list a slice's ancestors
+type GetSliceAncestryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SliceId       string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSliceAncestryRequest) Reset() {
+	*x = GetSliceAncestryRequest{}
+	mi := &file_slice_service_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSliceAncestryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSliceAncestryRequest) ProtoMessage() {}
+
+func (x *GetSliceAncestryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[61]
 	if x != nil {
-		return x.ModifiedFiles
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *StateResponse) GetLastModified() int64 {
+// Deprecated: Use GetSliceAncestryRequest.ProtoReflect.Descriptor instead.
+func (*GetSliceAncestryRequest) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *GetSliceAncestryRequest) GetSliceId() string {
 	if x != nil {
-		return x.LastModified
+		return x.SliceId
 	}
-	return 0
+	return ""
 }
 
-// Request to get root slice info
-type GetRootSliceRequest struct {
+// Response listing ancestors nearest first, ending with the root slice
+type GetSliceAncestryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ancestors     []*SliceNode           `protobuf:"bytes,1,rep,name=ancestors,proto3" json:"ancestors,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetRootSliceRequest) Reset() {
-	*x = GetRootSliceRequest{}
-	mi := &file_slice_service_proto_msgTypes[27]
+func (x *GetSliceAncestryResponse) Reset() {
+	*x = GetSliceAncestryResponse{}
+	mi := &file_slice_service_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetRootSliceRequest) String() string {
+func (x *GetSliceAncestryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRootSliceRequest) ProtoMessage() {}
+func (*GetSliceAncestryResponse) ProtoMessage() {}
 
-func (x *GetRootSliceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_slice_service_proto_msgTypes[27]
+func (x *GetSliceAncestryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1990,35 +4454,42 @@ func (x *GetRootSliceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRootSliceRequest.ProtoReflect.Descriptor instead.
-func (*GetRootSliceRequest) Descriptor() ([]byte, []int) {
-	return file_slice_service_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use GetSliceAncestryResponse.ProtoReflect.Descriptor instead.
+func (*GetSliceAncestryResponse) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{62}
 }
 
-// Response with root slice info
-type GetRootSliceResponse struct {
+func (x *GetSliceAncestryResponse) GetAncestors() []*SliceNode {
+	if x != nil {
+		return x.Ancestors
+	}
+	return nil
+}
+
+// Request to move a slice under a different parent
+type ReparentSliceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SliceId       string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
-	CommitHash    string                 `protobuf:"bytes,2,opt,name=commit_hash,json=commitHash,proto3" json:"commit_hash,omitempty"`
+	ParentSliceId string                 `protobuf:"bytes,2,opt,name=parent_slice_id,json=parentSliceId,proto3" json:"parent_slice_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetRootSliceResponse) Reset() {
-	*x = GetRootSliceResponse{}
-	mi := &file_slice_service_proto_msgTypes[28]
+func (x *ReparentSliceRequest) Reset() {
+	*x = ReparentSliceRequest{}
+	mi := &file_slice_service_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetRootSliceResponse) String() string {
+func (x *ReparentSliceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRootSliceResponse) ProtoMessage() {}
+func (*ReparentSliceRequest) ProtoMessage() {}
 
-func (x *GetRootSliceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_slice_service_proto_msgTypes[28]
+func (x *ReparentSliceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2029,52 +4500,50 @@ func (x *GetRootSliceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRootSliceResponse.ProtoReflect.Descriptor instead.
-func (*GetRootSliceResponse) Descriptor() ([]byte, []int) {
-	return file_slice_service_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use ReparentSliceRequest.ProtoReflect.Descriptor instead.
+func (*ReparentSliceRequest) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *GetRootSliceResponse) GetSliceId() string {
+func (x *ReparentSliceRequest) GetSliceId() string {
 	if x != nil {
 		return x.SliceId
 	}
 	return ""
 }
 
-func (x *GetRootSliceResponse) GetCommitHash() string {
+func (x *ReparentSliceRequest) GetParentSliceId() string {
 	if x != nil {
-		return x.CommitHash
+		return x.ParentSliceId
 	}
 	return ""
 }
 
-// Request to create a slice from an existing folder
-type CreateSliceFromFolderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ParentSliceId string                 `protobuf:"bytes,1,opt,name=parent_slice_id,json=parentSliceId,proto3" json:"parent_slice_id,omitempty"`
-	FolderPath    string                 `protobuf:"bytes,2,opt,name=folder_path,json=folderPath,proto3" json:"folder_path,omitempty"`
-	NewSliceId    string                 `protobuf:"bytes,3,opt,name=new_slice_id,json=newSliceId,proto3" json:"new_slice_id,omitempty"`
-	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Response for a reparented slice
+type ReparentSliceResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	SliceId               string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	PreviousParentSliceId string                 `protobuf:"bytes,2,opt,name=previous_parent_slice_id,json=previousParentSliceId,proto3" json:"previous_parent_slice_id,omitempty"`
+	ParentSliceId         string                 `protobuf:"bytes,3,opt,name=parent_slice_id,json=parentSliceId,proto3" json:"parent_slice_id,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
-func (x *CreateSliceFromFolderRequest) Reset() {
-	*x = CreateSliceFromFolderRequest{}
-	mi := &file_slice_service_proto_msgTypes[29]
+func (x *ReparentSliceResponse) Reset() {
+	*x = ReparentSliceResponse{}
+	mi := &file_slice_service_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateSliceFromFolderRequest) String() string {
+func (x *ReparentSliceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateSliceFromFolderRequest) ProtoMessage() {}
+func (*ReparentSliceResponse) ProtoMessage() {}
 
-func (x *CreateSliceFromFolderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_slice_service_proto_msgTypes[29]
+func (x *ReparentSliceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2085,71 +4554,116 @@ func (x *CreateSliceFromFolderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateSliceFromFolderRequest.ProtoReflect.Descriptor instead.
-func (*CreateSliceFromFolderRequest) Descriptor() ([]byte, []int) {
-	return file_slice_service_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use ReparentSliceResponse.ProtoReflect.Descriptor instead.
+func (*ReparentSliceResponse) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *CreateSliceFromFolderRequest) GetParentSliceId() string {
+func (x *ReparentSliceResponse) GetSliceId() string {
 	if x != nil {
-		return x.ParentSliceId
+		return x.SliceId
 	}
 	return ""
 }
 
-func (x *CreateSliceFromFolderRequest) GetFolderPath() string {
+func (x *ReparentSliceResponse) GetPreviousParentSliceId() string {
 	if x != nil {
-		return x.FolderPath
+		return x.PreviousParentSliceId
 	}
 	return ""
 }
 
-func (x *CreateSliceFromFolderRequest) GetNewSliceId() string {
+func (x *ReparentSliceResponse) GetParentSliceId() string {
 	if x != nil {
-		return x.NewSliceId
+		return x.ParentSliceId
 	}
 	return ""
 }
 
-func (x *CreateSliceFromFolderRequest) GetName() string {
+// Request to fold a slice back into its parent
+type FoldSliceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SliceId       string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
+	Author        string                 `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FoldSliceRequest) Reset() {
+	*x = FoldSliceRequest{}
+	mi := &file_slice_service_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FoldSliceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FoldSliceRequest) ProtoMessage() {}
+
+func (x *FoldSliceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[65]
 	if x != nil {
-		return x.Name
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FoldSliceRequest.ProtoReflect.Descriptor instead.
+func (*FoldSliceRequest) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *FoldSliceRequest) GetSliceId() string {
+	if x != nil {
+		return x.SliceId
 	}
 	return ""
 }
 
-func (x *CreateSliceFromFolderRequest) GetDescription() string {
+func (x *FoldSliceRequest) GetAuthor() string {
 	if x != nil {
-		return x.Description
+		return x.Author
 	}
 	return ""
 }
 
-// Response for slice creation from folder
-type CreateSliceFromFolderResponse struct {
+// Response for a folded slice
+type FoldSliceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SliceId       string                 `protobuf:"bytes,1,opt,name=slice_id,json=sliceId,proto3" json:"slice_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	Files         []string               `protobuf:"bytes,3,rep,name=files,proto3" json:"files,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	ParentSliceId string                 `protobuf:"bytes,1,opt,name=parent_slice_id,json=parentSliceId,proto3" json:"parent_slice_id,omitempty"`
+	// The parent's commit holding the folded files
+	CommitHash string `protobuf:"bytes,2,opt,name=commit_hash,json=commitHash,proto3" json:"commit_hash,omitempty"`
+	// Files moved to the parent, sorted by path
+	Files []string `protobuf:"bytes,3,rep,name=files,proto3" json:"files,omitempty"`
+	// Commits in the slice's history, reachable from the fold commit
+	CommitsFolded int32 `protobuf:"varint,4,opt,name=commits_folded,json=commitsFolded,proto3" json:"commits_folded,omitempty"`
+	// Child slices of the folded slice, now under its parent
+	ReparentedSlices []string `protobuf:"bytes,5,rep,name=reparented_slices,json=reparentedSlices,proto3" json:"reparented_slices,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *CreateSliceFromFolderResponse) Reset() {
-	*x = CreateSliceFromFolderResponse{}
-	mi := &file_slice_service_proto_msgTypes[30]
+func (x *FoldSliceResponse) Reset() {
+	*x = FoldSliceResponse{}
+	mi := &file_slice_service_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateSliceFromFolderResponse) String() string {
+func (x *FoldSliceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateSliceFromFolderResponse) ProtoMessage() {}
+func (*FoldSliceResponse) ProtoMessage() {}
 
-func (x *CreateSliceFromFolderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_slice_service_proto_msgTypes[30]
+func (x *FoldSliceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_slice_service_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2160,41 +4674,56 @@ func (x *CreateSliceFromFolderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateSliceFromFolderResponse.ProtoReflect.Descriptor instead.
-func (*CreateSliceFromFolderResponse) Descriptor() ([]byte, []int) {
-	return file_slice_service_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use FoldSliceResponse.ProtoReflect.Descriptor instead.
+func (*FoldSliceResponse) Descriptor() ([]byte, []int) {
+	return file_slice_service_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *CreateSliceFromFolderResponse) GetSliceId() string {
+func (x *FoldSliceResponse) GetParentSliceId() string {
 	if x != nil {
-		return x.SliceId
+		return x.ParentSliceId
 	}
 	return ""
 }
 
-func (x *CreateSliceFromFolderResponse) GetStatus() string {
+func (x *FoldSliceResponse) GetCommitHash() string {
 	if x != nil {
-		return x.Status
+		return x.CommitHash
 	}
 	return ""
 }
 
-func (x *CreateSliceFromFolderResponse) GetFiles() []string {
+func (x *FoldSliceResponse) GetFiles() []string {
 	if x != nil {
 		return x.Files
 	}
 	return nil
 }
 
+func (x *FoldSliceResponse) GetCommitsFolded() int32 {
+	if x != nil {
+		return x.CommitsFolded
+	}
+	return 0
+}
+
+func (x *FoldSliceResponse) GetReparentedSlices() []string {
+	if x != nil {
+		return x.ReparentedSlices
+	}
+	return nil
+}
+
 var File_slice_service_proto protoreflect.FileDescriptor
 
 const file_slice_service_proto_rawDesc = "" +
 	"\n" +
-	"\x13slice_service.proto\x12\bslice.v1\"M\n" +
+	"\x13slice_service.proto\x12\bslice.v1\"r\n" +
 	"\x0fCheckoutRequest\x12\x19\n" +
 	"\bslice_id\x18\x01 \x01(\tR\asliceId\x12\x1f\n" +
 	"\vcommit_hash\x18\x02 \x01(\tR\n" +
-	"commitHash\"t\n" +
+	"commitHash\x12#\n" +
+	"\rmanifest_only\x18\x03 \x01(\bR\fmanifestOnly\"t\n" +
 	"\x10CheckoutResponse\x123\n" +
 	"\bmanifest\x18\x01 \x01(\v2\x17.slice.v1.SliceManifestR\bmanifest\x12+\n" +
 	"\x05files\x18\x02 \x03(\v2\x15.slice.v1.FileContentR\x05files\"m\n" +
@@ -2215,14 +4744,15 @@ const file_slice_service_proto_rawDesc = "" +
 	"\rCheckoutChunk\x125\n" +
 	"\bmanifest\x18\x01 \x01(\v2\x17.slice.v1.SliceManifestH\x00R\bmanifest\x12+\n" +
 	"\x04file\x18\x02 \x01(\v2\x15.slice.v1.FileContentH\x00R\x04fileB\a\n" +
-	"\x05chunk\"\xe2\x01\n" +
+	"\x05chunk\"\x92\x02\n" +
 	"\x16CreateChangesetRequest\x12\x19\n" +
 	"\bslice_id\x18\x01 \x01(\tR\asliceId\x12(\n" +
 	"\x10base_commit_hash\x18\x02 \x01(\tR\x0ebaseCommitHash\x12*\n" +
 	"\aobjects\x18\x03 \x03(\v2\x10.slice.v1.ObjectR\aobjects\x12%\n" +
 	"\x0emodified_files\x18\x04 \x03(\tR\rmodifiedFiles\x12\x16\n" +
 	"\x06author\x18\x05 \x01(\tR\x06author\x12\x18\n" +
-	"\amessage\x18\x06 \x01(\tR\amessage\"\x96\x01\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\x12.\n" +
+	"\x13parent_changeset_id\x18\a \x01(\tR\x11parentChangesetId\"\x96\x01\n" +
 	"\x17CreateChangesetResponse\x12!\n" +
 	"\fchangeset_id\x18\x01 \x01(\tR\vchangesetId\x12%\n" +
 	"\x0echangeset_hash\x18\x02 \x01(\tR\rchangesetHash\x121\n" +
@@ -2230,16 +4760,24 @@ const file_slice_service_proto_rawDesc = "" +
 	"\x0eChangesetChunk\x129\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x1b.slice.v1.ChangesetMetadataH\x00R\bmetadata\x12*\n" +
 	"\x06object\x18\x02 \x01(\v2\x10.slice.v1.ObjectH\x00R\x06objectB\a\n" +
-	"\x05chunk\"\x8a\x01\n" +
+	"\x05chunk\"\xe1\x01\n" +
 	"\x11ChangesetMetadata\x12\x19\n" +
 	"\bslice_id\x18\x01 \x01(\tR\asliceId\x12(\n" +
 	"\x10base_commit_hash\x18\x02 \x01(\tR\x0ebaseCommitHash\x12\x16\n" +
 	"\x06author\x18\x03 \x01(\tR\x06author\x12\x18\n" +
-	"\amessage\x18\x04 \x01(\tR\amessage\"Z\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12%\n" +
+	"\x0emodified_files\x18\x05 \x03(\tR\rmodifiedFiles\x12.\n" +
+	"\x13parent_changeset_id\x18\x06 \x01(\tR\x11parentChangesetId\"3\n" +
+	"\x19FindMissingObjectsRequest\x12\x16\n" +
+	"\x06hashes\x18\x01 \x03(\tR\x06hashes\"C\n" +
+	"\x1aFindMissingObjectsResponse\x12%\n" +
+	"\x0emissing_hashes\x18\x01 \x03(\tR\rmissingHashes\"\x86\x01\n" +
 	"\x06Object\x12(\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x14.slice.v1.ObjectTypeR\x04type\x12\x12\n" +
 	"\x04hash\x18\x02 \x01(\tR\x04hash\x12\x12\n" +
-	"\x04data\x18\x03 \x01(\fR\x04data\";\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x03R\x06offset\x12\x12\n" +
+	"\x04more\x18\x05 \x01(\bR\x04more\";\n" +
 	"\x16ReviewChangesetRequest\x12!\n" +
 	"\fchangeset_id\x18\x01 \x01(\tR\vchangesetId\"\xd4\x01\n" +
 	"\x17ReviewChangesetResponse\x125\n" +
@@ -2254,7 +4792,32 @@ const file_slice_service_proto_rawDesc = "" +
 	"\rfiles_deleted\x18\x03 \x01(\x05R\ffilesDeleted\x12\x1f\n" +
 	"\vlines_added\x18\x04 \x01(\x03R\n" +
 	"linesAdded\x12#\n" +
-	"\rlines_removed\x18\x05 \x01(\x03R\flinesRemoved\":\n" +
+	"\rlines_removed\x18\x05 \x01(\x03R\flinesRemoved\"u\n" +
+	"\x14ChangesetDiffRequest\x12!\n" +
+	"\fchangeset_id\x18\x01 \x01(\tR\vchangesetId\x12(\n" +
+	"\rcontext_lines\x18\x02 \x01(\x05H\x00R\fcontextLines\x88\x01\x01B\x10\n" +
+	"\x0e_context_lines\"\xbf\x01\n" +
+	"\x15ChangesetDiffResponse\x12!\n" +
+	"\fchangeset_id\x18\x01 \x01(\tR\vchangesetId\x12(\n" +
+	"\x10base_commit_hash\x18\x02 \x01(\tR\x0ebaseCommitHash\x12/\n" +
+	"\asummary\x18\x03 \x01(\v2\x15.slice.v1.DiffSummaryR\asummary\x12(\n" +
+	"\x05files\x18\x04 \x03(\v2\x12.slice.v1.FileDiffR\x05files\"\xfe\x01\n" +
+	"\bFileDiff\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x129\n" +
+	"\vchange_type\x18\x02 \x01(\x0e2\x18.slice.v1.FileChangeTypeR\n" +
+	"changeType\x12\x1f\n" +
+	"\vlines_added\x18\x03 \x01(\x03R\n" +
+	"linesAdded\x12#\n" +
+	"\rlines_removed\x18\x04 \x01(\x03R\flinesRemoved\x12\x16\n" +
+	"\x06binary\x18\x05 \x01(\bR\x06binary\x12(\n" +
+	"\x05hunks\x18\x06 \x03(\v2\x12.slice.v1.DiffHunkR\x05hunks\x12\x1b\n" +
+	"\ttoo_large\x18\a \x01(\bR\btooLarge\"\x94\x01\n" +
+	"\bDiffHunk\x12\x1b\n" +
+	"\told_start\x18\x01 \x01(\x05R\boldStart\x12\x1b\n" +
+	"\told_lines\x18\x02 \x01(\x05R\boldLines\x12\x1b\n" +
+	"\tnew_start\x18\x03 \x01(\x05R\bnewStart\x12\x1b\n" +
+	"\tnew_lines\x18\x04 \x01(\x05R\bnewLines\x12\x14\n" +
+	"\x05lines\x18\x05 \x03(\tR\x05lines\":\n" +
 	"\x15MergeChangesetRequest\x12!\n" +
 	"\fchangeset_id\x18\x01 \x01(\tR\vchangesetId\"\xc4\x01\n" +
 	"\x16MergeChangesetResponse\x12-\n" +
@@ -2264,22 +4827,36 @@ const file_slice_service_proto_rawDesc = "" +
 	"\tconflicts\x18\x04 \x03(\v2\x12.slice.v1.ConflictR\tconflicts\"W\n" +
 	"\bConflict\x12\x17\n" +
 	"\afile_id\x18\x01 \x01(\tR\x06fileId\x122\n" +
-	"\x15conflicting_slice_ids\x18\x02 \x03(\tR\x13conflictingSliceIds\";\n" +
+	"\x15conflicting_slice_ids\x18\x02 \x03(\tR\x13conflictingSliceIds\"U\n" +
 	"\x16RebaseChangesetRequest\x12!\n" +
-	"\fchangeset_id\x18\x01 \x01(\tR\vchangesetId\"\xe1\x01\n" +
+	"\fchangeset_id\x18\x01 \x01(\tR\vchangesetId\x12\x18\n" +
+	"\arestack\x18\x02 \x01(\bR\arestack\"\x8c\x03\n" +
 	"\x17RebaseChangesetResponse\x12.\n" +
 	"\x06status\x18\x01 \x01(\x0e2\x16.slice.v1.RebaseStatusR\x06status\x12/\n" +
 	"\x14new_base_commit_hash\x18\x02 \x01(\tR\x11newBaseCommitHash\x123\n" +
 	"\x16slice_commits_to_apply\x18\x03 \x03(\tR\x13sliceCommitsToApply\x120\n" +
-	"\tconflicts\x18\x04 \x03(\v2\x12.slice.v1.ConflictR\tconflicts\"\x88\x01\n" +
+	"\tconflicts\x18\x04 \x03(\v2\x12.slice.v1.ConflictR\tconflicts\x12C\n" +
+	"\x10conflicted_files\x18\x05 \x03(\v2\x18.slice.v1.ConflictedFileR\x0fconflictedFiles\x125\n" +
+	"\trestacked\x18\x06 \x03(\v2\x17.slice.v1.RestackResultR\trestacked\x12-\n" +
+	"\x12stacked_changesets\x18\a \x03(\tR\x11stackedChangesets\"\xb0\x01\n" +
+	"\rRestackResult\x12!\n" +
+	"\fchangeset_id\x18\x01 \x01(\tR\vchangesetId\x12.\n" +
+	"\x13parent_changeset_id\x18\x02 \x01(\tR\x11parentChangesetId\x12.\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x16.slice.v1.RebaseStatusR\x06status\x12\x1c\n" +
+	"\tconflicts\x18\x04 \x03(\tR\tconflicts\"e\n" +
+	"\x0eConflictedFile\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\x12%\n" +
+	"\x0econflict_count\x18\x03 \x01(\x05R\rconflictCount\"\xb5\x01\n" +
 	"\x15ListChangesetsRequest\x12\x19\n" +
 	"\bslice_id\x18\x01 \x01(\tR\asliceId\x12>\n" +
 	"\rstatus_filter\x18\x02 \x01(\x0e2\x19.slice.v1.ChangesetStatusR\fstatusFilter\x12\x14\n" +
-	"\x05limit\x18\x03 \x01(\x05R\x05limit\"Q\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12+\n" +
+	"\x11include_abandoned\x18\x04 \x01(\bR\x10includeAbandoned\"Q\n" +
 	"\x16ListChangesetsResponse\x127\n" +
 	"\n" +
 	"changesets\x18\x01 \x03(\v2\x17.slice.v1.ChangesetInfoR\n" +
-	"changesets\"\xe6\x02\n" +
+	"changesets\"\x98\x04\n" +
 	"\rChangesetInfo\x12!\n" +
 	"\fchangeset_id\x18\x01 \x01(\tR\vchangesetId\x12%\n" +
 	"\x0echangeset_hash\x18\x02 \x01(\tR\rchangesetHash\x12\x19\n" +
@@ -2292,13 +4869,110 @@ const file_slice_service_proto_rawDesc = "" +
 	"created_at\x18\b \x01(\x03R\tcreatedAt\x12\x1b\n" +
 	"\tmerged_at\x18\t \x01(\x03R\bmergedAt\x12\x18\n" +
 	"\amessage\x18\n" +
-	" \x01(\tR\amessage\"q\n" +
+	" \x01(\tR\amessage\x12*\n" +
+	"\x05votes\x18\v \x03(\v2\x14.slice.v1.ReviewVoteR\x05votes\x12%\n" +
+	"\x0eabandon_reason\x18\f \x01(\tR\rabandonReason\x12.\n" +
+	"\x13parent_changeset_id\x18\r \x01(\tR\x11parentChangesetId\x12-\n" +
+	"\x06checks\x18\x0e \x03(\v2\x15.slice.v1.StatusCheckR\x06checks\"\xad\x01\n" +
+	"\vStatusCheck\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12*\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x14.slice.v1.CheckStateR\x05state\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"target_url\x18\x04 \x01(\tR\ttargetUrl\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\x03R\tupdatedAt\"\xbe\x01\n" +
+	"\x18SetChangesetCheckRequest\x12!\n" +
+	"\fchangeset_id\x18\x01 \x01(\tR\vchangesetId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12*\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x14.slice.v1.CheckStateR\x05state\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"target_url\x18\x05 \x01(\tR\ttargetUrl\"\x8a\x01\n" +
+	"\x19SetChangesetCheckResponse\x125\n" +
+	"\tchangeset\x18\x01 \x01(\v2\x17.slice.v1.ChangesetInfoR\tchangeset\x126\n" +
+	"\x17pending_required_checks\x18\x02 \x03(\tR\x15pendingRequiredChecks\"}\n" +
+	"\n" +
+	"ReviewVote\x12\x1a\n" +
+	"\breviewer\x18\x01 \x01(\tR\breviewer\x12\x1a\n" +
+	"\bapproved\x18\x02 \x01(\bR\bapproved\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\x03R\tcreatedAt\"T\n" +
+	"\x17AbandonChangesetRequest\x12!\n" +
+	"\fchangeset_id\x18\x01 \x01(\tR\vchangesetId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"Q\n" +
+	"\x18AbandonChangesetResponse\x125\n" +
+	"\tchangeset\x18\x01 \x01(\v2\x17.slice.v1.ChangesetInfoR\tchangeset\"\xe6\x02\n" +
+	"\rReviewComment\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x01 \x01(\tR\tcommentId\x12!\n" +
+	"\fchangeset_id\x18\x02 \x01(\tR\vchangesetId\x12%\n" +
+	"\x0echangeset_hash\x18\x03 \x01(\tR\rchangesetHash\x12\x12\n" +
+	"\x04path\x18\x04 \x01(\tR\x04path\x12\x12\n" +
+	"\x04line\x18\x05 \x01(\x05R\x04line\x12\x1b\n" +
+	"\tparent_id\x18\x06 \x01(\tR\bparentId\x12\x16\n" +
+	"\x06author\x18\a \x01(\tR\x06author\x12\x12\n" +
+	"\x04body\x18\b \x01(\tR\x04body\x12\x1a\n" +
+	"\bresolved\x18\t \x01(\bR\bresolved\x12\x1f\n" +
+	"\vresolved_by\x18\n" +
+	" \x01(\tR\n" +
+	"resolvedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\x03R\tcreatedAt\x12\x1f\n" +
+	"\vresolved_at\x18\f \x01(\x03R\n" +
+	"resolvedAt\"\xa7\x01\n" +
+	"\x11AddCommentRequest\x12!\n" +
+	"\fchangeset_id\x18\x01 \x01(\tR\vchangesetId\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x12\n" +
+	"\x04line\x18\x03 \x01(\x05R\x04line\x12\x1b\n" +
+	"\tparent_id\x18\x04 \x01(\tR\bparentId\x12\x16\n" +
+	"\x06author\x18\x05 \x01(\tR\x06author\x12\x12\n" +
+	"\x04body\x18\x06 \x01(\tR\x04body\"G\n" +
+	"\x12AddCommentResponse\x121\n" +
+	"\acomment\x18\x01 \x01(\v2\x17.slice.v1.ReviewCommentR\acomment\"8\n" +
+	"\x13ListCommentsRequest\x12!\n" +
+	"\fchangeset_id\x18\x01 \x01(\tR\vchangesetId\"K\n" +
+	"\x14ListCommentsResponse\x123\n" +
+	"\bcomments\x18\x01 \x03(\v2\x17.slice.v1.ReviewCommentR\bcomments\"W\n" +
+	"\x15ResolveCommentRequest\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x01 \x01(\tR\tcommentId\x12\x1f\n" +
+	"\vresolved_by\x18\x02 \x01(\tR\n" +
+	"resolvedBy\"K\n" +
+	"\x16ResolveCommentResponse\x121\n" +
+	"\acomment\x18\x01 \x01(\v2\x17.slice.v1.ReviewCommentR\acomment\"[\n" +
+	"\x13EnqueueMergeRequest\x12!\n" +
+	"\fchangeset_id\x18\x01 \x01(\tR\vchangesetId\x12!\n" +
+	"\frequested_by\x18\x02 \x01(\tR\vrequestedBy\"p\n" +
+	"\x14EnqueueMergeResponse\x12!\n" +
+	"\fchangeset_id\x18\x01 \x01(\tR\vchangesetId\x12\x19\n" +
+	"\bslice_id\x18\x02 \x01(\tR\asliceId\x12\x1a\n" +
+	"\bposition\x18\x03 \x01(\x05R\bposition\";\n" +
+	"\x16WatchMergeQueueRequest\x12!\n" +
+	"\fchangeset_id\x18\x01 \x01(\tR\vchangesetId\"\xfc\x01\n" +
+	"\x0fMergeQueueEvent\x12!\n" +
+	"\fchangeset_id\x18\x01 \x01(\tR\vchangesetId\x12\x19\n" +
+	"\bslice_id\x18\x02 \x01(\tR\asliceId\x12/\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x19.slice.v1.MergeQueueStateR\x05state\x12\x1a\n" +
+	"\bposition\x18\x04 \x01(\x05R\bposition\x12&\n" +
+	"\x0fnew_commit_hash\x18\x05 \x01(\tR\rnewCommitHash\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\x12\x1c\n" +
+	"\tconflicts\x18\a \x03(\tR\tconflicts\"o\n" +
+	"\x14ChangesetVoteRequest\x12!\n" +
+	"\fchangeset_id\x18\x01 \x01(\tR\vchangesetId\x12\x1a\n" +
+	"\breviewer\x18\x02 \x01(\tR\breviewer\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\"\x9b\x01\n" +
+	"\x15ChangesetVoteResponse\x125\n" +
+	"\tchangeset\x18\x01 \x01(\v2\x17.slice.v1.ChangesetInfoR\tchangeset\x12\x1c\n" +
+	"\tapprovals\x18\x02 \x01(\x05R\tapprovals\x12-\n" +
+	"\x12required_approvals\x18\x03 \x01(\x05R\x11requiredApprovals\"q\n" +
 	"\x14CommitHistoryRequest\x12\x19\n" +
 	"\bslice_id\x18\x01 \x01(\tR\asliceId\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12(\n" +
 	"\x10from_commit_hash\x18\x03 \x01(\tR\x0efromCommitHash\"G\n" +
 	"\x15CommitHistoryResponse\x12.\n" +
-	"\acommits\x18\x01 \x03(\v2\x14.slice.v1.CommitInfoR\acommits\"\x86\x01\n" +
+	"\acommits\x18\x01 \x03(\v2\x14.slice.v1.CommitInfoR\acommits\"\xa7\x01\n" +
 	"\n" +
 	"CommitInfo\x12\x1f\n" +
 	"\vcommit_hash\x18\x01 \x01(\tR\n" +
@@ -2306,7 +4980,9 @@ const file_slice_service_proto_rawDesc = "" +
 	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12\x1f\n" +
 	"\vparent_hash\x18\x03 \x01(\tR\n" +
 	"parentHash\x12\x18\n" +
-	"\amessage\x18\x04 \x01(\tR\amessage\")\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x1f\n" +
+	"\vfolded_hash\x18\x05 \x01(\tR\n" +
+	"foldedHash\")\n" +
 	"\fStateRequest\x12\x19\n" +
 	"\bslice_id\x18\x01 \x01(\tR\asliceId\"\x89\x01\n" +
 	"\rStateResponse\x12,\n" +
@@ -2317,7 +4993,7 @@ const file_slice_service_proto_rawDesc = "" +
 	"\x14GetRootSliceResponse\x12\x19\n" +
 	"\bslice_id\x18\x01 \x01(\tR\asliceId\x12\x1f\n" +
 	"\vcommit_hash\x18\x02 \x01(\tR\n" +
-	"commitHash\"\xbf\x01\n" +
+	"commitHash\"\xde\x01\n" +
 	"\x1cCreateSliceFromFolderRequest\x12&\n" +
 	"\x0fparent_slice_id\x18\x01 \x01(\tR\rparentSliceId\x12\x1f\n" +
 	"\vfolder_path\x18\x02 \x01(\tR\n" +
@@ -2325,11 +5001,48 @@ const file_slice_service_proto_rawDesc = "" +
 	"\fnew_slice_id\x18\x03 \x01(\tR\n" +
 	"newSliceId\x12\x12\n" +
 	"\x04name\x18\x04 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x05 \x01(\tR\vdescription\"h\n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x06 \x01(\tR\tcreatedBy\"\x89\x01\n" +
 	"\x1dCreateSliceFromFolderResponse\x12\x19\n" +
 	"\bslice_id\x18\x01 \x01(\tR\asliceId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
-	"\x05files\x18\x03 \x03(\tR\x05files*J\n" +
+	"\x05files\x18\x03 \x03(\tR\x05files\x12\x1f\n" +
+	"\vcommit_hash\x18\x04 \x01(\tR\n" +
+	"commitHash\"\x97\x01\n" +
+	"\tSliceNode\x12\x19\n" +
+	"\bslice_id\x18\x01 \x01(\tR\asliceId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12&\n" +
+	"\x0fparent_slice_id\x18\x03 \x01(\tR\rparentSliceId\x12\x14\n" +
+	"\x05depth\x18\x04 \x01(\x05R\x05depth\x12\x1d\n" +
+	"\n" +
+	"file_count\x18\x05 \x01(\x05R\tfileCount\"S\n" +
+	"\x18ListSliceChildrenRequest\x12\x19\n" +
+	"\bslice_id\x18\x01 \x01(\tR\asliceId\x12\x1c\n" +
+	"\trecursive\x18\x02 \x01(\bR\trecursive\"L\n" +
+	"\x19ListSliceChildrenResponse\x12/\n" +
+	"\bchildren\x18\x01 \x03(\v2\x13.slice.v1.SliceNodeR\bchildren\"4\n" +
+	"\x17GetSliceAncestryRequest\x12\x19\n" +
+	"\bslice_id\x18\x01 \x01(\tR\asliceId\"M\n" +
+	"\x18GetSliceAncestryResponse\x121\n" +
+	"\tancestors\x18\x01 \x03(\v2\x13.slice.v1.SliceNodeR\tancestors\"Y\n" +
+	"\x14ReparentSliceRequest\x12\x19\n" +
+	"\bslice_id\x18\x01 \x01(\tR\asliceId\x12&\n" +
+	"\x0fparent_slice_id\x18\x02 \x01(\tR\rparentSliceId\"\x93\x01\n" +
+	"\x15ReparentSliceResponse\x12\x19\n" +
+	"\bslice_id\x18\x01 \x01(\tR\asliceId\x127\n" +
+	"\x18previous_parent_slice_id\x18\x02 \x01(\tR\x15previousParentSliceId\x12&\n" +
+	"\x0fparent_slice_id\x18\x03 \x01(\tR\rparentSliceId\"E\n" +
+	"\x10FoldSliceRequest\x12\x19\n" +
+	"\bslice_id\x18\x01 \x01(\tR\asliceId\x12\x16\n" +
+	"\x06author\x18\x02 \x01(\tR\x06author\"\xc6\x01\n" +
+	"\x11FoldSliceResponse\x12&\n" +
+	"\x0fparent_slice_id\x18\x01 \x01(\tR\rparentSliceId\x12\x1f\n" +
+	"\vcommit_hash\x18\x02 \x01(\tR\n" +
+	"commitHash\x12\x14\n" +
+	"\x05files\x18\x03 \x03(\tR\x05files\x12%\n" +
+	"\x0ecommits_folded\x18\x04 \x01(\x05R\rcommitsFolded\x12+\n" +
+	"\x11reparented_slices\x18\x05 \x03(\tR\x10reparentedSlices*J\n" +
 	"\n" +
 	"ObjectType\x12\b\n" +
 	"\x04BLOB\x10\x00\x12\b\n" +
@@ -2346,17 +5059,32 @@ const file_slice_service_proto_rawDesc = "" +
 	"\x15REBASE_STATUS_SUCCESS\x10\x00\x12\x1a\n" +
 	"\x16REBASE_STATUS_CONFLICT\x10\x01\x12\x1d\n" +
 	"\x19REBASE_STATUS_NEEDS_MERGE\x10\x02\x12\x17\n" +
-	"\x13REBASE_STATUS_ERROR\x10\x03*F\n" +
+	"\x13REBASE_STATUS_ERROR\x10\x03*W\n" +
+	"\n" +
+	"CheckState\x12\x17\n" +
+	"\x13CHECK_STATE_PENDING\x10\x00\x12\x17\n" +
+	"\x13CHECK_STATE_SUCCESS\x10\x01\x12\x17\n" +
+	"\x13CHECK_STATE_FAILURE\x10\x02*\x8a\x01\n" +
+	"\x0fMergeQueueState\x12\x1c\n" +
+	"\x18MERGE_QUEUE_STATE_QUEUED\x10\x00\x12\x1d\n" +
+	"\x19MERGE_QUEUE_STATE_MERGING\x10\x01\x12\x1c\n" +
+	"\x18MERGE_QUEUE_STATE_MERGED\x10\x02\x12\x1c\n" +
+	"\x18MERGE_QUEUE_STATE_FAILED\x10\x03*U\n" +
 	"\x0fChangesetStatus\x12\v\n" +
 	"\aPENDING\x10\x00\x12\f\n" +
 	"\bAPPROVED\x10\x01\x12\f\n" +
 	"\bREJECTED\x10\x02\x12\n" +
 	"\n" +
-	"\x06MERGED\x10\x03*H\n" +
+	"\x06MERGED\x10\x03\x12\r\n" +
+	"\tABANDONED\x10\x04*i\n" +
+	"\x0eFileChangeType\x12\x1d\n" +
+	"\x19FILE_CHANGE_TYPE_MODIFIED\x10\x00\x12\x1a\n" +
+	"\x16FILE_CHANGE_TYPE_ADDED\x10\x01\x12\x1c\n" +
+	"\x18FILE_CHANGE_TYPE_DELETED\x10\x02*H\n" +
 	"\fReviewStatus\x12\x13\n" +
 	"\x0fREADY_FOR_MERGE\x10\x00\x12\x10\n" +
 	"\fNEEDS_REBASE\x10\x01\x12\x11\n" +
-	"\rHAS_CONFLICTS\x10\x022\xfc\a\n" +
+	"\rHAS_CONFLICTS\x10\x022\xf3\x11\n" +
 	"\fSliceService\x12F\n" +
 	"\rCheckoutSlice\x12\x19.slice.v1.CheckoutRequest\x1a\x1a.slice.v1.CheckoutResponse\x12V\n" +
 	"\x0fCreateChangeset\x12 .slice.v1.CreateChangesetRequest\x1a!.slice.v1.CreateChangesetResponse\x12V\n" +
@@ -2367,9 +5095,25 @@ const file_slice_service_proto_rawDesc = "" +
 	"\rGetSliceState\x12\x16.slice.v1.StateRequest\x1a\x17.slice.v1.StateResponse\x12S\n" +
 	"\x0eListChangesets\x12\x1f.slice.v1.ListChangesetsRequest\x1a .slice.v1.ListChangesetsResponse\x12M\n" +
 	"\fGetRootSlice\x12\x1d.slice.v1.GetRootSliceRequest\x1a\x1e.slice.v1.GetRootSliceResponse\x12h\n" +
-	"\x15CreateSliceFromFolder\x12&.slice.v1.CreateSliceFromFolderRequest\x1a'.slice.v1.CreateSliceFromFolderResponse\x12K\n" +
+	"\x15CreateSliceFromFolder\x12&.slice.v1.CreateSliceFromFolderRequest\x1a'.slice.v1.CreateSliceFromFolderResponse\x12\\\n" +
+	"\x11ListSliceChildren\x12\".slice.v1.ListSliceChildrenRequest\x1a#.slice.v1.ListSliceChildrenResponse\x12Y\n" +
+	"\x10GetSliceAncestry\x12!.slice.v1.GetSliceAncestryRequest\x1a\".slice.v1.GetSliceAncestryResponse\x12P\n" +
+	"\rReparentSlice\x12\x1e.slice.v1.ReparentSliceRequest\x1a\x1f.slice.v1.ReparentSliceResponse\x12D\n" +
+	"\tFoldSlice\x12\x1a.slice.v1.FoldSliceRequest\x1a\x1b.slice.v1.FoldSliceResponse\x12K\n" +
 	"\x13StreamCheckoutSlice\x12\x19.slice.v1.CheckoutRequest\x1a\x17.slice.v1.CheckoutChunk0\x01\x12V\n" +
-	"\x15StreamCreateChangeset\x12\x18.slice.v1.ChangesetChunk\x1a!.slice.v1.CreateChangesetResponse(\x01B)Z'github.com/niczy/gitslice/proto;slicev1b\x06proto3"
+	"\x15StreamCreateChangeset\x12\x18.slice.v1.ChangesetChunk\x1a!.slice.v1.CreateChangesetResponse(\x01\x12_\n" +
+	"\x12FindMissingObjects\x12#.slice.v1.FindMissingObjectsRequest\x1a$.slice.v1.FindMissingObjectsResponse\x12S\n" +
+	"\x10GetChangesetDiff\x12\x1e.slice.v1.ChangesetDiffRequest\x1a\x1f.slice.v1.ChangesetDiffResponse\x12S\n" +
+	"\x10ApproveChangeset\x12\x1e.slice.v1.ChangesetVoteRequest\x1a\x1f.slice.v1.ChangesetVoteResponse\x12R\n" +
+	"\x0fRejectChangeset\x12\x1e.slice.v1.ChangesetVoteRequest\x1a\x1f.slice.v1.ChangesetVoteResponse\x12Y\n" +
+	"\x10AbandonChangeset\x12!.slice.v1.AbandonChangesetRequest\x1a\".slice.v1.AbandonChangesetResponse\x12G\n" +
+	"\n" +
+	"AddComment\x12\x1b.slice.v1.AddCommentRequest\x1a\x1c.slice.v1.AddCommentResponse\x12M\n" +
+	"\fListComments\x12\x1d.slice.v1.ListCommentsRequest\x1a\x1e.slice.v1.ListCommentsResponse\x12S\n" +
+	"\x0eResolveComment\x12\x1f.slice.v1.ResolveCommentRequest\x1a .slice.v1.ResolveCommentResponse\x12\\\n" +
+	"\x11SetChangesetCheck\x12\".slice.v1.SetChangesetCheckRequest\x1a#.slice.v1.SetChangesetCheckResponse\x12M\n" +
+	"\fEnqueueMerge\x12\x1d.slice.v1.EnqueueMergeRequest\x1a\x1e.slice.v1.EnqueueMergeResponse\x12P\n" +
+	"\x0fWatchMergeQueue\x12 .slice.v1.WatchMergeQueueRequest\x1a\x19.slice.v1.MergeQueueEvent0\x01B)Z'github.com/niczy/gitslice/proto;slicev1b\x06proto3"
 
 var (
 	file_slice_service_proto_rawDescOnce sync.Once
@@ -2383,97 +5127,186 @@ func file_slice_service_proto_rawDescGZIP() []byte {
 	return file_slice_service_proto_rawDescData
 }
 
-var file_slice_service_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_slice_service_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_slice_service_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_slice_service_proto_msgTypes = make([]protoimpl.MessageInfo, 67)
 var file_slice_service_proto_goTypes = []any{
 	(ObjectType)(0),                       // 0: slice.v1.ObjectType
 	(MergeStatus)(0),                      // 1: slice.v1.MergeStatus
 	(RebaseStatus)(0),                     // 2: slice.v1.RebaseStatus
-	(ChangesetStatus)(0),                  // 3: slice.v1.ChangesetStatus
-	(ReviewStatus)(0),                     // 4: slice.v1.ReviewStatus
-	(*CheckoutRequest)(nil),               // 5: slice.v1.CheckoutRequest
-	(*CheckoutResponse)(nil),              // 6: slice.v1.CheckoutResponse
-	(*SliceManifest)(nil),                 // 7: slice.v1.SliceManifest
-	(*FileMetadata)(nil),                  // 8: slice.v1.FileMetadata
-	(*FileContent)(nil),                   // 9: slice.v1.FileContent
-	(*CheckoutChunk)(nil),                 // 10: slice.v1.CheckoutChunk
-	(*CreateChangesetRequest)(nil),        // 11: slice.v1.CreateChangesetRequest
-	(*CreateChangesetResponse)(nil),       // 12: slice.v1.CreateChangesetResponse
-	(*ChangesetChunk)(nil),                // 13: slice.v1.ChangesetChunk
-	(*ChangesetMetadata)(nil),             // 14: slice.v1.ChangesetMetadata
-	(*Object)(nil),                        // 15: slice.v1.Object
-	(*ReviewChangesetRequest)(nil),        // 16: slice.v1.ReviewChangesetRequest
-	(*ReviewChangesetResponse)(nil),       // 17: slice.v1.ReviewChangesetResponse
-	(*DiffSummary)(nil),                   // 18: slice.v1.DiffSummary
-	(*MergeChangesetRequest)(nil),         // 19: slice.v1.MergeChangesetRequest
-	(*MergeChangesetResponse)(nil),        // 20: slice.v1.MergeChangesetResponse
-	(*Conflict)(nil),                      // 21: slice.v1.Conflict
-	(*RebaseChangesetRequest)(nil),        // 22: slice.v1.RebaseChangesetRequest
-	(*RebaseChangesetResponse)(nil),       // 23: slice.v1.RebaseChangesetResponse
-	(*ListChangesetsRequest)(nil),         // 24: slice.v1.ListChangesetsRequest
-	(*ListChangesetsResponse)(nil),        // 25: slice.v1.ListChangesetsResponse
-	(*ChangesetInfo)(nil),                 // 26: slice.v1.ChangesetInfo
-	(*CommitHistoryRequest)(nil),          // 27: slice.v1.CommitHistoryRequest
-	(*CommitHistoryResponse)(nil),         // 28: slice.v1.CommitHistoryResponse
-	(*CommitInfo)(nil),                    // 29: slice.v1.CommitInfo
-	(*StateRequest)(nil),                  // 30: slice.v1.StateRequest
-	(*StateResponse)(nil),                 // 31: slice.v1.StateResponse
-	(*GetRootSliceRequest)(nil),           // 32: slice.v1.GetRootSliceRequest
-	(*GetRootSliceResponse)(nil),          // 33: slice.v1.GetRootSliceResponse
-	(*CreateSliceFromFolderRequest)(nil),  // 34: slice.v1.CreateSliceFromFolderRequest
-	(*CreateSliceFromFolderResponse)(nil), // 35: slice.v1.CreateSliceFromFolderResponse
+	(CheckState)(0),                       // 3: slice.v1.CheckState
+	(MergeQueueState)(0),                  // 4: slice.v1.MergeQueueState
+	(ChangesetStatus)(0),                  // 5: slice.v1.ChangesetStatus
+	(FileChangeType)(0),                   // 6: slice.v1.FileChangeType
+	(ReviewStatus)(0),                     // 7: slice.v1.ReviewStatus
+	(*CheckoutRequest)(nil),               // 8: slice.v1.CheckoutRequest
+	(*CheckoutResponse)(nil),              // 9: slice.v1.CheckoutResponse
+	(*SliceManifest)(nil),                 // 10: slice.v1.SliceManifest
+	(*FileMetadata)(nil),                  // 11: slice.v1.FileMetadata
+	(*FileContent)(nil),                   // 12: slice.v1.FileContent
+	(*CheckoutChunk)(nil),                 // 13: slice.v1.CheckoutChunk
+	(*CreateChangesetRequest)(nil),        // 14: slice.v1.CreateChangesetRequest
+	(*CreateChangesetResponse)(nil),       // 15: slice.v1.CreateChangesetResponse
+	(*ChangesetChunk)(nil),                // 16: slice.v1.ChangesetChunk
+	(*ChangesetMetadata)(nil),             // 17: slice.v1.ChangesetMetadata
+	(*FindMissingObjectsRequest)(nil),     // 18: slice.v1.FindMissingObjectsRequest
+	(*FindMissingObjectsResponse)(nil),    // 19: slice.v1.FindMissingObjectsResponse
+	(*Object)(nil),                        // 20: slice.v1.Object
+	(*ReviewChangesetRequest)(nil),        // 21: slice.v1.ReviewChangesetRequest
+	(*ReviewChangesetResponse)(nil),       // 22: slice.v1.ReviewChangesetResponse
+	(*DiffSummary)(nil),                   // 23: slice.v1.DiffSummary
+	(*ChangesetDiffRequest)(nil),          // 24: slice.v1.ChangesetDiffRequest
+	(*ChangesetDiffResponse)(nil),         // 25: slice.v1.ChangesetDiffResponse
+	(*FileDiff)(nil),                      // 26: slice.v1.FileDiff
+	(*DiffHunk)(nil),                      // 27: slice.v1.DiffHunk
+	(*MergeChangesetRequest)(nil),         // 28: slice.v1.MergeChangesetRequest
+	(*MergeChangesetResponse)(nil),        // 29: slice.v1.MergeChangesetResponse
+	(*Conflict)(nil),                      // 30: slice.v1.Conflict
+	(*RebaseChangesetRequest)(nil),        // 31: slice.v1.RebaseChangesetRequest
+	(*RebaseChangesetResponse)(nil),       // 32: slice.v1.RebaseChangesetResponse
+	(*RestackResult)(nil),                 // 33: slice.v1.RestackResult
+	(*ConflictedFile)(nil),                // 34: slice.v1.ConflictedFile
+	(*ListChangesetsRequest)(nil),         // 35: slice.v1.ListChangesetsRequest
+	(*ListChangesetsResponse)(nil),        // 36: slice.v1.ListChangesetsResponse
+	(*ChangesetInfo)(nil),                 // 37: slice.v1.ChangesetInfo
+	(*StatusCheck)(nil),                   // 38: slice.v1.StatusCheck
+	(*SetChangesetCheckRequest)(nil),      // 39: slice.v1.SetChangesetCheckRequest
+	(*SetChangesetCheckResponse)(nil),     // 40: slice.v1.SetChangesetCheckResponse
+	(*ReviewVote)(nil),                    // 41: slice.v1.ReviewVote
+	(*AbandonChangesetRequest)(nil),       // 42: slice.v1.AbandonChangesetRequest
+	(*AbandonChangesetResponse)(nil),      // 43: slice.v1.AbandonChangesetResponse
+	(*ReviewComment)(nil),                 // 44: slice.v1.ReviewComment
+	(*AddCommentRequest)(nil),             // 45: slice.v1.AddCommentRequest
+	(*AddCommentResponse)(nil),            // 46: slice.v1.AddCommentResponse
+	(*ListCommentsRequest)(nil),           // 47: slice.v1.ListCommentsRequest
+	(*ListCommentsResponse)(nil),          // 48: slice.v1.ListCommentsResponse
+	(*ResolveCommentRequest)(nil),         // 49: slice.v1.ResolveCommentRequest
+	(*ResolveCommentResponse)(nil),        // 50: slice.v1.ResolveCommentResponse
+	(*EnqueueMergeRequest)(nil),           // 51: slice.v1.EnqueueMergeRequest
+	(*EnqueueMergeResponse)(nil),          // 52: slice.v1.EnqueueMergeResponse
+	(*WatchMergeQueueRequest)(nil),        // 53: slice.v1.WatchMergeQueueRequest
+	(*MergeQueueEvent)(nil),               // 54: slice.v1.MergeQueueEvent
+	(*ChangesetVoteRequest)(nil),          // 55: slice.v1.ChangesetVoteRequest
+	(*ChangesetVoteResponse)(nil),         // 56: slice.v1.ChangesetVoteResponse
+	(*CommitHistoryRequest)(nil),          // 57: slice.v1.CommitHistoryRequest
+	(*CommitHistoryResponse)(nil),         // 58: slice.v1.CommitHistoryResponse
+	(*CommitInfo)(nil),                    // 59: slice.v1.CommitInfo
+	(*StateRequest)(nil),                  // 60: slice.v1.StateRequest
+	(*StateResponse)(nil),                 // 61: slice.v1.StateResponse
+	(*GetRootSliceRequest)(nil),           // 62: slice.v1.GetRootSliceRequest
+	(*GetRootSliceResponse)(nil),          // 63: slice.v1.GetRootSliceResponse
+	(*CreateSliceFromFolderRequest)(nil),  // 64: slice.v1.CreateSliceFromFolderRequest
+	(*CreateSliceFromFolderResponse)(nil), // 65: slice.v1.CreateSliceFromFolderResponse
+	(*SliceNode)(nil),                     // 66: slice.v1.SliceNode
+	(*ListSliceChildrenRequest)(nil),      // 67: slice.v1.ListSliceChildrenRequest
+	(*ListSliceChildrenResponse)(nil),     // 68: slice.v1.ListSliceChildrenResponse
+	(*GetSliceAncestryRequest)(nil),       // 69: slice.v1.GetSliceAncestryRequest
+	(*GetSliceAncestryResponse)(nil),      // 70: slice.v1.GetSliceAncestryResponse
+	(*ReparentSliceRequest)(nil),          // 71: slice.v1.ReparentSliceRequest
+	(*ReparentSliceResponse)(nil),         // 72: slice.v1.ReparentSliceResponse
+	(*FoldSliceRequest)(nil),              // 73: slice.v1.FoldSliceRequest
+	(*FoldSliceResponse)(nil),             // 74: slice.v1.FoldSliceResponse
 }
 var file_slice_service_proto_depIdxs = []int32{
-	7,  // 0: slice.v1.CheckoutResponse.manifest:type_name -> slice.v1.SliceManifest
-	9,  // 1: slice.v1.CheckoutResponse.files:type_name -> slice.v1.FileContent
-	8,  // 2: slice.v1.SliceManifest.file_metadata:type_name -> slice.v1.FileMetadata
-	7,  // 3: slice.v1.CheckoutChunk.manifest:type_name -> slice.v1.SliceManifest
-	9,  // 4: slice.v1.CheckoutChunk.file:type_name -> slice.v1.FileContent
-	15, // 5: slice.v1.CreateChangesetRequest.objects:type_name -> slice.v1.Object
-	3,  // 6: slice.v1.CreateChangesetResponse.status:type_name -> slice.v1.ChangesetStatus
-	14, // 7: slice.v1.ChangesetChunk.metadata:type_name -> slice.v1.ChangesetMetadata
-	15, // 8: slice.v1.ChangesetChunk.object:type_name -> slice.v1.Object
+	10, // 0: slice.v1.CheckoutResponse.manifest:type_name -> slice.v1.SliceManifest
+	12, // 1: slice.v1.CheckoutResponse.files:type_name -> slice.v1.FileContent
+	11, // 2: slice.v1.SliceManifest.file_metadata:type_name -> slice.v1.FileMetadata
+	10, // 3: slice.v1.CheckoutChunk.manifest:type_name -> slice.v1.SliceManifest
+	12, // 4: slice.v1.CheckoutChunk.file:type_name -> slice.v1.FileContent
+	20, // 5: slice.v1.CreateChangesetRequest.objects:type_name -> slice.v1.Object
+	5,  // 6: slice.v1.CreateChangesetResponse.status:type_name -> slice.v1.ChangesetStatus
+	17, // 7: slice.v1.ChangesetChunk.metadata:type_name -> slice.v1.ChangesetMetadata
+	20, // 8: slice.v1.ChangesetChunk.object:type_name -> slice.v1.Object
 	0,  // 9: slice.v1.Object.type:type_name -> slice.v1.ObjectType
-	26, // 10: slice.v1.ReviewChangesetResponse.changeset:type_name -> slice.v1.ChangesetInfo
-	18, // 11: slice.v1.ReviewChangesetResponse.diff:type_name -> slice.v1.DiffSummary
-	4,  // 12: slice.v1.ReviewChangesetResponse.review_status:type_name -> slice.v1.ReviewStatus
-	1,  // 13: slice.v1.MergeChangesetResponse.status:type_name -> slice.v1.MergeStatus
-	21, // 14: slice.v1.MergeChangesetResponse.conflicts:type_name -> slice.v1.Conflict
-	2,  // 15: slice.v1.RebaseChangesetResponse.status:type_name -> slice.v1.RebaseStatus
-	21, // 16: slice.v1.RebaseChangesetResponse.conflicts:type_name -> slice.v1.Conflict
-	3,  // 17: slice.v1.ListChangesetsRequest.status_filter:type_name -> slice.v1.ChangesetStatus
-	26, // 18: slice.v1.ListChangesetsResponse.changesets:type_name -> slice.v1.ChangesetInfo
-	3,  // 19: slice.v1.ChangesetInfo.status:type_name -> slice.v1.ChangesetStatus
-	29, // 20: slice.v1.CommitHistoryResponse.commits:type_name -> slice.v1.CommitInfo
-	5,  // 21: slice.v1.SliceService.CheckoutSlice:input_type -> slice.v1.CheckoutRequest
-	11, // 22: slice.v1.SliceService.CreateChangeset:input_type -> slice.v1.CreateChangesetRequest
-	16, // 23: slice.v1.SliceService.ReviewChangeset:input_type -> slice.v1.ReviewChangesetRequest
-	19, // 24: slice.v1.SliceService.MergeChangeset:input_type -> slice.v1.MergeChangesetRequest
-	22, // 25: slice.v1.SliceService.RebaseChangeset:input_type -> slice.v1.RebaseChangesetRequest
-	27, // 26: slice.v1.SliceService.GetSliceCommits:input_type -> slice.v1.CommitHistoryRequest
-	30, // 27: slice.v1.SliceService.GetSliceState:input_type -> slice.v1.StateRequest
-	24, // 28: slice.v1.SliceService.ListChangesets:input_type -> slice.v1.ListChangesetsRequest
-	32, // 29: slice.v1.SliceService.GetRootSlice:input_type -> slice.v1.GetRootSliceRequest
-	34, // 30: slice.v1.SliceService.CreateSliceFromFolder:input_type -> slice.v1.CreateSliceFromFolderRequest
-	5,  // 31: slice.v1.SliceService.StreamCheckoutSlice:input_type -> slice.v1.CheckoutRequest
-	13, // 32: slice.v1.SliceService.StreamCreateChangeset:input_type -> slice.v1.ChangesetChunk
-	6,  // 33: slice.v1.SliceService.CheckoutSlice:output_type -> slice.v1.CheckoutResponse
-	12, // 34: slice.v1.SliceService.CreateChangeset:output_type -> slice.v1.CreateChangesetResponse
-	17, // 35: slice.v1.SliceService.ReviewChangeset:output_type -> slice.v1.ReviewChangesetResponse
-	20, // 36: slice.v1.SliceService.MergeChangeset:output_type -> slice.v1.MergeChangesetResponse
-	23, // 37: slice.v1.SliceService.RebaseChangeset:output_type -> slice.v1.RebaseChangesetResponse
-	28, // 38: slice.v1.SliceService.GetSliceCommits:output_type -> slice.v1.CommitHistoryResponse
-	31, // 39: slice.v1.SliceService.GetSliceState:output_type -> slice.v1.StateResponse
-	25, // 40: slice.v1.SliceService.ListChangesets:output_type -> slice.v1.ListChangesetsResponse
-	33, // 41: slice.v1.SliceService.GetRootSlice:output_type -> slice.v1.GetRootSliceResponse
-	35, // 42: slice.v1.SliceService.CreateSliceFromFolder:output_type -> slice.v1.CreateSliceFromFolderResponse
-	10, // 43: slice.v1.SliceService.StreamCheckoutSlice:output_type -> slice.v1.CheckoutChunk
-	12, // 44: slice.v1.SliceService.StreamCreateChangeset:output_type -> slice.v1.CreateChangesetResponse
-	33, // [33:45] is the sub-list for method output_type
-	21, // [21:33] is the sub-list for method input_type
-	21, // [21:21] is the sub-list for extension type_name
-	21, // [21:21] is the sub-list for extension extendee
-	0,  // [0:21] is the sub-list for field type_name
+	37, // 10: slice.v1.ReviewChangesetResponse.changeset:type_name -> slice.v1.ChangesetInfo
+	23, // 11: slice.v1.ReviewChangesetResponse.diff:type_name -> slice.v1.DiffSummary
+	7,  // 12: slice.v1.ReviewChangesetResponse.review_status:type_name -> slice.v1.ReviewStatus
+	23, // 13: slice.v1.ChangesetDiffResponse.summary:type_name -> slice.v1.DiffSummary
+	26, // 14: slice.v1.ChangesetDiffResponse.files:type_name -> slice.v1.FileDiff
+	6,  // 15: slice.v1.FileDiff.change_type:type_name -> slice.v1.FileChangeType
+	27, // 16: slice.v1.FileDiff.hunks:type_name -> slice.v1.DiffHunk
+	1,  // 17: slice.v1.MergeChangesetResponse.status:type_name -> slice.v1.MergeStatus
+	30, // 18: slice.v1.MergeChangesetResponse.conflicts:type_name -> slice.v1.Conflict
+	2,  // 19: slice.v1.RebaseChangesetResponse.status:type_name -> slice.v1.RebaseStatus
+	30, // 20: slice.v1.RebaseChangesetResponse.conflicts:type_name -> slice.v1.Conflict
+	34, // 21: slice.v1.RebaseChangesetResponse.conflicted_files:type_name -> slice.v1.ConflictedFile
+	33, // 22: slice.v1.RebaseChangesetResponse.restacked:type_name -> slice.v1.RestackResult
+	2,  // 23: slice.v1.RestackResult.status:type_name -> slice.v1.RebaseStatus
+	5,  // 24: slice.v1.ListChangesetsRequest.status_filter:type_name -> slice.v1.ChangesetStatus
+	37, // 25: slice.v1.ListChangesetsResponse.changesets:type_name -> slice.v1.ChangesetInfo
+	5,  // 26: slice.v1.ChangesetInfo.status:type_name -> slice.v1.ChangesetStatus
+	41, // 27: slice.v1.ChangesetInfo.votes:type_name -> slice.v1.ReviewVote
+	38, // 28: slice.v1.ChangesetInfo.checks:type_name -> slice.v1.StatusCheck
+	3,  // 29: slice.v1.StatusCheck.state:type_name -> slice.v1.CheckState
+	3,  // 30: slice.v1.SetChangesetCheckRequest.state:type_name -> slice.v1.CheckState
+	37, // 31: slice.v1.SetChangesetCheckResponse.changeset:type_name -> slice.v1.ChangesetInfo
+	37, // 32: slice.v1.AbandonChangesetResponse.changeset:type_name -> slice.v1.ChangesetInfo
+	44, // 33: slice.v1.AddCommentResponse.comment:type_name -> slice.v1.ReviewComment
+	44, // 34: slice.v1.ListCommentsResponse.comments:type_name -> slice.v1.ReviewComment
+	44, // 35: slice.v1.ResolveCommentResponse.comment:type_name -> slice.v1.ReviewComment
+	4,  // 36: slice.v1.MergeQueueEvent.state:type_name -> slice.v1.MergeQueueState
+	37, // 37: slice.v1.ChangesetVoteResponse.changeset:type_name -> slice.v1.ChangesetInfo
+	59, // 38: slice.v1.CommitHistoryResponse.commits:type_name -> slice.v1.CommitInfo
+	66, // 39: slice.v1.ListSliceChildrenResponse.children:type_name -> slice.v1.SliceNode
+	66, // 40: slice.v1.GetSliceAncestryResponse.ancestors:type_name -> slice.v1.SliceNode
+	8,  // 41: slice.v1.SliceService.CheckoutSlice:input_type -> slice.v1.CheckoutRequest
+	14, // 42: slice.v1.SliceService.CreateChangeset:input_type -> slice.v1.CreateChangesetRequest
+	21, // 43: slice.v1.SliceService.ReviewChangeset:input_type -> slice.v1.ReviewChangesetRequest
+	28, // 44: slice.v1.SliceService.MergeChangeset:input_type -> slice.v1.MergeChangesetRequest
+	31, // 45: slice.v1.SliceService.RebaseChangeset:input_type -> slice.v1.RebaseChangesetRequest
+	57, // 46: slice.v1.SliceService.GetSliceCommits:input_type -> slice.v1.CommitHistoryRequest
+	60, // 47: slice.v1.SliceService.GetSliceState:input_type -> slice.v1.StateRequest
+	35, // 48: slice.v1.SliceService.ListChangesets:input_type -> slice.v1.ListChangesetsRequest
+	62, // 49: slice.v1.SliceService.GetRootSlice:input_type -> slice.v1.GetRootSliceRequest
+	64, // 50: slice.v1.SliceService.CreateSliceFromFolder:input_type -> slice.v1.CreateSliceFromFolderRequest
+	67, // 51: slice.v1.SliceService.ListSliceChildren:input_type -> slice.v1.ListSliceChildrenRequest
+	69, // 52: slice.v1.SliceService.GetSliceAncestry:input_type -> slice.v1.GetSliceAncestryRequest
+	71, // 53: slice.v1.SliceService.ReparentSlice:input_type -> slice.v1.ReparentSliceRequest
+	73, // 54: slice.v1.SliceService.FoldSlice:input_type -> slice.v1.FoldSliceRequest
+	8,  // 55: slice.v1.SliceService.StreamCheckoutSlice:input_type -> slice.v1.CheckoutRequest
+	16, // 56: slice.v1.SliceService.StreamCreateChangeset:input_type -> slice.v1.ChangesetChunk
+	18, // 57: slice.v1.SliceService.FindMissingObjects:input_type -> slice.v1.FindMissingObjectsRequest
+	24, // 58: slice.v1.SliceService.GetChangesetDiff:input_type -> slice.v1.ChangesetDiffRequest
+	55, // 59: slice.v1.SliceService.ApproveChangeset:input_type -> slice.v1.ChangesetVoteRequest
+	55, // 60: slice.v1.SliceService.RejectChangeset:input_type -> slice.v1.ChangesetVoteRequest
+	42, // 61: slice.v1.SliceService.AbandonChangeset:input_type -> slice.v1.AbandonChangesetRequest
+	45, // 62: slice.v1.SliceService.AddComment:input_type -> slice.v1.AddCommentRequest
+	47, // 63: slice.v1.SliceService.ListComments:input_type -> slice.v1.ListCommentsRequest
+	49, // 64: slice.v1.SliceService.ResolveComment:input_type -> slice.v1.ResolveCommentRequest
+	39, // 65: slice.v1.SliceService.SetChangesetCheck:input_type -> slice.v1.SetChangesetCheckRequest
+	51, // 66: slice.v1.SliceService.EnqueueMerge:input_type -> slice.v1.EnqueueMergeRequest
+	53, // 67: slice.v1.SliceService.WatchMergeQueue:input_type -> slice.v1.WatchMergeQueueRequest
+	9,  // 68: slice.v1.SliceService.CheckoutSlice:output_type -> slice.v1.CheckoutResponse
+	15, // 69: slice.v1.SliceService.CreateChangeset:output_type -> slice.v1.CreateChangesetResponse
+	22, // 70: slice.v1.SliceService.ReviewChangeset:output_type -> slice.v1.ReviewChangesetResponse
+	29, // 71: slice.v1.SliceService.MergeChangeset:output_type -> slice.v1.MergeChangesetResponse
+	32, // 72: slice.v1.SliceService.RebaseChangeset:output_type -> slice.v1.RebaseChangesetResponse
+	58, // 73: slice.v1.SliceService.GetSliceCommits:output_type -> slice.v1.CommitHistoryResponse
+	61, // 74: slice.v1.SliceService.GetSliceState:output_type -> slice.v1.StateResponse
+	36, // 75: slice.v1.SliceService.ListChangesets:output_type -> slice.v1.ListChangesetsResponse
+	63, // 76: slice.v1.SliceService.GetRootSlice:output_type -> slice.v1.GetRootSliceResponse
+	65, // 77: slice.v1.SliceService.CreateSliceFromFolder:output_type -> slice.v1.CreateSliceFromFolderResponse
+	68, // 78: slice.v1.SliceService.ListSliceChildren:output_type -> slice.v1.ListSliceChildrenResponse
+	70, // 79: slice.v1.SliceService.GetSliceAncestry:output_type -> slice.v1.GetSliceAncestryResponse
+	72, // 80: slice.v1.SliceService.ReparentSlice:output_type -> slice.v1.ReparentSliceResponse
+	74, // 81: slice.v1.SliceService.FoldSlice:output_type -> slice.v1.FoldSliceResponse
+	13, // 82: slice.v1.SliceService.StreamCheckoutSlice:output_type -> slice.v1.CheckoutChunk
+	15, // 83: slice.v1.SliceService.StreamCreateChangeset:output_type -> slice.v1.CreateChangesetResponse
+	19, // 84: slice.v1.SliceService.FindMissingObjects:output_type -> slice.v1.FindMissingObjectsResponse
+	25, // 85: slice.v1.SliceService.GetChangesetDiff:output_type -> slice.v1.ChangesetDiffResponse
+	56, // 86: slice.v1.SliceService.ApproveChangeset:output_type -> slice.v1.ChangesetVoteResponse
+	56, // 87: slice.v1.SliceService.RejectChangeset:output_type -> slice.v1.ChangesetVoteResponse
+	43, // 88: slice.v1.SliceService.AbandonChangeset:output_type -> slice.v1.AbandonChangesetResponse
+	46, // 89: slice.v1.SliceService.AddComment:output_type -> slice.v1.AddCommentResponse
+	48, // 90: slice.v1.SliceService.ListComments:output_type -> slice.v1.ListCommentsResponse
+	50, // 91: slice.v1.SliceService.ResolveComment:output_type -> slice.v1.ResolveCommentResponse
+	40, // 92: slice.v1.SliceService.SetChangesetCheck:output_type -> slice.v1.SetChangesetCheckResponse
+	52, // 93: slice.v1.SliceService.EnqueueMerge:output_type -> slice.v1.EnqueueMergeResponse
+	54, // 94: slice.v1.SliceService.WatchMergeQueue:output_type -> slice.v1.MergeQueueEvent
+	68, // [68:95] is the sub-list for method output_type
+	41, // [41:68] is the sub-list for method input_type
+	41, // [41:41] is the sub-list for extension type_name
+	41, // [41:41] is the sub-list for extension extendee
+	0,  // [0:41] is the sub-list for field type_name
 }
 
 func init() { file_slice_service_proto_init() }
@@ -2489,13 +5322,14 @@ func file_slice_service_proto_init() {
 		(*ChangesetChunk_Metadata)(nil),
 		(*ChangesetChunk_Object)(nil),
 	}
+	file_slice_service_proto_msgTypes[16].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_slice_service_proto_rawDesc), len(file_slice_service_proto_rawDesc)),
-			NumEnums:      5,
-			NumMessages:   31,
+			NumEnums:      8,
+			NumMessages:   67,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -29,8 +29,23 @@ const (
 	SliceService_ListChangesets_FullMethodName        = "/slice.v1.SliceService/ListChangesets"
 	SliceService_GetRootSlice_FullMethodName          = "/slice.v1.SliceService/GetRootSlice"
 	SliceService_CreateSliceFromFolder_FullMethodName = "/slice.v1.SliceService/CreateSliceFromFolder"
+	SliceService_ListSliceChildren_FullMethodName     = "/slice.v1.SliceService/ListSliceChildren"
+	SliceService_GetSliceAncestry_FullMethodName      = "/slice.v1.SliceService/GetSliceAncestry"
+	SliceService_ReparentSlice_FullMethodName         = "/slice.v1.SliceService/ReparentSlice"
+	SliceService_FoldSlice_FullMethodName             = "/slice.v1.SliceService/FoldSlice"
 	SliceService_StreamCheckoutSlice_FullMethodName   = "/slice.v1.SliceService/StreamCheckoutSlice"
 	SliceService_StreamCreateChangeset_FullMethodName = "/slice.v1.SliceService/StreamCreateChangeset"
+	SliceService_FindMissingObjects_FullMethodName    = "/slice.v1.SliceService/FindMissingObjects"
+	SliceService_GetChangesetDiff_FullMethodName      = "/slice.v1.SliceService/GetChangesetDiff"
+	SliceService_ApproveChangeset_FullMethodName      = "/slice.v1.SliceService/ApproveChangeset"
+	SliceService_RejectChangeset_FullMethodName       = "/slice.v1.SliceService/RejectChangeset"
+	SliceService_AbandonChangeset_FullMethodName      = "/slice.v1.SliceService/AbandonChangeset"
+	SliceService_AddComment_FullMethodName            = "/slice.v1.SliceService/AddComment"
+	SliceService_ListComments_FullMethodName          = "/slice.v1.SliceService/ListComments"
+	SliceService_ResolveComment_FullMethodName        = "/slice.v1.SliceService/ResolveComment"
+	SliceService_SetChangesetCheck_FullMethodName     = "/slice.v1.SliceService/SetChangesetCheck"
+	SliceService_EnqueueMerge_FullMethodName          = "/slice.v1.SliceService/EnqueueMerge"
+	SliceService_WatchMergeQueue_FullMethodName       = "/slice.v1.SliceService/WatchMergeQueue"
 )
 
 // SliceServiceClient is the client API for SliceService service.
@@ -55,12 +70,43 @@ type SliceServiceClient interface {
 	ListChangesets(ctx context.Context, in *ListChangesetsRequest, opts ...grpc.CallOption) (*ListChangesetsResponse, error)
 	// Get root slice info
 	GetRootSlice(ctx context.Context, in *GetRootSliceRequest, opts ...grpc.CallOption) (*GetRootSliceResponse, error)
-	// Create a new slice from an existing folder
+	// Create a new slice from an existing folder, moving the folder's files
+	// out of the parent slice
 	CreateSliceFromFolder(ctx context.Context, in *CreateSliceFromFolderRequest, opts ...grpc.CallOption) (*CreateSliceFromFolderResponse, error)
+	// List the slices under a slice, depth first
+	ListSliceChildren(ctx context.Context, in *ListSliceChildrenRequest, opts ...grpc.CallOption) (*ListSliceChildrenResponse, error)
+	// List a slice's ancestors from its parent up to the root slice
+	GetSliceAncestry(ctx context.Context, in *GetSliceAncestryRequest, opts ...grpc.CallOption) (*GetSliceAncestryResponse, error)
+	// Move a slice under a different parent
+	ReparentSlice(ctx context.Context, in *ReparentSliceRequest, opts ...grpc.CallOption) (*ReparentSliceResponse, error)
+	// Fold a slice's files and history back into its parent
+	FoldSlice(ctx context.Context, in *FoldSliceRequest, opts ...grpc.CallOption) (*FoldSliceResponse, error)
 	// Stream checkout for large slices (server streaming)
 	StreamCheckoutSlice(ctx context.Context, in *CheckoutRequest, opts ...grpc.CallOption) (SliceService_StreamCheckoutSliceClient, error)
 	// Stream changeset creation (client streaming)
 	StreamCreateChangeset(ctx context.Context, opts ...grpc.CallOption) (SliceService_StreamCreateChangesetClient, error)
+	// Report which objects the server does not have yet
+	FindMissingObjects(ctx context.Context, in *FindMissingObjectsRequest, opts ...grpc.CallOption) (*FindMissingObjectsResponse, error)
+	// Get per-file unified diff hunks for a changeset
+	GetChangesetDiff(ctx context.Context, in *ChangesetDiffRequest, opts ...grpc.CallOption) (*ChangesetDiffResponse, error)
+	// Record a reviewer's approval of a changeset
+	ApproveChangeset(ctx context.Context, in *ChangesetVoteRequest, opts ...grpc.CallOption) (*ChangesetVoteResponse, error)
+	// Record a reviewer's rejection of a changeset
+	RejectChangeset(ctx context.Context, in *ChangesetVoteRequest, opts ...grpc.CallOption) (*ChangesetVoteResponse, error)
+	// Close a changeset that will not be merged
+	AbandonChangeset(ctx context.Context, in *AbandonChangesetRequest, opts ...grpc.CallOption) (*AbandonChangesetResponse, error)
+	// Leave a review comment on a changeset, optionally anchored to a file line
+	AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*AddCommentResponse, error)
+	// List a changeset's review comments, oldest first
+	ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error)
+	// Mark a comment thread as resolved
+	ResolveComment(ctx context.Context, in *ResolveCommentRequest, opts ...grpc.CallOption) (*ResolveCommentResponse, error)
+	// Report the state of a named status check, such as a CI run, on a changeset
+	SetChangesetCheck(ctx context.Context, in *SetChangesetCheckRequest, opts ...grpc.CallOption) (*SetChangesetCheckResponse, error)
+	// Queue an approved changeset to be rebased and merged in turn
+	EnqueueMerge(ctx context.Context, in *EnqueueMergeRequest, opts ...grpc.CallOption) (*EnqueueMergeResponse, error)
+	// Stream a queued changeset's position and outcome (server streaming)
+	WatchMergeQueue(ctx context.Context, in *WatchMergeQueueRequest, opts ...grpc.CallOption) (SliceService_WatchMergeQueueClient, error)
 }
 
 type sliceServiceClient struct {
@@ -161,6 +207,42 @@ func (c *sliceServiceClient) CreateSliceFromFolder(ctx context.Context, in *Crea
 	return out, nil
 }
 
+func (c *sliceServiceClient) ListSliceChildren(ctx context.Context, in *ListSliceChildrenRequest, opts ...grpc.CallOption) (*ListSliceChildrenResponse, error) {
+	out := new(ListSliceChildrenResponse)
+	err := c.cc.Invoke(ctx, SliceService_ListSliceChildren_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceServiceClient) GetSliceAncestry(ctx context.Context, in *GetSliceAncestryRequest, opts ...grpc.CallOption) (*GetSliceAncestryResponse, error) {
+	out := new(GetSliceAncestryResponse)
+	err := c.cc.Invoke(ctx, SliceService_GetSliceAncestry_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceServiceClient) ReparentSlice(ctx context.Context, in *ReparentSliceRequest, opts ...grpc.CallOption) (*ReparentSliceResponse, error) {
+	out := new(ReparentSliceResponse)
+	err := c.cc.Invoke(ctx, SliceService_ReparentSlice_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceServiceClient) FoldSlice(ctx context.Context, in *FoldSliceRequest, opts ...grpc.CallOption) (*FoldSliceResponse, error) {
+	out := new(FoldSliceResponse)
+	err := c.cc.Invoke(ctx, SliceService_FoldSlice_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *sliceServiceClient) StreamCheckoutSlice(ctx context.Context, in *CheckoutRequest, opts ...grpc.CallOption) (SliceService_StreamCheckoutSliceClient, error) {
 	stream, err := c.cc.NewStream(ctx, &SliceService_ServiceDesc.Streams[0], SliceService_StreamCheckoutSlice_FullMethodName, opts...)
 	if err != nil {
@@ -227,6 +309,128 @@ func (x *sliceServiceStreamCreateChangesetClient) CloseAndRecv() (*CreateChanges
 	return m, nil
 }
 
+func (c *sliceServiceClient) FindMissingObjects(ctx context.Context, in *FindMissingObjectsRequest, opts ...grpc.CallOption) (*FindMissingObjectsResponse, error) {
+	out := new(FindMissingObjectsResponse)
+	err := c.cc.Invoke(ctx, SliceService_FindMissingObjects_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceServiceClient) GetChangesetDiff(ctx context.Context, in *ChangesetDiffRequest, opts ...grpc.CallOption) (*ChangesetDiffResponse, error) {
+	out := new(ChangesetDiffResponse)
+	err := c.cc.Invoke(ctx, SliceService_GetChangesetDiff_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceServiceClient) ApproveChangeset(ctx context.Context, in *ChangesetVoteRequest, opts ...grpc.CallOption) (*ChangesetVoteResponse, error) {
+	out := new(ChangesetVoteResponse)
+	err := c.cc.Invoke(ctx, SliceService_ApproveChangeset_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceServiceClient) RejectChangeset(ctx context.Context, in *ChangesetVoteRequest, opts ...grpc.CallOption) (*ChangesetVoteResponse, error) {
+	out := new(ChangesetVoteResponse)
+	err := c.cc.Invoke(ctx, SliceService_RejectChangeset_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceServiceClient) AbandonChangeset(ctx context.Context, in *AbandonChangesetRequest, opts ...grpc.CallOption) (*AbandonChangesetResponse, error) {
+	out := new(AbandonChangesetResponse)
+	err := c.cc.Invoke(ctx, SliceService_AbandonChangeset_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceServiceClient) AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*AddCommentResponse, error) {
+	out := new(AddCommentResponse)
+	err := c.cc.Invoke(ctx, SliceService_AddComment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceServiceClient) ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error) {
+	out := new(ListCommentsResponse)
+	err := c.cc.Invoke(ctx, SliceService_ListComments_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceServiceClient) ResolveComment(ctx context.Context, in *ResolveCommentRequest, opts ...grpc.CallOption) (*ResolveCommentResponse, error) {
+	out := new(ResolveCommentResponse)
+	err := c.cc.Invoke(ctx, SliceService_ResolveComment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceServiceClient) SetChangesetCheck(ctx context.Context, in *SetChangesetCheckRequest, opts ...grpc.CallOption) (*SetChangesetCheckResponse, error) {
+	out := new(SetChangesetCheckResponse)
+	err := c.cc.Invoke(ctx, SliceService_SetChangesetCheck_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceServiceClient) EnqueueMerge(ctx context.Context, in *EnqueueMergeRequest, opts ...grpc.CallOption) (*EnqueueMergeResponse, error) {
+	out := new(EnqueueMergeResponse)
+	err := c.cc.Invoke(ctx, SliceService_EnqueueMerge_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceServiceClient) WatchMergeQueue(ctx context.Context, in *WatchMergeQueueRequest, opts ...grpc.CallOption) (SliceService_WatchMergeQueueClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SliceService_ServiceDesc.Streams[2], SliceService_WatchMergeQueue_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sliceServiceWatchMergeQueueClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SliceService_WatchMergeQueueClient interface {
+	Recv() (*MergeQueueEvent, error)
+	grpc.ClientStream
+}
+
+type sliceServiceWatchMergeQueueClient struct {
+	grpc.ClientStream
+}
+
+func (x *sliceServiceWatchMergeQueueClient) Recv() (*MergeQueueEvent, error) {
+	m := new(MergeQueueEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // SliceServiceServer is the server API for SliceService service.
 // All implementations must embed UnimplementedSliceServiceServer
 // for forward compatibility
@@ -249,12 +453,43 @@ type SliceServiceServer interface {
 	ListChangesets(context.Context, *ListChangesetsRequest) (*ListChangesetsResponse, error)
 	// Get root slice info
 	GetRootSlice(context.Context, *GetRootSliceRequest) (*GetRootSliceResponse, error)
-	// Create a new slice from an existing folder
+	// Create a new slice from an existing folder, moving the folder's files
+	// out of the parent slice
 	CreateSliceFromFolder(context.Context, *CreateSliceFromFolderRequest) (*CreateSliceFromFolderResponse, error)
+	// List the slices under a slice, depth first
+	ListSliceChildren(context.Context, *ListSliceChildrenRequest) (*ListSliceChildrenResponse, error)
+	// List a slice's ancestors from its parent up to the root slice
+	GetSliceAncestry(context.Context, *GetSliceAncestryRequest) (*GetSliceAncestryResponse, error)
+	// Move a slice under a different parent
+	ReparentSlice(context.Context, *ReparentSliceRequest) (*ReparentSliceResponse, error)
+	// Fold a slice's files and history back into its parent
+	FoldSlice(context.Context, *FoldSliceRequest) (*FoldSliceResponse, error)
 	// Stream checkout for large slices (server streaming)
 	StreamCheckoutSlice(*CheckoutRequest, SliceService_StreamCheckoutSliceServer) error
 	// Stream changeset creation (client streaming)
 	StreamCreateChangeset(SliceService_StreamCreateChangesetServer) error
+	// Report which objects the server does not have yet
+	FindMissingObjects(context.Context, *FindMissingObjectsRequest) (*FindMissingObjectsResponse, error)
+	// Get per-file unified diff hunks for a changeset
+	GetChangesetDiff(context.Context, *ChangesetDiffRequest) (*ChangesetDiffResponse, error)
+	// Record a reviewer's approval of a changeset
+	ApproveChangeset(context.Context, *ChangesetVoteRequest) (*ChangesetVoteResponse, error)
+	// Record a reviewer's rejection of a changeset
+	RejectChangeset(context.Context, *ChangesetVoteRequest) (*ChangesetVoteResponse, error)
+	// Close a changeset that will not be merged
+	AbandonChangeset(context.Context, *AbandonChangesetRequest) (*AbandonChangesetResponse, error)
+	// Leave a review comment on a changeset, optionally anchored to a file line
+	AddComment(context.Context, *AddCommentRequest) (*AddCommentResponse, error)
+	// List a changeset's review comments, oldest first
+	ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error)
+	// Mark a comment thread as resolved
+	ResolveComment(context.Context, *ResolveCommentRequest) (*ResolveCommentResponse, error)
+	// Report the state of a named status check, such as a CI run, on a changeset
+	SetChangesetCheck(context.Context, *SetChangesetCheckRequest) (*SetChangesetCheckResponse, error)
+	// Queue an approved changeset to be rebased and merged in turn
+	EnqueueMerge(context.Context, *EnqueueMergeRequest) (*EnqueueMergeResponse, error)
+	// Stream a queued changeset's position and outcome (server streaming)
+	WatchMergeQueue(*WatchMergeQueueRequest, SliceService_WatchMergeQueueServer) error
 	mustEmbedUnimplementedSliceServiceServer()
 }
 
@@ -292,12 +527,57 @@ func (UnimplementedSliceServiceServer) GetRootSlice(context.Context, *GetRootSli
 func (UnimplementedSliceServiceServer) CreateSliceFromFolder(context.Context, *CreateSliceFromFolderRequest) (*CreateSliceFromFolderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateSliceFromFolder not implemented")
 }
+func (UnimplementedSliceServiceServer) ListSliceChildren(context.Context, *ListSliceChildrenRequest) (*ListSliceChildrenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSliceChildren not implemented")
+}
+func (UnimplementedSliceServiceServer) GetSliceAncestry(context.Context, *GetSliceAncestryRequest) (*GetSliceAncestryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSliceAncestry not implemented")
+}
+func (UnimplementedSliceServiceServer) ReparentSlice(context.Context, *ReparentSliceRequest) (*ReparentSliceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReparentSlice not implemented")
+}
+func (UnimplementedSliceServiceServer) FoldSlice(context.Context, *FoldSliceRequest) (*FoldSliceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FoldSlice not implemented")
+}
 func (UnimplementedSliceServiceServer) StreamCheckoutSlice(*CheckoutRequest, SliceService_StreamCheckoutSliceServer) error {
 	return status.Errorf(codes.Unimplemented, "method StreamCheckoutSlice not implemented")
 }
 func (UnimplementedSliceServiceServer) StreamCreateChangeset(SliceService_StreamCreateChangesetServer) error {
 	return status.Errorf(codes.Unimplemented, "method StreamCreateChangeset not implemented")
 }
+func (UnimplementedSliceServiceServer) FindMissingObjects(context.Context, *FindMissingObjectsRequest) (*FindMissingObjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindMissingObjects not implemented")
+}
+func (UnimplementedSliceServiceServer) GetChangesetDiff(context.Context, *ChangesetDiffRequest) (*ChangesetDiffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChangesetDiff not implemented")
+}
+func (UnimplementedSliceServiceServer) ApproveChangeset(context.Context, *ChangesetVoteRequest) (*ChangesetVoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveChangeset not implemented")
+}
+func (UnimplementedSliceServiceServer) RejectChangeset(context.Context, *ChangesetVoteRequest) (*ChangesetVoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectChangeset not implemented")
+}
+func (UnimplementedSliceServiceServer) AbandonChangeset(context.Context, *AbandonChangesetRequest) (*AbandonChangesetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbandonChangeset not implemented")
+}
+func (UnimplementedSliceServiceServer) AddComment(context.Context, *AddCommentRequest) (*AddCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddComment not implemented")
+}
+func (UnimplementedSliceServiceServer) ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListComments not implemented")
+}
+func (UnimplementedSliceServiceServer) ResolveComment(context.Context, *ResolveCommentRequest) (*ResolveCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveComment not implemented")
+}
+func (UnimplementedSliceServiceServer) SetChangesetCheck(context.Context, *SetChangesetCheckRequest) (*SetChangesetCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetChangesetCheck not implemented")
+}
+func (UnimplementedSliceServiceServer) EnqueueMerge(context.Context, *EnqueueMergeRequest) (*EnqueueMergeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnqueueMerge not implemented")
+}
+func (UnimplementedSliceServiceServer) WatchMergeQueue(*WatchMergeQueueRequest, SliceService_WatchMergeQueueServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchMergeQueue not implemented")
+}
 func (UnimplementedSliceServiceServer) mustEmbedUnimplementedSliceServiceServer() {}
 
 // UnsafeSliceServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -491,6 +771,78 @@ func _SliceService_CreateSliceFromFolder_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SliceService_ListSliceChildren_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSliceChildrenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceServiceServer).ListSliceChildren(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SliceService_ListSliceChildren_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceServiceServer).ListSliceChildren(ctx, req.(*ListSliceChildrenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceService_GetSliceAncestry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSliceAncestryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceServiceServer).GetSliceAncestry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SliceService_GetSliceAncestry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceServiceServer).GetSliceAncestry(ctx, req.(*GetSliceAncestryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceService_ReparentSlice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReparentSliceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceServiceServer).ReparentSlice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SliceService_ReparentSlice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceServiceServer).ReparentSlice(ctx, req.(*ReparentSliceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceService_FoldSlice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FoldSliceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceServiceServer).FoldSlice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SliceService_FoldSlice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceServiceServer).FoldSlice(ctx, req.(*FoldSliceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SliceService_StreamCheckoutSlice_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(CheckoutRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -538,6 +890,207 @@ func (x *sliceServiceStreamCreateChangesetServer) Recv() (*ChangesetChunk, error
 	return m, nil
 }
 
+func _SliceService_FindMissingObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindMissingObjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceServiceServer).FindMissingObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SliceService_FindMissingObjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceServiceServer).FindMissingObjects(ctx, req.(*FindMissingObjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceService_GetChangesetDiff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangesetDiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceServiceServer).GetChangesetDiff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SliceService_GetChangesetDiff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceServiceServer).GetChangesetDiff(ctx, req.(*ChangesetDiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceService_ApproveChangeset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangesetVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceServiceServer).ApproveChangeset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SliceService_ApproveChangeset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceServiceServer).ApproveChangeset(ctx, req.(*ChangesetVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceService_RejectChangeset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangesetVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceServiceServer).RejectChangeset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SliceService_RejectChangeset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceServiceServer).RejectChangeset(ctx, req.(*ChangesetVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceService_AbandonChangeset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbandonChangesetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceServiceServer).AbandonChangeset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SliceService_AbandonChangeset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceServiceServer).AbandonChangeset(ctx, req.(*AbandonChangesetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceService_AddComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceServiceServer).AddComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SliceService_AddComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceServiceServer).AddComment(ctx, req.(*AddCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceService_ListComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceServiceServer).ListComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SliceService_ListComments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceServiceServer).ListComments(ctx, req.(*ListCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceService_ResolveComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceServiceServer).ResolveComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SliceService_ResolveComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceServiceServer).ResolveComment(ctx, req.(*ResolveCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceService_SetChangesetCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetChangesetCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceServiceServer).SetChangesetCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SliceService_SetChangesetCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceServiceServer).SetChangesetCheck(ctx, req.(*SetChangesetCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceService_EnqueueMerge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnqueueMergeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceServiceServer).EnqueueMerge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SliceService_EnqueueMerge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceServiceServer).EnqueueMerge(ctx, req.(*EnqueueMergeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceService_WatchMergeQueue_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchMergeQueueRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SliceServiceServer).WatchMergeQueue(m, &sliceServiceWatchMergeQueueServer{stream})
+}
+
+type SliceService_WatchMergeQueueServer interface {
+	Send(*MergeQueueEvent) error
+	grpc.ServerStream
+}
+
+type sliceServiceWatchMergeQueueServer struct {
+	grpc.ServerStream
+}
+
+func (x *sliceServiceWatchMergeQueueServer) Send(m *MergeQueueEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // SliceService_ServiceDesc is the grpc.ServiceDesc for SliceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -585,6 +1138,62 @@ var SliceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateSliceFromFolder",
 			Handler:    _SliceService_CreateSliceFromFolder_Handler,
 		},
+		{
+			MethodName: "ListSliceChildren",
+			Handler:    _SliceService_ListSliceChildren_Handler,
+		},
+		{
+			MethodName: "GetSliceAncestry",
+			Handler:    _SliceService_GetSliceAncestry_Handler,
+		},
+		{
+			MethodName: "ReparentSlice",
+			Handler:    _SliceService_ReparentSlice_Handler,
+		},
+		{
+			MethodName: "FoldSlice",
+			Handler:    _SliceService_FoldSlice_Handler,
+		},
+		{
+			MethodName: "FindMissingObjects",
+			Handler:    _SliceService_FindMissingObjects_Handler,
+		},
+		{
+			MethodName: "GetChangesetDiff",
+			Handler:    _SliceService_GetChangesetDiff_Handler,
+		},
+		{
+			MethodName: "ApproveChangeset",
+			Handler:    _SliceService_ApproveChangeset_Handler,
+		},
+		{
+			MethodName: "RejectChangeset",
+			Handler:    _SliceService_RejectChangeset_Handler,
+		},
+		{
+			MethodName: "AbandonChangeset",
+			Handler:    _SliceService_AbandonChangeset_Handler,
+		},
+		{
+			MethodName: "AddComment",
+			Handler:    _SliceService_AddComment_Handler,
+		},
+		{
+			MethodName: "ListComments",
+			Handler:    _SliceService_ListComments_Handler,
+		},
+		{
+			MethodName: "ResolveComment",
+			Handler:    _SliceService_ResolveComment_Handler,
+		},
+		{
+			MethodName: "SetChangesetCheck",
+			Handler:    _SliceService_SetChangesetCheck_Handler,
+		},
+		{
+			MethodName: "EnqueueMerge",
+			Handler:    _SliceService_EnqueueMerge_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -597,6 +1206,11 @@ var SliceService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _SliceService_StreamCreateChangeset_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "WatchMergeQueue",
+			Handler:       _SliceService_WatchMergeQueue_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "slice_service.proto",
 }
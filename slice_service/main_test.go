@@ -2,13 +2,22 @@ package main
 
 import (
 	"context"
+	"io"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/niczy/gitslice/internal/models"
+	"github.com/niczy/gitslice/internal/objects"
 	"github.com/niczy/gitslice/internal/services/slice"
 	"github.com/niczy/gitslice/internal/storage"
 	slicev1 "github.com/niczy/gitslice/proto/slice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 const statusFilterAll = slicev1.ChangesetStatus(-1)
@@ -84,3 +93,1724 @@ func TestListChangesetsFiltersByStatus(t *testing.T) {
 		}
 	})
 }
+
+func TestCreateChangesetStoresUploadedObjects(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1"}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+
+	content := []byte("package main\n")
+	blobHash := objects.HashBlob(content)
+	treeData, err := objects.EncodeTree(&models.Tree{Entries: []models.TreeEntry{{Name: "main.go", Mode: models.TreeModeFile, Hash: blobHash}}})
+	if err != nil {
+		t.Fatalf("EncodeTree failed: %v", err)
+	}
+	treeHash, _ := objects.Hash(models.ObjectTypeTree, treeData)
+
+	resp, err := srv.CreateChangeset(ctx, &slicev1.CreateChangesetRequest{
+		SliceId: "slice-1",
+		Objects: []*slicev1.Object{
+			{Type: slicev1.ObjectType_BLOB, Hash: blobHash, Data: content},
+			{Type: slicev1.ObjectType_TREE, Hash: treeHash, Data: treeData},
+		},
+		Author:  "alice",
+		Message: "add main",
+	})
+	if err != nil {
+		t.Fatalf("CreateChangeset returned error: %v", err)
+	}
+
+	cs, err := st.GetChangeset(ctx, resp.ChangesetId)
+	if err != nil {
+		t.Fatalf("GetChangeset failed: %v", err)
+	}
+	if cs.TreeHash != treeHash {
+		t.Fatalf("expected tree hash %s, got %s", treeHash, cs.TreeHash)
+	}
+	if len(cs.ModifiedFiles) != 1 || cs.ModifiedFiles[0] != "main.go" {
+		t.Fatalf("expected modified files derived from tree, got %v", cs.ModifiedFiles)
+	}
+	if data, err := storage.ReadBlob(ctx, st, blobHash); err != nil || string(data) != string(content) {
+		t.Fatalf("expected blob to be stored: %v", err)
+	}
+
+	_, err = srv.CreateChangeset(ctx, &slicev1.CreateChangesetRequest{
+		SliceId: "slice-1",
+		Objects: []*slicev1.Object{{Type: slicev1.ObjectType_BLOB, Hash: blobHash, Data: []byte("tampered")}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for mismatched hash, got %v", err)
+	}
+}
+
+func TestMergeAndCheckoutSliceHistory(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1"}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+
+	checkout := func(ref string) map[string]string {
+		t.Helper()
+		resp, err := srv.CheckoutSlice(ctx, &slicev1.CheckoutRequest{SliceId: "slice-1", CommitHash: ref})
+		if err != nil {
+			t.Fatalf("CheckoutSlice returned error: %v", err)
+		}
+		files := make(map[string]string)
+		for _, file := range resp.Files {
+			files[file.FileId] = string(file.Content)
+		}
+		return files
+	}
+
+	first := mergeFiles(t, st, srv, map[string]string{"main.go": "v1", "docs/README.md": "readme"})
+	if got := checkout("HEAD"); len(got) != 2 || got["main.go"] != "v1" || got["docs/README.md"] != "readme" {
+		t.Fatalf("unexpected checkout after first merge: %v", got)
+	}
+
+	second := mergeFiles(t, st, srv, map[string]string{"main.go": "v2"}, "docs/README.md")
+	if got := checkout("HEAD"); len(got) != 1 || got["main.go"] != "v2" {
+		t.Fatalf("unexpected checkout after second merge: %v", got)
+	}
+
+	commit, err := storage.ReadCommit(ctx, st, second)
+	if err != nil {
+		t.Fatalf("ReadCommit failed: %v", err)
+	}
+	if commit.ParentHash != first || commit.SliceID != "slice-1" || commit.Author != "alice" {
+		t.Fatalf("unexpected commit object: %+v", commit)
+	}
+
+	state, err := srv.GetSliceState(ctx, &slicev1.StateRequest{SliceId: "slice-1"})
+	if err != nil || state.LatestCommitHash != second {
+		t.Fatalf("expected head %s, got %+v (%v)", second, state, err)
+	}
+
+	if got := checkout(first); len(got) != 2 || got["main.go"] != "v1" || got["docs/README.md"] != "readme" {
+		t.Fatalf("unexpected historical checkout: %v", got)
+	}
+	if got := checkout(first[:12]); got["main.go"] != "v1" {
+		t.Fatalf("unexpected checkout by prefix: %v", got)
+	}
+	_, err = srv.CheckoutSlice(ctx, &slicev1.CheckoutRequest{SliceId: "slice-1", CommitHash: objects.HashBlob([]byte("unknown"))})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for unknown commit, got %v", err)
+	}
+}
+
+func TestMergeOnLegacyHeadRecordsTheStoredCommit(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1"}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	// A head recorded before commits were objects
+	legacy := &models.Commit{CommitHash: "commit-1", Message: "legacy", Timestamp: time.Now()}
+	if err := st.AddSliceCommit(ctx, "slice-1", legacy); err != nil {
+		t.Fatalf("AddSliceCommit failed: %v", err)
+	}
+	if err := st.UpdateSliceMetadata(ctx, "slice-1", &models.SliceMetadata{SliceID: "slice-1", HeadCommitHash: legacy.CommitHash}); err != nil {
+		t.Fatalf("UpdateSliceMetadata failed: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+
+	head := mergeFiles(t, st, srv, map[string]string{"main.go": "v1"})
+	stored, err := storage.ReadCommit(ctx, st, head)
+	if err != nil {
+		t.Fatalf("ReadCommit failed: %v", err)
+	}
+	history, err := srv.GetSliceCommits(ctx, &slicev1.CommitHistoryRequest{SliceId: "slice-1"})
+	if err != nil || len(history.Commits) != 2 {
+		t.Fatalf("expected the merge on top of the legacy commit: %v %+v", err, history)
+	}
+	if got := history.Commits[0]; got.CommitHash != head || got.ParentHash != stored.ParentHash {
+		t.Fatalf("history records parent %q for %s, but the commit object has %q", got.ParentHash, head, stored.ParentHash)
+	}
+}
+
+// createFiles uploads the given path -> content map as a changeset on slice-1
+// and returns its ID. Paths listed in deleted are recorded as modified but left
+// out of the uploaded tree.
+func createFiles(t *testing.T, st storage.Storage, srv slicev1.SliceServiceServer, files map[string]string, deleted ...string) string {
+	t.Helper()
+	return createFilesOnBase(t, st, srv, "", files, deleted...)
+}
+
+// createFilesOnBase is createFiles with an explicit base commit.
+func createFilesOnBase(t *testing.T, st storage.Storage, srv slicev1.SliceServiceServer, base string, files map[string]string, deleted ...string) string {
+	t.Helper()
+	return createFilesWith(t, st, srv, &slicev1.CreateChangesetRequest{BaseCommitHash: base}, files, deleted...)
+}
+
+// createFilesWith is createFiles starting from a partly filled request.
+func createFilesWith(t *testing.T, st storage.Storage, srv slicev1.SliceServiceServer, req *slicev1.CreateChangesetRequest, files map[string]string, deleted ...string) string {
+	t.Helper()
+	ctx := context.Background()
+
+	if req.SliceId == "" {
+		req.SliceId = "slice-1"
+	}
+	req.Author, req.Message = "alice", "update"
+	snapshot := make(map[string]string)
+	for path, content := range files {
+		blobHash := objects.HashBlob([]byte(content))
+		snapshot[path] = blobHash
+		req.Objects = append(req.Objects, &slicev1.Object{Type: slicev1.ObjectType_BLOB, Hash: blobHash, Data: []byte(content)})
+	}
+	treeHash, err := storage.WriteSnapshot(ctx, st, snapshot)
+	if err != nil {
+		t.Fatalf("WriteSnapshot failed: %v", err)
+	}
+	tree, err := st.GetObject(ctx, treeHash)
+	if err != nil {
+		t.Fatalf("GetObject failed: %v", err)
+	}
+	req.Objects = append(req.Objects, &slicev1.Object{Type: slicev1.ObjectType_TREE, Hash: treeHash, Data: tree.Data})
+	req.ModifiedFiles = append(storage.SnapshotPaths(snapshot), deleted...)
+
+	created, err := srv.CreateChangeset(ctx, req)
+	if err != nil {
+		t.Fatalf("CreateChangeset returned error: %v", err)
+	}
+	return created.ChangesetId
+}
+
+// mergeFiles creates a changeset with createFiles, merges it and returns the
+// new commit hash.
+func mergeFiles(t *testing.T, st storage.Storage, srv slicev1.SliceServiceServer, files map[string]string, deleted ...string) string {
+	t.Helper()
+
+	changesetID := createFiles(t, st, srv, files, deleted...)
+	merged, err := srv.MergeChangeset(context.Background(), &slicev1.MergeChangesetRequest{ChangesetId: changesetID})
+	if err != nil || merged.Status != slicev1.MergeStatus_MERGE_STATUS_SUCCESS {
+		t.Fatalf("MergeChangeset failed: %v %+v", err, merged)
+	}
+	return merged.NewCommitHash
+}
+
+type checkoutStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks []*slicev1.CheckoutChunk
+}
+
+func (s *checkoutStream) Context() context.Context { return s.ctx }
+
+func (s *checkoutStream) Send(chunk *slicev1.CheckoutChunk) error {
+	s.chunks = append(s.chunks, chunk)
+	return nil
+}
+
+func TestStreamCheckoutSliceChunksLargeFiles(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1"}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+
+	large := strings.Repeat("a", 5<<20/2)
+	mergeFiles(t, st, srv, map[string]string{"assets/large.bin": large, "small.txt": "small"})
+
+	stream := &checkoutStream{ctx: ctx}
+	if err := srv.StreamCheckoutSlice(&slicev1.CheckoutRequest{SliceId: "slice-1"}, stream); err != nil {
+		t.Fatalf("StreamCheckoutSlice returned error: %v", err)
+	}
+
+	if len(stream.chunks) == 0 || stream.chunks[0].GetManifest() == nil {
+		t.Fatalf("expected manifest as the first chunk")
+	}
+	if got := len(stream.chunks[0].GetManifest().FileMetadata); got != 2 {
+		t.Fatalf("expected 2 files in manifest, got %d", got)
+	}
+
+	contents := make(map[string]string)
+	largeChunks := 0
+	for _, chunk := range stream.chunks[1:] {
+		file := chunk.GetFile()
+		if file == nil {
+			t.Fatalf("expected only file chunks after the manifest")
+		}
+		if len(file.Content) > 1<<20 {
+			t.Fatalf("chunk for %s carries %d bytes", file.FileId, len(file.Content))
+		}
+		if file.FileId == "assets/large.bin" {
+			largeChunks++
+		}
+		contents[file.FileId] += string(file.Content)
+	}
+	if largeChunks < 3 {
+		t.Fatalf("expected large file to be split, got %d chunks", largeChunks)
+	}
+	if contents["assets/large.bin"] != large || contents["small.txt"] != "small" {
+		t.Fatalf("reassembled contents do not match")
+	}
+
+	t.Run("manifest only", func(t *testing.T) {
+		resp, err := srv.CheckoutSlice(ctx, &slicev1.CheckoutRequest{SliceId: "slice-1", ManifestOnly: true})
+		if err != nil {
+			t.Fatalf("CheckoutSlice returned error: %v", err)
+		}
+		if len(resp.Files) != 0 || len(resp.Manifest.FileMetadata) != 2 {
+			t.Fatalf("expected a manifest without contents, got %d files and %d entries", len(resp.Files), len(resp.Manifest.FileMetadata))
+		}
+		var total int64
+		for _, fm := range resp.Manifest.FileMetadata {
+			total += fm.Size
+		}
+		if total != int64(len(large)+len("small")) {
+			t.Fatalf("expected manifest sizes to add up to %d, got %d", len(large)+len("small"), total)
+		}
+
+		manifestStream := &checkoutStream{ctx: ctx}
+		if err := srv.StreamCheckoutSlice(&slicev1.CheckoutRequest{SliceId: "slice-1", ManifestOnly: true}, manifestStream); err != nil {
+			t.Fatalf("StreamCheckoutSlice returned error: %v", err)
+		}
+		if len(manifestStream.chunks) != 1 || manifestStream.chunks[0].GetManifest() == nil {
+			t.Fatalf("expected only the manifest, got %d chunks", len(manifestStream.chunks))
+		}
+	})
+}
+
+type changesetStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks []*slicev1.ChangesetChunk
+	resp   *slicev1.CreateChangesetResponse
+}
+
+func (s *changesetStream) Context() context.Context { return s.ctx }
+
+func (s *changesetStream) Recv() (*slicev1.ChangesetChunk, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return chunk, nil
+}
+
+func (s *changesetStream) SendAndClose(resp *slicev1.CreateChangesetResponse) error {
+	s.resp = resp
+	return nil
+}
+
+func TestStreamCreateChangeset(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1"}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+
+	content := []byte("streamed")
+	blobHash := objects.HashBlob(content)
+	treeData, err := objects.EncodeTree(&models.Tree{Entries: []models.TreeEntry{{Name: "stream.txt", Mode: models.TreeModeFile, Hash: blobHash}}})
+	if err != nil {
+		t.Fatalf("EncodeTree failed: %v", err)
+	}
+	treeHash, _ := objects.Hash(models.ObjectTypeTree, treeData)
+
+	metadata := &slicev1.ChangesetChunk{Chunk: &slicev1.ChangesetChunk_Metadata{Metadata: &slicev1.ChangesetMetadata{SliceId: "slice-1", Author: "alice", Message: "stream"}}}
+	blob := &slicev1.ChangesetChunk{Chunk: &slicev1.ChangesetChunk_Object{Object: &slicev1.Object{Type: slicev1.ObjectType_BLOB, Hash: blobHash, Data: content}}}
+	tree := &slicev1.ChangesetChunk{Chunk: &slicev1.ChangesetChunk_Object{Object: &slicev1.Object{Type: slicev1.ObjectType_TREE, Hash: treeHash, Data: treeData}}}
+
+	stream := &changesetStream{ctx: ctx, chunks: []*slicev1.ChangesetChunk{metadata, blob, tree}}
+	if err := srv.StreamCreateChangeset(stream); err != nil {
+		t.Fatalf("StreamCreateChangeset returned error: %v", err)
+	}
+	cs, err := st.GetChangeset(ctx, stream.resp.ChangesetId)
+	if err != nil {
+		t.Fatalf("GetChangeset failed: %v", err)
+	}
+	if cs.TreeHash != treeHash || len(cs.ModifiedFiles) != 1 || cs.ModifiedFiles[0] != "stream.txt" {
+		t.Fatalf("unexpected changeset: %+v", cs)
+	}
+
+	t.Run("objects before metadata", func(t *testing.T) {
+		err := srv.StreamCreateChangeset(&changesetStream{ctx: ctx, chunks: []*slicev1.ChangesetChunk{blob, metadata}})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("missing blob leaves no changeset", func(t *testing.T) {
+		before, _ := st.ListChangesets(ctx, "slice-1", nil, 0)
+		orphanData, _ := objects.EncodeTree(&models.Tree{Entries: []models.TreeEntry{{Name: "gone.txt", Mode: models.TreeModeFile, Hash: objects.HashBlob([]byte("never uploaded"))}}})
+		orphanHash, _ := objects.Hash(models.ObjectTypeTree, orphanData)
+		orphan := &slicev1.ChangesetChunk{Chunk: &slicev1.ChangesetChunk_Object{Object: &slicev1.Object{Type: slicev1.ObjectType_TREE, Hash: orphanHash, Data: orphanData}}}
+
+		err := srv.StreamCreateChangeset(&changesetStream{ctx: ctx, chunks: []*slicev1.ChangesetChunk{metadata, orphan}})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument, got %v", err)
+		}
+		after, _ := st.ListChangesets(ctx, "slice-1", nil, 0)
+		if len(after) != len(before) {
+			t.Fatalf("expected no changeset to be created, had %d now %d", len(before), len(after))
+		}
+	})
+}
+
+func TestStreamCreateChangesetReassemblesLargeObjects(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1"}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+
+	// Larger than gRPC's default 4MB message limit, so it must arrive in parts
+	large := []byte(strings.Repeat("0123456789abcdef", 5<<16))
+	blobHash := objects.HashBlob(large)
+	treeData, err := objects.EncodeTree(&models.Tree{Entries: []models.TreeEntry{{Name: "large.bin", Mode: models.TreeModeFile, Hash: blobHash}}})
+	if err != nil {
+		t.Fatalf("EncodeTree failed: %v", err)
+	}
+	treeHash, _ := objects.Hash(models.ObjectTypeTree, treeData)
+
+	metadata := &slicev1.ChangesetChunk{Chunk: &slicev1.ChangesetChunk_Metadata{Metadata: &slicev1.ChangesetMetadata{SliceId: "slice-1", Author: "alice", Message: "large"}}}
+	tree := &slicev1.ChangesetChunk{Chunk: &slicev1.ChangesetChunk_Object{Object: &slicev1.Object{Type: slicev1.ObjectType_TREE, Hash: treeHash, Data: treeData}}}
+	var parts []*slicev1.ChangesetChunk
+	for offset := 0; offset < len(large); offset += 1 << 20 {
+		end := offset + 1<<20
+		if end > len(large) {
+			end = len(large)
+		}
+		parts = append(parts, &slicev1.ChangesetChunk{Chunk: &slicev1.ChangesetChunk_Object{Object: &slicev1.Object{
+			Type: slicev1.ObjectType_BLOB, Hash: blobHash, Data: large[offset:end], Offset: int64(offset), More: end < len(large),
+		}}})
+	}
+	if len(parts) < 5 {
+		t.Fatalf("expected the blob to need several parts, got %d", len(parts))
+	}
+
+	chunks := append(append([]*slicev1.ChangesetChunk{metadata}, parts...), tree)
+	stream := &changesetStream{ctx: ctx, chunks: chunks}
+	if err := srv.StreamCreateChangeset(stream); err != nil {
+		t.Fatalf("StreamCreateChangeset returned error: %v", err)
+	}
+	cs, err := st.GetChangeset(ctx, stream.resp.ChangesetId)
+	if err != nil {
+		t.Fatalf("GetChangeset failed: %v", err)
+	}
+	if cs.TreeHash != treeHash {
+		t.Fatalf("unexpected tree hash %s", cs.TreeHash)
+	}
+	stored, err := storage.ReadBlob(ctx, st, blobHash)
+	if err != nil {
+		t.Fatalf("ReadBlob failed: %v", err)
+	}
+	if string(stored) != string(large) {
+		t.Fatalf("stored blob does not match the uploaded content")
+	}
+
+	t.Run("parts out of order", func(t *testing.T) {
+		swapped := []*slicev1.ChangesetChunk{metadata, parts[1], parts[0]}
+		err := srv.StreamCreateChangeset(&changesetStream{ctx: ctx, chunks: swapped})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("missing last part", func(t *testing.T) {
+		truncated := []*slicev1.ChangesetChunk{metadata, parts[0], parts[1]}
+		err := srv.StreamCreateChangeset(&changesetStream{ctx: ctx, chunks: truncated})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("object over the size limit", func(t *testing.T) {
+		part := make([]byte, 4<<20)
+		oversize := []*slicev1.ChangesetChunk{metadata}
+		for offset := 0; offset <= 64<<20; offset += len(part) {
+			oversize = append(oversize, &slicev1.ChangesetChunk{Chunk: &slicev1.ChangesetChunk_Object{Object: &slicev1.Object{
+				Type: slicev1.ObjectType_BLOB, Hash: blobHash, Data: part, Offset: int64(offset), More: true,
+			}}})
+		}
+		err := srv.StreamCreateChangeset(&changesetStream{ctx: ctx, chunks: oversize})
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Fatalf("expected ResourceExhausted, got %v", err)
+		}
+	})
+
+	t.Run("unary requests cannot split objects", func(t *testing.T) {
+		_, err := srv.CreateChangeset(ctx, &slicev1.CreateChangesetRequest{
+			SliceId: "slice-1",
+			Objects: []*slicev1.Object{parts[0].GetObject()},
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument, got %v", err)
+		}
+	})
+}
+
+func TestReviewAndDiffChangeset(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1"}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+
+	mergeFiles(t, st, srv, map[string]string{
+		"main.go":  "package main\n\nfunc main() {\n\tprintln(\"hi\")\n}\n",
+		"old.txt":  "remove me\n",
+		"same.txt": "unchanged\n",
+	})
+	changesetID := createFiles(t, st, srv, map[string]string{
+		"main.go":  "package main\n\nfunc main() {\n\tprintln(\"hello\")\n}\n",
+		"new.txt":  "one\ntwo\n",
+		"same.txt": "unchanged\n",
+	}, "old.txt")
+
+	review, err := srv.ReviewChangeset(ctx, &slicev1.ReviewChangesetRequest{ChangesetId: changesetID})
+	if err != nil {
+		t.Fatalf("ReviewChangeset returned error: %v", err)
+	}
+	got := review.Diff
+	if got.FilesAdded != 1 || got.FilesModified != 1 || got.FilesDeleted != 1 || got.LinesAdded != 3 || got.LinesRemoved != 2 {
+		t.Fatalf("unexpected diff summary: %+v", got)
+	}
+
+	diffResp, err := srv.GetChangesetDiff(ctx, &slicev1.ChangesetDiffRequest{ChangesetId: changesetID, ContextLines: proto.Int32(1)})
+	if err != nil {
+		t.Fatalf("GetChangesetDiff returned error: %v", err)
+	}
+	if len(diffResp.Files) != 3 {
+		t.Fatalf("expected 3 changed files, got %d", len(diffResp.Files))
+	}
+	mainDiff := diffResp.Files[0]
+	if mainDiff.Path != "main.go" || mainDiff.ChangeType != slicev1.FileChangeType_FILE_CHANGE_TYPE_MODIFIED || len(mainDiff.Hunks) != 1 {
+		t.Fatalf("unexpected main.go diff: %+v", mainDiff)
+	}
+	hunk := mainDiff.Hunks[0]
+	want := []string{" func main() {", "-\tprintln(\"hi\")", "+\tprintln(\"hello\")", " }"}
+	if hunk.OldStart != 3 || hunk.NewStart != 3 || strings.Join(hunk.Lines, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected hunk: %+v", hunk)
+	}
+	if diffResp.Files[1].Path != "new.txt" || diffResp.Files[2].Path != "old.txt" || diffResp.Files[2].ChangeType != slicev1.FileChangeType_FILE_CHANGE_TYPE_DELETED {
+		t.Fatalf("unexpected file order or types: %+v", diffResp.Files)
+	}
+
+	bare, err := srv.GetChangesetDiff(ctx, &slicev1.ChangesetDiffRequest{ChangesetId: changesetID, ContextLines: proto.Int32(0)})
+	if err != nil {
+		t.Fatalf("GetChangesetDiff without context returned error: %v", err)
+	}
+	hunk = bare.Files[0].Hunks[0]
+	want = []string{"-\tprintln(\"hi\")", "+\tprintln(\"hello\")"}
+	if hunk.OldStart != 4 || hunk.OldLines != 1 || strings.Join(hunk.Lines, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("expected a hunk without context, got %+v", hunk)
+	}
+	if _, err := srv.GetChangesetDiff(ctx, &slicev1.ChangesetDiffRequest{ChangesetId: changesetID, ContextLines: proto.Int32(-1)}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for negative context, got %v", err)
+	}
+}
+
+func TestDiffReportsFilesTooLargeToCompare(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1"}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+
+	large := strings.Repeat("line\n", 1<<18)
+	mergeFiles(t, st, srv, map[string]string{"large.txt": large, "small.txt": "a\n"})
+	changesetID := createFiles(t, st, srv, map[string]string{"large.txt": large + "more\n", "small.txt": "b\n"})
+
+	diffResp, err := srv.GetChangesetDiff(ctx, &slicev1.ChangesetDiffRequest{ChangesetId: changesetID})
+	if err != nil {
+		t.Fatalf("GetChangesetDiff returned error: %v", err)
+	}
+	if len(diffResp.Files) != 2 {
+		t.Fatalf("expected 2 changed files, got %d", len(diffResp.Files))
+	}
+	if file := diffResp.Files[0]; file.Path != "large.txt" || !file.TooLarge || len(file.Hunks) != 0 {
+		t.Fatalf("expected large.txt to be reported as too large, got %+v", file)
+	}
+	if file := diffResp.Files[1]; file.TooLarge || len(file.Hunks) != 1 {
+		t.Fatalf("expected small.txt to be diffed, got %+v", file)
+	}
+}
+
+func TestReviewDetectsStaleBase(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1"}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+
+	base := mergeFiles(t, st, srv, map[string]string{"a.txt": "a1", "b.txt": "b1"})
+	changesetID := createFilesOnBase(t, st, srv, base, map[string]string{"a.txt": "a2"})
+
+	review := func() *slicev1.ReviewChangesetResponse {
+		t.Helper()
+		resp, err := srv.ReviewChangeset(ctx, &slicev1.ReviewChangesetRequest{ChangesetId: changesetID})
+		if err != nil {
+			t.Fatalf("ReviewChangeset returned error: %v", err)
+		}
+		return resp
+	}
+
+	if resp := review(); resp.ReviewStatus != slicev1.ReviewStatus_READY_FOR_MERGE || len(resp.Warnings) != 0 {
+		t.Fatalf("expected READY_FOR_MERGE on current head, got %s %v", resp.ReviewStatus, resp.Warnings)
+	}
+
+	mergeFiles(t, st, srv, map[string]string{"b.txt": "b2"})
+	if resp := review(); resp.ReviewStatus != slicev1.ReviewStatus_NEEDS_REBASE || len(resp.Warnings) != 1 {
+		t.Fatalf("expected NEEDS_REBASE after unrelated change, got %s %v", resp.ReviewStatus, resp.Warnings)
+	}
+
+	mergeFiles(t, st, srv, map[string]string{"a.txt": "a3"})
+	resp := review()
+	if resp.ReviewStatus != slicev1.ReviewStatus_HAS_CONFLICTS {
+		t.Fatalf("expected HAS_CONFLICTS after overlapping change, got %s", resp.ReviewStatus)
+	}
+	if len(resp.Warnings) != 2 || !strings.Contains(resp.Warnings[1], "a.txt") {
+		t.Fatalf("expected warning naming a.txt, got %v", resp.Warnings)
+	}
+}
+
+func TestRebaseChangesetReplaysSliceCommits(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1"}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+
+	base := mergeFiles(t, st, srv, map[string]string{"a.txt": "a1\n", "b.txt": "b1\n"})
+	clean := createFilesOnBase(t, st, srv, base, map[string]string{"a.txt": "a2\n"})
+	conflicting := createFilesOnBase(t, st, srv, base, map[string]string{"b.txt": "mine\n"})
+
+	first := mergeFiles(t, st, srv, map[string]string{"b.txt": "b2\n"})
+	second := mergeFiles(t, st, srv, map[string]string{"c.txt": "c1\n"})
+
+	resp, err := srv.RebaseChangeset(ctx, &slicev1.RebaseChangesetRequest{ChangesetId: clean})
+	if err != nil {
+		t.Fatalf("RebaseChangeset returned error: %v", err)
+	}
+	if resp.Status != slicev1.RebaseStatus_REBASE_STATUS_SUCCESS || resp.NewBaseCommitHash != second {
+		t.Fatalf("unexpected rebase response: %+v", resp)
+	}
+	if len(resp.SliceCommitsToApply) != 2 || resp.SliceCommitsToApply[0] != first || resp.SliceCommitsToApply[1] != second {
+		t.Fatalf("expected commits %s and %s to apply, got %v", first, second, resp.SliceCommitsToApply)
+	}
+
+	review, err := srv.ReviewChangeset(ctx, &slicev1.ReviewChangesetRequest{ChangesetId: clean})
+	if err != nil || review.ReviewStatus != slicev1.ReviewStatus_READY_FOR_MERGE {
+		t.Fatalf("expected rebased changeset to be ready for merge: %v %+v", err, review)
+	}
+
+	resp, err = srv.RebaseChangeset(ctx, &slicev1.RebaseChangesetRequest{ChangesetId: conflicting})
+	if err != nil {
+		t.Fatalf("RebaseChangeset returned error: %v", err)
+	}
+	if resp.Status != slicev1.RebaseStatus_REBASE_STATUS_CONFLICT || len(resp.Conflicts) != 1 || resp.Conflicts[0].FileId != "b.txt" {
+		t.Fatalf("expected a conflict on b.txt, got %+v", resp)
+	}
+	if len(resp.ConflictedFiles) != 1 || resp.ConflictedFiles[0].ConflictCount != 1 {
+		t.Fatalf("expected marked contents for b.txt, got %+v", resp.ConflictedFiles)
+	}
+	want := "<<<<<<< changeset " + conflicting + "\nmine\n=======\nb2\n>>>>>>> slice slice-1\n"
+	if got := string(resp.ConflictedFiles[0].Content); got != want {
+		t.Fatalf("unexpected conflict markers:\n%s", got)
+	}
+	cs, err := st.GetChangeset(ctx, conflicting)
+	if err != nil || cs.BaseCommitHash != base {
+		t.Fatalf("expected conflicting changeset to keep its base: %v %+v", err, cs)
+	}
+}
+
+func TestRebaseChangesetMergesSeparateLineChanges(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1"}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+
+	base := mergeFiles(t, st, srv, map[string]string{"notes.txt": "one\ntwo\nthree\nfour\n"})
+	changeset := createFilesOnBase(t, st, srv, base, map[string]string{"notes.txt": "ONE\ntwo\nthree\nfour\n"})
+	mergeFiles(t, st, srv, map[string]string{"notes.txt": "one\ntwo\nthree\nFOUR\n"})
+
+	resp, err := srv.RebaseChangeset(ctx, &slicev1.RebaseChangesetRequest{ChangesetId: changeset})
+	if err != nil {
+		t.Fatalf("RebaseChangeset returned error: %v", err)
+	}
+	if resp.Status != slicev1.RebaseStatus_REBASE_STATUS_SUCCESS {
+		t.Fatalf("expected separate line changes to merge cleanly, got %+v", resp)
+	}
+
+	merged, err := srv.MergeChangeset(ctx, &slicev1.MergeChangesetRequest{ChangesetId: changeset})
+	if err != nil || merged.Status != slicev1.MergeStatus_MERGE_STATUS_SUCCESS {
+		t.Fatalf("MergeChangeset failed: %v %+v", err, merged)
+	}
+	checkout, err := srv.CheckoutSlice(ctx, &slicev1.CheckoutRequest{SliceId: "slice-1"})
+	if err != nil {
+		t.Fatalf("CheckoutSlice returned error: %v", err)
+	}
+	if len(checkout.Files) != 1 || string(checkout.Files[0].Content) != "ONE\ntwo\nthree\nFOUR\n" {
+		t.Fatalf("expected both edits in the merged file, got %+v", checkout.Files)
+	}
+}
+
+func TestMergeChangesetRejectsStaleBase(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1"}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+
+	base := mergeFiles(t, st, srv, map[string]string{"a.txt": "a1\n"})
+	first := createFilesOnBase(t, st, srv, base, map[string]string{"b.txt": "b1\n"})
+	second := createFilesOnBase(t, st, srv, base, map[string]string{"c.txt": "c1\n"})
+
+	// Both changesets race from the same base; exactly one may move the head
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, id := range []string{first, second} {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			_, errs[i] = srv.MergeChangeset(ctx, &slicev1.MergeChangesetRequest{ChangesetId: id})
+		}(i, id)
+	}
+	wg.Wait()
+
+	wins, loser := 0, ""
+	for i, err := range errs {
+		if err == nil {
+			wins++
+			continue
+		}
+		if code := status.Code(err); code != codes.FailedPrecondition && code != codes.Aborted {
+			t.Fatalf("unexpected merge error: %v", err)
+		}
+		loser = []string{first, second}[i]
+	}
+	if wins != 1 {
+		t.Fatalf("expected exactly one merge to win, got %v", errs)
+	}
+
+	// Once the winner has landed, the other changeset's base is stale
+	_, err := srv.MergeChangeset(ctx, &slicev1.MergeChangesetRequest{ChangesetId: loser})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for stale base, got %v", err)
+	}
+	cs, err := st.GetChangeset(ctx, loser)
+	if err != nil || cs.Status != models.ChangesetStatusPending {
+		t.Fatalf("expected rejected changeset to stay pending: %v %+v", err, cs)
+	}
+
+	if _, err := srv.RebaseChangeset(ctx, &slicev1.RebaseChangesetRequest{ChangesetId: loser}); err != nil {
+		t.Fatalf("RebaseChangeset returned error: %v", err)
+	}
+	merged, err := srv.MergeChangeset(ctx, &slicev1.MergeChangesetRequest{ChangesetId: loser})
+	if err != nil || merged.Status != slicev1.MergeStatus_MERGE_STATUS_SUCCESS {
+		t.Fatalf("expected rebased changeset to merge: %v %+v", err, merged)
+	}
+}
+
+func TestApprovalRuleGatesMerge(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	slice := &models.Slice{
+		ID:           "slice-1",
+		Name:         "slice-1",
+		Owners:       []string{"alice", "bob"},
+		ApprovalRule: models.ApprovalRule{RequiredApprovals: 2, OwnersOnly: true},
+	}
+	if err := st.CreateSlice(ctx, slice); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+
+	changeset := createFiles(t, st, srv, map[string]string{"a.txt": "a\n"})
+	merge := func() error {
+		_, err := srv.MergeChangeset(ctx, &slicev1.MergeChangesetRequest{ChangesetId: changeset})
+		return err
+	}
+	vote := func(reviewer string, approve bool) *slicev1.ChangesetVoteResponse {
+		t.Helper()
+		req := &slicev1.ChangesetVoteRequest{ChangesetId: changeset, Reviewer: reviewer, Comment: "comment from " + reviewer}
+		var resp *slicev1.ChangesetVoteResponse
+		var err error
+		if approve {
+			resp, err = srv.ApproveChangeset(ctx, req)
+		} else {
+			resp, err = srv.RejectChangeset(ctx, req)
+		}
+		if err != nil {
+			t.Fatalf("vote by %s failed: %v", reviewer, err)
+		}
+		return resp
+	}
+
+	if _, err := srv.ApproveChangeset(ctx, &slicev1.ChangesetVoteRequest{ChangesetId: changeset}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument without a reviewer, got %v", err)
+	}
+	if status.Code(merge()) != codes.FailedPrecondition {
+		t.Fatalf("expected merge without approvals to be refused")
+	}
+
+	// Non-owners may comment but their votes don't count toward the rule
+	if resp := vote("carol", true); resp.Approvals != 0 || resp.Changeset.Status != slicev1.ChangesetStatus_PENDING {
+		t.Fatalf("expected non-owner approval not to count, got %+v", resp)
+	}
+	if resp := vote("alice", true); resp.Approvals != 1 || resp.RequiredApprovals != 2 {
+		t.Fatalf("expected one of two approvals, got %+v", resp)
+	}
+	if resp := vote("bob", false); resp.Changeset.Status != slicev1.ChangesetStatus_REJECTED {
+		t.Fatalf("expected owner rejection to reject the changeset, got %+v", resp)
+	}
+	if status.Code(merge()) != codes.FailedPrecondition {
+		t.Fatalf("expected merge of rejected changeset to be refused")
+	}
+
+	resp := vote("bob", true)
+	if resp.Changeset.Status != slicev1.ChangesetStatus_APPROVED || resp.Approvals != 2 {
+		t.Fatalf("expected changed vote to approve the changeset, got %+v", resp)
+	}
+	if len(resp.Changeset.Votes) != 3 {
+		t.Fatalf("expected one vote per reviewer, got %d", len(resp.Changeset.Votes))
+	}
+	if err := merge(); err != nil {
+		t.Fatalf("expected approved changeset to merge: %v", err)
+	}
+	if _, err := srv.RejectChangeset(ctx, &slicev1.ChangesetVoteRequest{ChangesetId: changeset, Reviewer: "alice"}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected votes on merged changesets to be refused, got %v", err)
+	}
+}
+
+func TestRebaseDropsVotesForOldContent(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	slice := &models.Slice{ID: "slice-1", Name: "slice-1", Owners: []string{"alice"}}
+	if err := st.CreateSlice(ctx, slice); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+
+	base := mergeFiles(t, st, srv, map[string]string{"notes.txt": "one\ntwo\nthree\n"})
+	changeset := createFilesOnBase(t, st, srv, base, map[string]string{"notes.txt": "ONE\ntwo\nthree\n"})
+	unchanged := createFilesOnBase(t, st, srv, base, map[string]string{"other.txt": "other\n"})
+	mergeFiles(t, st, srv, map[string]string{"notes.txt": "one\ntwo\nTHREE\n"})
+
+	slice.ApprovalRule = models.ApprovalRule{RequiredApprovals: 1}
+	if err := st.UpdateSlice(ctx, slice); err != nil {
+		t.Fatalf("UpdateSlice failed: %v", err)
+	}
+	for _, id := range []string{changeset, unchanged} {
+		resp, err := srv.ApproveChangeset(ctx, &slicev1.ChangesetVoteRequest{ChangesetId: id, Reviewer: "alice"})
+		if err != nil || resp.Changeset.Status != slicev1.ChangesetStatus_APPROVED {
+			t.Fatalf("ApproveChangeset failed: %v %+v", err, resp)
+		}
+		if resp, err := srv.RebaseChangeset(ctx, &slicev1.RebaseChangesetRequest{ChangesetId: id}); err != nil || resp.Status != slicev1.RebaseStatus_REBASE_STATUS_SUCCESS {
+			t.Fatalf("RebaseChangeset failed: %v %+v", err, resp)
+		}
+	}
+
+	// Approval covered the old content; the rebase rewrote it
+	cs, err := st.GetChangeset(ctx, changeset)
+	if err != nil {
+		t.Fatalf("GetChangeset failed: %v", err)
+	}
+	if len(cs.Votes) != 0 || cs.Status != models.ChangesetStatusPending {
+		t.Fatalf("expected the rebased changeset to need review again, got status %v with votes %+v", cs.Status, cs.Votes)
+	}
+	_, err = srv.MergeChangeset(ctx, &slicev1.MergeChangesetRequest{ChangesetId: changeset})
+	if status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), "0 of 1 required approvals") {
+		t.Fatalf("expected merge to wait for a fresh approval, got %v", err)
+	}
+
+	// A rebase that leaves the content alone keeps its approval
+	cs, err = st.GetChangeset(ctx, unchanged)
+	if err != nil {
+		t.Fatalf("GetChangeset failed: %v", err)
+	}
+	if len(cs.Votes) != 1 || cs.Status != models.ChangesetStatusApproved {
+		t.Fatalf("expected an unchanged tree to keep its approval, got status %v with votes %+v", cs.Status, cs.Votes)
+	}
+}
+
+func TestRequiredChecksGateMerge(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1", RequiredChecks: []string{"ci", "lint"}}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+
+	changeset := createFiles(t, st, srv, map[string]string{"a.txt": "a\n"})
+	setCheck := func(name string, state slicev1.CheckState, description string) *slicev1.SetChangesetCheckResponse {
+		t.Helper()
+		resp, err := srv.SetChangesetCheck(ctx, &slicev1.SetChangesetCheckRequest{ChangesetId: changeset, Name: name, State: state, Description: description})
+		if err != nil {
+			t.Fatalf("SetChangesetCheck %s failed: %v", name, err)
+		}
+		return resp
+	}
+	warnings := func() string {
+		t.Helper()
+		review, err := srv.ReviewChangeset(ctx, &slicev1.ReviewChangesetRequest{ChangesetId: changeset})
+		if err != nil {
+			t.Fatalf("ReviewChangeset failed: %v", err)
+		}
+		return strings.Join(review.Warnings, "\n")
+	}
+	merge := func() error {
+		_, err := srv.MergeChangeset(ctx, &slicev1.MergeChangesetRequest{ChangesetId: changeset})
+		return err
+	}
+
+	if _, err := srv.SetChangesetCheck(ctx, &slicev1.SetChangesetCheckRequest{ChangesetId: changeset}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument without a check name, got %v", err)
+	}
+	if got := warnings(); !strings.Contains(got, "Required check ci has not reported.") || !strings.Contains(got, "Required check lint has not reported.") {
+		t.Fatalf("expected missing checks to be reported, got %q", got)
+	}
+	if err := merge(); status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), "waiting on required checks: ci, lint") {
+		t.Fatalf("expected merge to wait for checks, got %v", err)
+	}
+
+	setCheck("ci", slicev1.CheckState_CHECK_STATE_PENDING, "")
+	if resp := setCheck("lint", slicev1.CheckState_CHECK_STATE_FAILURE, "3 issues"); len(resp.PendingRequiredChecks) != 2 {
+		t.Fatalf("expected both checks still outstanding, got %v", resp.PendingRequiredChecks)
+	}
+	if got := warnings(); !strings.Contains(got, "Required check ci is pending.") || !strings.Contains(got, "Required check lint failed: 3 issues.") {
+		t.Fatalf("expected pending and failed checks to be reported, got %q", got)
+	}
+	if err := merge(); status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), "failed required checks: lint") {
+		t.Fatalf("expected merge to be refused on a failed check, got %v", err)
+	}
+
+	// Optional checks are recorded but never block
+	setCheck("coverage", slicev1.CheckState_CHECK_STATE_FAILURE, "")
+	setCheck("ci", slicev1.CheckState_CHECK_STATE_SUCCESS, "")
+	resp := setCheck("lint", slicev1.CheckState_CHECK_STATE_SUCCESS, "")
+	if len(resp.PendingRequiredChecks) != 0 || len(resp.Changeset.Checks) != 3 {
+		t.Fatalf("expected one entry per check and none outstanding, got %+v", resp)
+	}
+	if got := warnings(); strings.Contains(got, "Required check") {
+		t.Fatalf("expected no check warnings once green, got %q", got)
+	}
+	if err := merge(); err != nil {
+		t.Fatalf("expected changeset with green checks to merge: %v", err)
+	}
+	if _, err := srv.SetChangesetCheck(ctx, &slicev1.SetChangesetCheckRequest{ChangesetId: changeset, Name: "ci"}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected checks on merged changesets to be refused, got %v", err)
+	}
+}
+
+func TestRebaseDropsChecksForOldContent(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	slice := &models.Slice{ID: "slice-1", Name: "slice-1"}
+	if err := st.CreateSlice(ctx, slice); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+
+	base := mergeFiles(t, st, srv, map[string]string{"notes.txt": "one\ntwo\nthree\n"})
+	changeset := createFilesOnBase(t, st, srv, base, map[string]string{"notes.txt": "ONE\ntwo\nthree\n"})
+	if _, err := srv.SetChangesetCheck(ctx, &slicev1.SetChangesetCheckRequest{ChangesetId: changeset, Name: "ci", State: slicev1.CheckState_CHECK_STATE_SUCCESS}); err != nil {
+		t.Fatalf("SetChangesetCheck failed: %v", err)
+	}
+	mergeFiles(t, st, srv, map[string]string{"notes.txt": "one\ntwo\nTHREE\n"})
+
+	slice.RequiredChecks = []string{"ci"}
+	if err := st.UpdateSlice(ctx, slice); err != nil {
+		t.Fatalf("UpdateSlice failed: %v", err)
+	}
+
+	if resp, err := srv.RebaseChangeset(ctx, &slicev1.RebaseChangesetRequest{ChangesetId: changeset}); err != nil || resp.Status != slicev1.RebaseStatus_REBASE_STATUS_SUCCESS {
+		t.Fatalf("RebaseChangeset failed: %v %+v", err, resp)
+	}
+	cs, err := st.GetChangeset(ctx, changeset)
+	if err != nil {
+		t.Fatalf("GetChangeset failed: %v", err)
+	}
+	if len(cs.Checks) != 0 {
+		t.Fatalf("expected the rebased tree to drop earlier checks, got %+v", cs.Checks)
+	}
+	_, err = srv.MergeChangeset(ctx, &slicev1.MergeChangesetRequest{ChangesetId: changeset})
+	if status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), "waiting on required checks: ci") {
+		t.Fatalf("expected merge to wait for checks on the rebased tree, got %v", err)
+	}
+}
+
+func TestAbandonChangesetBlocksMergeAndRebase(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1"}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+
+	abandoned := createFiles(t, st, srv, map[string]string{"a.txt": "a\n"})
+	kept := createFiles(t, st, srv, map[string]string{"b.txt": "b\n"})
+
+	resp, err := srv.AbandonChangeset(ctx, &slicev1.AbandonChangesetRequest{ChangesetId: abandoned, Reason: "superseded"})
+	if err != nil {
+		t.Fatalf("AbandonChangeset failed: %v", err)
+	}
+	if resp.Changeset.Status != slicev1.ChangesetStatus_ABANDONED || resp.Changeset.AbandonReason != "superseded" {
+		t.Fatalf("unexpected abandoned changeset: %+v", resp.Changeset)
+	}
+
+	if _, err := srv.AbandonChangeset(ctx, &slicev1.AbandonChangesetRequest{ChangesetId: abandoned}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected second abandon to be refused, got %v", err)
+	}
+	if _, err := srv.AbandonChangeset(ctx, &slicev1.AbandonChangesetRequest{ChangesetId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for unknown changeset, got %v", err)
+	}
+	if _, err := srv.MergeChangeset(ctx, &slicev1.MergeChangesetRequest{ChangesetId: abandoned}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected merge of abandoned changeset to be refused, got %v", err)
+	}
+	if _, err := srv.RebaseChangeset(ctx, &slicev1.RebaseChangesetRequest{ChangesetId: abandoned}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected rebase of abandoned changeset to be refused, got %v", err)
+	}
+
+	listed := func(req *slicev1.ListChangesetsRequest) []string {
+		t.Helper()
+		req.SliceId = "slice-1"
+		resp, err := srv.ListChangesets(ctx, req)
+		if err != nil {
+			t.Fatalf("ListChangesets failed: %v", err)
+		}
+		var ids []string
+		for _, cs := range resp.Changesets {
+			ids = append(ids, cs.ChangesetId)
+		}
+		return ids
+	}
+	if ids := listed(&slicev1.ListChangesetsRequest{StatusFilter: statusFilterAll}); len(ids) != 1 || ids[0] != kept {
+		t.Fatalf("expected default listing to hide abandoned changeset, got %v", ids)
+	}
+	if ids := listed(&slicev1.ListChangesetsRequest{StatusFilter: statusFilterAll, IncludeAbandoned: true}); len(ids) != 2 {
+		t.Fatalf("expected include_abandoned to list both changesets, got %v", ids)
+	}
+	if ids := listed(&slicev1.ListChangesetsRequest{StatusFilter: slicev1.ChangesetStatus_ABANDONED}); len(ids) != 1 || ids[0] != abandoned {
+		t.Fatalf("expected status filter to list the abandoned changeset, got %v", ids)
+	}
+
+	if _, err := srv.MergeChangeset(ctx, &slicev1.MergeChangesetRequest{ChangesetId: kept}); err != nil {
+		t.Fatalf("expected remaining changeset to merge: %v", err)
+	}
+	if _, err := srv.AbandonChangeset(ctx, &slicev1.AbandonChangesetRequest{ChangesetId: kept}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected abandon of merged changeset to be refused, got %v", err)
+	}
+}
+
+func TestReviewCommentThreads(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1"}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+	changeset := createFiles(t, st, srv, map[string]string{"a.txt": "a\n"})
+
+	add := func(req *slicev1.AddCommentRequest) (*slicev1.ReviewComment, error) {
+		req.ChangesetId = changeset
+		resp, err := srv.AddComment(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Comment, nil
+	}
+
+	root, err := add(&slicev1.AddCommentRequest{Path: "a.txt", Line: 1, Author: "bob", Body: "why a?"})
+	if err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if root.ChangesetHash == "" || root.Line != 1 {
+		t.Fatalf("expected comment anchored to the changeset revision, got %+v", root)
+	}
+	reply, err := add(&slicev1.AddCommentRequest{ParentId: root.CommentId, Author: "alice", Body: "it is the spec"})
+	if err != nil {
+		t.Fatalf("AddComment reply failed: %v", err)
+	}
+	if reply.ParentId != root.CommentId || reply.Path != "a.txt" || reply.Line != 1 {
+		t.Fatalf("expected reply to join the thread's anchor, got %+v", reply)
+	}
+
+	for name, req := range map[string]*slicev1.AddCommentRequest{
+		"no author":       {Path: "a.txt", Body: "x"},
+		"no body":         {Path: "a.txt", Author: "bob"},
+		"unmodified path": {Path: "b.txt", Author: "bob", Body: "x"},
+		"line no path":    {Line: 3, Author: "bob", Body: "x"},
+	} {
+		if _, err := add(req); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("%s: expected InvalidArgument, got %v", name, err)
+		}
+	}
+	if _, err := srv.AddComment(ctx, &slicev1.AddCommentRequest{ChangesetId: "missing", Author: "bob", Body: "x"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for unknown changeset, got %v", err)
+	}
+
+	review, err := srv.ReviewChangeset(ctx, &slicev1.ReviewChangesetRequest{ChangesetId: changeset})
+	if err != nil {
+		t.Fatalf("ReviewChangeset failed: %v", err)
+	}
+	if !strings.Contains(strings.Join(review.Warnings, "\n"), "1 unresolved review comment thread") {
+		t.Fatalf("expected unresolved thread warning, got %v", review.Warnings)
+	}
+
+	// Resolving a reply resolves the whole thread
+	resolved, err := srv.ResolveComment(ctx, &slicev1.ResolveCommentRequest{CommentId: reply.CommentId, ResolvedBy: "bob"})
+	if err != nil {
+		t.Fatalf("ResolveComment failed: %v", err)
+	}
+	if resolved.Comment.CommentId != root.CommentId || !resolved.Comment.Resolved || resolved.Comment.ResolvedBy != "bob" {
+		t.Fatalf("expected thread root to be resolved, got %+v", resolved.Comment)
+	}
+	if _, err := srv.ResolveComment(ctx, &slicev1.ResolveCommentRequest{CommentId: root.CommentId, ResolvedBy: "bob"}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition resolving twice, got %v", err)
+	}
+
+	list, err := srv.ListComments(ctx, &slicev1.ListCommentsRequest{ChangesetId: changeset})
+	if err != nil {
+		t.Fatalf("ListComments failed: %v", err)
+	}
+	if len(list.Comments) != 2 || list.Comments[0].CommentId != root.CommentId || !list.Comments[0].Resolved {
+		t.Fatalf("unexpected comment listing: %+v", list.Comments)
+	}
+}
+
+func TestStackedChangesets(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1"}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+
+	mergeFiles(t, st, srv, map[string]string{"a.txt": "a1\na2\na3\n", "b.txt": "b1\n"})
+	parent := createFiles(t, st, srv, map[string]string{"a.txt": "a1\nA2\na3\n"})
+	stacked := func(parentID string, files map[string]string) string {
+		t.Helper()
+		return createFilesWith(t, st, srv, &slicev1.CreateChangesetRequest{ParentChangesetId: parentID}, files)
+	}
+	// The child builds on the parent's edit, so without the parent as its
+	// ancestor both would appear to change line 2
+	child := stacked(parent, map[string]string{"a.txt": "a1\nA2\nA3\n", "c.txt": "c\n"})
+	grandchild := stacked(child, map[string]string{"c.txt": "c\nC\n"})
+
+	if _, err := srv.CreateChangeset(ctx, &slicev1.CreateChangesetRequest{SliceId: "slice-1", ParentChangesetId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for unknown parent, got %v", err)
+	}
+	if _, err := srv.MergeChangeset(ctx, &slicev1.MergeChangesetRequest{ChangesetId: child}); status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), "must merge first") {
+		t.Fatalf("expected child merge to wait for its parent, got %v", err)
+	}
+
+	// Move the slice so the whole stack is stale, then restack it
+	head := mergeFiles(t, st, srv, map[string]string{"b.txt": "b2\n"})
+	rebased, err := srv.RebaseChangeset(ctx, &slicev1.RebaseChangesetRequest{ChangesetId: parent, Restack: true})
+	if err != nil || rebased.Status != slicev1.RebaseStatus_REBASE_STATUS_SUCCESS {
+		t.Fatalf("RebaseChangeset failed: %v %+v", err, rebased)
+	}
+	if len(rebased.StackedChangesets) != 1 || rebased.StackedChangesets[0] != child {
+		t.Fatalf("expected child to be listed as stacked, got %v", rebased.StackedChangesets)
+	}
+	if len(rebased.Restacked) != 2 || rebased.Restacked[1].ChangesetId != grandchild || rebased.Restacked[1].ParentChangesetId != child {
+		t.Fatalf("expected child and grandchild to be restacked, got %+v", rebased.Restacked)
+	}
+	for _, id := range []string{child, grandchild} {
+		cs, _ := st.GetChangeset(ctx, id)
+		if cs.BaseCommitHash != head {
+			t.Fatalf("expected %s to move to %s, got %s", id, head, cs.BaseCommitHash)
+		}
+	}
+
+	merge := func(id string) *slicev1.MergeChangesetResponse {
+		t.Helper()
+		if rebased, err := srv.RebaseChangeset(ctx, &slicev1.RebaseChangesetRequest{ChangesetId: id}); err != nil || rebased.Status != slicev1.RebaseStatus_REBASE_STATUS_SUCCESS {
+			t.Fatalf("rebase of %s failed: %v %+v", id, err, rebased)
+		}
+		merged, err := srv.MergeChangeset(ctx, &slicev1.MergeChangesetRequest{ChangesetId: id})
+		if err != nil {
+			t.Fatalf("merge of %s failed: %v", id, err)
+		}
+		return merged
+	}
+	merge(parent)
+	merge(child)
+	merge(grandchild)
+
+	checkout, err := srv.CheckoutSlice(ctx, &slicev1.CheckoutRequest{SliceId: "slice-1"})
+	if err != nil {
+		t.Fatalf("CheckoutSlice failed: %v", err)
+	}
+	got := make(map[string]string)
+	for _, file := range checkout.Files {
+		got[file.FileId] = string(file.Content)
+	}
+	if got["a.txt"] != "a1\nA2\nA3\n" || got["b.txt"] != "b2\n" || got["c.txt"] != "c\nC\n" {
+		t.Fatalf("unexpected files after merging the stack: %v", got)
+	}
+
+	list, err := srv.ListChangesets(ctx, &slicev1.ListChangesetsRequest{SliceId: "slice-1", StatusFilter: statusFilterAll})
+	if err != nil {
+		t.Fatalf("ListChangesets failed: %v", err)
+	}
+	for _, cs := range list.Changesets {
+		if cs.ChangesetId == grandchild && cs.ParentChangesetId != child {
+			t.Fatalf("expected grandchild to report its parent, got %q", cs.ParentChangesetId)
+		}
+	}
+	if _, err := srv.CreateChangeset(ctx, &slicev1.CreateChangesetRequest{SliceId: "slice-1", ParentChangesetId: parent}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected stacking on a merged changeset to be refused, got %v", err)
+	}
+}
+
+func TestStackedChangesetDiffShowsOnlyItsOwnChanges(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1"}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+
+	mergeFiles(t, st, srv, map[string]string{"a.txt": "a1\n", "b.txt": "b1\n"})
+	parent := createFiles(t, st, srv, map[string]string{"a.txt": "A1\n"})
+	// The child's working copy carries the parent's edit along with its own
+	child := createFilesWith(t, st, srv, &slicev1.CreateChangesetRequest{ParentChangesetId: parent}, map[string]string{
+		"a.txt": "A1\n",
+		"b.txt": "B1\n",
+	})
+
+	for id, want := range map[string]string{parent: "a.txt", child: "b.txt"} {
+		diffResp, err := srv.GetChangesetDiff(ctx, &slicev1.ChangesetDiffRequest{ChangesetId: id})
+		if err != nil {
+			t.Fatalf("GetChangesetDiff of %s returned error: %v", id, err)
+		}
+		if len(diffResp.Files) != 1 || diffResp.Files[0].Path != want || diffResp.Files[0].ChangeType != slicev1.FileChangeType_FILE_CHANGE_TYPE_MODIFIED {
+			t.Fatalf("expected %s to change only %s, got %+v", id, want, diffResp.Files)
+		}
+	}
+}
+
+type mergeQueueStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events []*slicev1.MergeQueueEvent
+}
+
+func (s *mergeQueueStream) Context() context.Context { return s.ctx }
+
+func (s *mergeQueueStream) Send(event *slicev1.MergeQueueEvent) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func TestMergeQueueRebasesAndMergesInOrder(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1"}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+
+	base := mergeFiles(t, st, srv, map[string]string{"a.txt": "a1\na2\na3\n"})
+	// Both start from the same head, so the second needs a rebase once the first lands
+	first := createFiles(t, st, srv, map[string]string{"a.txt": "A1\na2\na3\n"})
+	second := createFiles(t, st, srv, map[string]string{"a.txt": "a1\na2\nA3\n"})
+	conflicting := createFilesOnBase(t, st, srv, base, map[string]string{"a.txt": "X1\na2\na3\n"})
+
+	if _, err := srv.EnqueueMerge(ctx, &slicev1.EnqueueMergeRequest{ChangesetId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for unknown changeset, got %v", err)
+	}
+	if err := srv.WatchMergeQueue(&slicev1.WatchMergeQueueRequest{ChangesetId: first}, &mergeQueueStream{ctx: ctx}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected watching an unqueued changeset to fail, got %v", err)
+	}
+
+	for _, id := range []string{first, second, conflicting} {
+		queued, err := srv.EnqueueMerge(ctx, &slicev1.EnqueueMergeRequest{ChangesetId: id, RequestedBy: "alice"})
+		if err != nil || queued.SliceId != "slice-1" || queued.Position < 1 {
+			t.Fatalf("EnqueueMerge of %s failed: %v %+v", id, err, queued)
+		}
+	}
+
+	watch := func(id string) *slicev1.MergeQueueEvent {
+		t.Helper()
+		watchCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		stream := &mergeQueueStream{ctx: watchCtx}
+		if err := srv.WatchMergeQueue(&slicev1.WatchMergeQueueRequest{ChangesetId: id}, stream); err != nil {
+			t.Fatalf("WatchMergeQueue of %s failed: %v", id, err)
+		}
+		return stream.events[len(stream.events)-1]
+	}
+	if event := watch(second); event.State != slicev1.MergeQueueState_MERGE_QUEUE_STATE_MERGED || event.NewCommitHash == "" {
+		t.Fatalf("expected second changeset to merge, got %+v", event)
+	}
+	if event := watch(first); event.State != slicev1.MergeQueueState_MERGE_QUEUE_STATE_MERGED {
+		t.Fatalf("expected first changeset to merge, got %+v", event)
+	}
+	event := watch(conflicting)
+	if event.State != slicev1.MergeQueueState_MERGE_QUEUE_STATE_FAILED || len(event.Conflicts) != 1 || event.Conflicts[0] != "a.txt" {
+		t.Fatalf("expected conflicting changeset to fail on a.txt, got %+v", event)
+	}
+	if cs, _ := st.GetChangeset(ctx, conflicting); cs.Status == models.ChangesetStatusMerged {
+		t.Fatalf("conflicting changeset should stay open")
+	}
+
+	if queue, err := st.ListMergeQueue(ctx, "slice-1"); err != nil || len(queue) != 0 {
+		t.Fatalf("expected merge queue to drain, got %v %+v", err, queue)
+	}
+	checkout, err := srv.CheckoutSlice(ctx, &slicev1.CheckoutRequest{SliceId: "slice-1"})
+	if err != nil {
+		t.Fatalf("CheckoutSlice failed: %v", err)
+	}
+	if len(checkout.Files) != 1 || string(checkout.Files[0].Content) != "A1\na2\nA3\n" {
+		t.Fatalf("expected both queued edits on the head, got %+v", checkout.Files)
+	}
+}
+
+func TestMergeQueueKeepsReviewAcrossRebases(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	slice := &models.Slice{ID: "slice-1", Name: "slice-1", Owners: []string{"alice"}}
+	if err := st.CreateSlice(ctx, slice); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+
+	mergeFiles(t, st, srv, map[string]string{"a.txt": "a1\na2\na3\n"})
+	first := createFiles(t, st, srv, map[string]string{"a.txt": "A1\na2\na3\n"})
+	second := createFiles(t, st, srv, map[string]string{"a.txt": "a1\na2\nA3\n"})
+
+	slice.ApprovalRule = models.ApprovalRule{RequiredApprovals: 1}
+	slice.RequiredChecks = []string{"ci"}
+	if err := st.UpdateSlice(ctx, slice); err != nil {
+		t.Fatalf("UpdateSlice failed: %v", err)
+	}
+	for _, id := range []string{first, second} {
+		if _, err := srv.ApproveChangeset(ctx, &slicev1.ChangesetVoteRequest{ChangesetId: id, Reviewer: "alice"}); err != nil {
+			t.Fatalf("ApproveChangeset failed: %v", err)
+		}
+		if _, err := srv.SetChangesetCheck(ctx, &slicev1.SetChangesetCheckRequest{ChangesetId: id, Name: "ci", State: slicev1.CheckState_CHECK_STATE_SUCCESS}); err != nil {
+			t.Fatalf("SetChangesetCheck failed: %v", err)
+		}
+		if _, err := srv.EnqueueMerge(ctx, &slicev1.EnqueueMergeRequest{ChangesetId: id, RequestedBy: "alice"}); err != nil {
+			t.Fatalf("EnqueueMerge of %s failed: %v", id, err)
+		}
+	}
+
+	// The second edits the same file, so it lands through a content rebase
+	watchCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	stream := &mergeQueueStream{ctx: watchCtx}
+	if err := srv.WatchMergeQueue(&slicev1.WatchMergeQueueRequest{ChangesetId: second}, stream); err != nil {
+		t.Fatalf("WatchMergeQueue failed: %v", err)
+	}
+	if event := stream.events[len(stream.events)-1]; event.State != slicev1.MergeQueueState_MERGE_QUEUE_STATE_MERGED {
+		t.Fatalf("expected the rebased changeset to merge, got %+v", event)
+	}
+
+	checkout, err := srv.CheckoutSlice(ctx, &slicev1.CheckoutRequest{SliceId: "slice-1"})
+	if err != nil {
+		t.Fatalf("CheckoutSlice failed: %v", err)
+	}
+	if len(checkout.Files) != 1 || string(checkout.Files[0].Content) != "A1\na2\nA3\n" {
+		t.Fatalf("expected both queued edits on the head, got %+v", checkout.Files)
+	}
+}
+
+func TestMergeChangesetDetectsContentConflicts(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	for _, id := range []string{"slice-1", "slice-2", "slice-3"} {
+		if err := st.CreateSlice(ctx, &models.Slice{ID: id, Name: id}); err != nil {
+			t.Fatalf("failed to create slice: %v", err)
+		}
+		if err := st.AddFileToSlice(ctx, "shared.txt", id); err != nil {
+			t.Fatalf("failed to claim file: %v", err)
+		}
+	}
+	srv := sliceservice.NewService(st)
+
+	merge := func(sliceID, content string) *slicev1.MergeChangesetResponse {
+		t.Helper()
+		id := createFilesWith(t, st, srv, &slicev1.CreateChangesetRequest{SliceId: sliceID}, map[string]string{"shared.txt": content})
+		resp, err := srv.MergeChangeset(ctx, &slicev1.MergeChangesetRequest{ChangesetId: id})
+		if err != nil {
+			t.Fatalf("MergeChangeset on %s failed: %v", sliceID, err)
+		}
+		return resp
+	}
+
+	// Claiming a file the other slices never changed is not a conflict
+	if resp := merge("slice-2", "v2\n"); resp.Status != slicev1.MergeStatus_MERGE_STATUS_SUCCESS {
+		t.Fatalf("expected first edit to merge, got %+v", resp)
+	}
+	// Nor is making the same edit
+	if resp := merge("slice-1", "v2\n"); resp.Status != slicev1.MergeStatus_MERGE_STATUS_SUCCESS {
+		t.Fatalf("expected identical edit to merge, got %+v", resp)
+	}
+	// Building on the shared version only moves one side
+	if resp := merge("slice-1", "v4\n"); resp.Status != slicev1.MergeStatus_MERGE_STATUS_SUCCESS {
+		t.Fatalf("expected edit on the shared version to merge, got %+v", resp)
+	}
+
+	resp := merge("slice-3", "v3\n")
+	if resp.Status != slicev1.MergeStatus_MERGE_STATUS_CONFLICT || len(resp.Conflicts) != 1 {
+		t.Fatalf("expected diverging edit to conflict, got %+v", resp)
+	}
+	// slice-3 never saw the global version slice-1 published; slice-2 is
+	// merely behind it and changed nothing since
+	conflicting := append([]string(nil), resp.Conflicts[0].ConflictingSliceIds...)
+	sort.Strings(conflicting)
+	if got := strings.Join(conflicting, ","); got != "slice-1" {
+		t.Fatalf("expected conflict with slice-1 only, got %s", got)
+	}
+
+	if err := st.AddFileToSlice(ctx, "shared.txt", "slice-3"); err != nil {
+		t.Fatalf("failed to claim file: %v", err)
+	}
+	candidates, err := st.ListConflicts(ctx)
+	if err != nil {
+		t.Fatalf("ListConflicts failed: %v", err)
+	}
+	conflicts, err := storage.NewConflictDetector(st).Filter(ctx, candidates)
+	if err != nil {
+		t.Fatalf("Filter failed: %v", err)
+	}
+	if len(conflicts) != 1 || strings.Join(conflicts[0].ConflictingSlices, ",") != "slice-1,slice-3" {
+		t.Fatalf("expected the open changeset to keep shared.txt conflicted, got %+v", conflicts)
+	}
+
+	// A changeset built on slice-2's version, which is behind, would undo slice-1's
+	createFilesWith(t, st, srv, &slicev1.CreateChangesetRequest{SliceId: "slice-2"}, map[string]string{"shared.txt": "v5\n"})
+	if conflicts, err = storage.NewConflictDetector(st).Filter(ctx, candidates); err != nil {
+		t.Fatalf("Filter failed: %v", err)
+	}
+	if len(conflicts) != 1 || strings.Join(conflicts[0].ConflictingSlices, ",") != "slice-1,slice-2,slice-3" {
+		t.Fatalf("expected the stale changeset to conflict with slice-1, got %+v", conflicts)
+	}
+}
+
+func TestMergeChangesetKeepsOtherSlicesClaims(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	for _, id := range []string{"slice-1", "slice-2"} {
+		if err := st.CreateSlice(ctx, &models.Slice{ID: id, Name: id}); err != nil {
+			t.Fatalf("failed to create slice: %v", err)
+		}
+		if err := st.AddFileToSlice(ctx, "f.txt", id); err != nil {
+			t.Fatalf("failed to claim file: %v", err)
+		}
+	}
+	srv := sliceservice.NewService(st)
+
+	id := createFilesWith(t, st, srv, &slicev1.CreateChangesetRequest{SliceId: "slice-1"}, map[string]string{"f.txt": "v1\n"})
+	resp, err := srv.MergeChangeset(ctx, &slicev1.MergeChangesetRequest{ChangesetId: id})
+	if err != nil || resp.Status != slicev1.MergeStatus_MERGE_STATUS_SUCCESS {
+		t.Fatalf("expected clean merge, got %+v, %v", resp, err)
+	}
+
+	owners, err := st.GetActiveSlicesForFile(ctx, "f.txt")
+	if err != nil {
+		t.Fatalf("GetActiveSlicesForFile failed: %v", err)
+	}
+	sort.Strings(owners)
+	if strings.Join(owners, ",") != "slice-1,slice-2" {
+		t.Fatalf("expected both slices to keep f.txt, got %v", owners)
+	}
+	other, err := st.GetSlice(ctx, "slice-2")
+	if err != nil {
+		t.Fatalf("GetSlice failed: %v", err)
+	}
+	if strings.Join(other.Files, ",") != "f.txt" {
+		t.Fatalf("expected slice-2 to still list f.txt, got %v", other.Files)
+	}
+}
+
+func TestMergePromotesOntoRootTree(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	for _, id := range []string{"slice-1", "slice-2"} {
+		if err := st.CreateSlice(ctx, &models.Slice{ID: id, Name: id}); err != nil {
+			t.Fatalf("failed to create slice: %v", err)
+		}
+	}
+	srv := sliceservice.NewService(st)
+
+	merge := func(sliceID string, files map[string]string) {
+		t.Helper()
+		id := createFilesWith(t, st, srv, &slicev1.CreateChangesetRequest{SliceId: sliceID}, files)
+		resp, err := srv.MergeChangeset(ctx, &slicev1.MergeChangesetRequest{ChangesetId: id})
+		if err != nil || resp.Status != slicev1.MergeStatus_MERGE_STATUS_SUCCESS {
+			t.Fatalf("MergeChangeset on %s failed: %+v, %v", sliceID, resp, err)
+		}
+	}
+	merge("slice-1", map[string]string{"a/x.txt": "x1\n"})
+	merge("slice-2", map[string]string{"b/y.txt": "y1\n"})
+	merge("slice-1", map[string]string{"a/x.txt": "x2\n"})
+
+	checkout, err := srv.CheckoutSlice(ctx, &slicev1.CheckoutRequest{SliceId: "root_slice"})
+	if err != nil {
+		t.Fatalf("CheckoutSlice failed: %v", err)
+	}
+	got := make(map[string]string)
+	for _, file := range checkout.Files {
+		got[file.FileId] = string(file.Content)
+	}
+	if len(got) != 2 || got["a/x.txt"] != "x2\n" || got["b/y.txt"] != "y1\n" {
+		t.Fatalf("expected the root tree to hold both slices' files, got %v", got)
+	}
+
+	state, err := st.GetGlobalState(ctx)
+	if err != nil {
+		t.Fatalf("GetGlobalState failed: %v", err)
+	}
+	if state.GlobalCommitHash != checkout.Manifest.CommitHash || len(state.History) != 3 {
+		t.Fatalf("expected one global commit per merge ending at the root head, got %+v", state)
+	}
+}
+
+func TestCreateSliceFromFolderCarvesParentFiles(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1", Owners: []string{"alice"}}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+	head := mergeFiles(t, st, srv, map[string]string{"app/main.go": "main\n", "app/util/strings.go": "util\n", "lib/money.go": "money\n"})
+
+	for folder, want := range map[string]codes.Code{"": codes.InvalidArgument, "../app": codes.InvalidArgument, "docs": codes.NotFound, "app/main.go": codes.NotFound} {
+		_, err := srv.CreateSliceFromFolder(ctx, &slicev1.CreateSliceFromFolderRequest{ParentSliceId: "slice-1", FolderPath: folder, NewSliceId: "app"})
+		if status.Code(err) != want {
+			t.Fatalf("expected %v for folder %q, got %v", want, folder, err)
+		}
+	}
+
+	resp, err := srv.CreateSliceFromFolder(ctx, &slicev1.CreateSliceFromFolderRequest{ParentSliceId: "slice-1", FolderPath: "app/", NewSliceId: "app", Name: "App", CreatedBy: "bob"})
+	if err != nil {
+		t.Fatalf("CreateSliceFromFolder failed: %v", err)
+	}
+	if strings.Join(resp.Files, ",") != "app/main.go,app/util/strings.go" || resp.CommitHash == "" {
+		t.Fatalf("expected the folder's files and a first commit, got %+v", resp)
+	}
+
+	slice, err := st.GetSlice(ctx, "app")
+	if err != nil || slice.ParentSlice != "slice-1" || slice.CreatedBy != "bob" || len(slice.Owners) != 1 {
+		t.Fatalf("expected new slice linked to its parent: %v %+v", err, slice)
+	}
+	commit, err := storage.ReadCommit(ctx, st, resp.CommitHash)
+	if err != nil || commit.ParentHash != head {
+		t.Fatalf("expected first commit on top of the parent head %s: %v %+v", head, err, commit)
+	}
+	checkout, err := srv.CheckoutSlice(ctx, &slicev1.CheckoutRequest{SliceId: "app"})
+	if err != nil || len(checkout.Files) != 2 {
+		t.Fatalf("expected the new slice to check out the folder: %v %+v", err, checkout)
+	}
+
+	if owners, _ := st.GetActiveSlicesForFile(ctx, "app/main.go"); len(owners) != 1 || owners[0] != "app" {
+		t.Fatalf("expected app/main.go to move to the new slice, got %v", owners)
+	}
+	if owners, _ := st.GetActiveSlicesForFile(ctx, "lib/money.go"); len(owners) != 1 || owners[0] != "slice-1" {
+		t.Fatalf("expected lib/money.go to stay with the parent, got %v", owners)
+	}
+}
+
+func TestCreateSliceFromFolderExcludesFolderFromPatternParent(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1", Include: []string{"svc/"}}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+	mergeFiles(t, st, srv, map[string]string{"svc/api/handler.go": "handler\n", "svc/core/money.go": "money\n"})
+
+	if _, err := srv.CreateSliceFromFolder(ctx, &slicev1.CreateSliceFromFolderRequest{ParentSliceId: "slice-1", FolderPath: "svc/api", NewSliceId: "api"}); err != nil {
+		t.Fatalf("CreateSliceFromFolder failed: %v", err)
+	}
+	if parent, _ := st.GetSlice(ctx, "slice-1"); strings.Join(parent.Exclude, ",") != "svc/api/" {
+		t.Fatalf("expected slice-1 to exclude the carved folder, got %v", parent.Exclude)
+	}
+	for file, want := range map[string]string{"svc/api/handler.go": "api", "svc/core/money.go": "slice-1"} {
+		if owners, _ := st.GetActiveSlicesForFile(ctx, file); strings.Join(owners, ",") != want {
+			t.Fatalf("expected %s owned by %q alone, got %v", file, want, owners)
+		}
+	}
+
+	archived, _ := st.GetSlice(ctx, "slice-1")
+	archived.Archived = true
+	if err := st.UpdateSlice(ctx, archived); err != nil {
+		t.Fatalf("UpdateSlice failed: %v", err)
+	}
+	_, err := srv.CreateSliceFromFolder(ctx, &slicev1.CreateSliceFromFolderRequest{ParentSliceId: "slice-1", FolderPath: "svc/core", NewSliceId: "core"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected carving from an archived slice to fail, got %v", err)
+	}
+}
+
+func TestSliceHierarchyReparentAndFold(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1"}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+	mergeFiles(t, st, srv, map[string]string{"app/main.go": "main\n", "app/util/strings.go": "util\n", "lib/money.go": "money\n"})
+
+	app, err := srv.CreateSliceFromFolder(ctx, &slicev1.CreateSliceFromFolderRequest{ParentSliceId: "slice-1", FolderPath: "app", NewSliceId: "app"})
+	if err != nil {
+		t.Fatalf("CreateSliceFromFolder failed: %v", err)
+	}
+	if _, err := srv.CreateSliceFromFolder(ctx, &slicev1.CreateSliceFromFolderRequest{ParentSliceId: "app", FolderPath: "app/util", NewSliceId: "util"}); err != nil {
+		t.Fatalf("CreateSliceFromFolder failed: %v", err)
+	}
+	root, err := st.GetRootSlice(ctx)
+	if err != nil {
+		t.Fatalf("GetRootSlice failed: %v", err)
+	}
+
+	children, err := srv.ListSliceChildren(ctx, &slicev1.ListSliceChildrenRequest{SliceId: "slice-1", Recursive: true})
+	if err != nil || len(children.Children) != 2 || children.Children[0].SliceId != "app" || children.Children[1].SliceId != "util" || children.Children[1].Depth != 2 {
+		t.Fatalf("expected app and util below slice-1: %v %+v", err, children)
+	}
+	ancestry, err := srv.GetSliceAncestry(ctx, &slicev1.GetSliceAncestryRequest{SliceId: "util"})
+	if err != nil || len(ancestry.Ancestors) != 3 || ancestry.Ancestors[0].SliceId != "app" || ancestry.Ancestors[2].SliceId != root.ID {
+		t.Fatalf("expected util's ancestry to end at the root: %v %+v", err, ancestry)
+	}
+
+	for _, tc := range []struct {
+		slice, parent string
+		want          codes.Code
+	}{
+		{"app", "app", codes.InvalidArgument},
+		{"slice-1", "util", codes.FailedPrecondition},
+		{"app", "missing", codes.NotFound},
+		{root.ID, "slice-1", codes.FailedPrecondition},
+	} {
+		if _, err := srv.ReparentSlice(ctx, &slicev1.ReparentSliceRequest{SliceId: tc.slice, ParentSliceId: tc.parent}); status.Code(err) != tc.want {
+			t.Fatalf("expected %v moving %s under %s, got %v", tc.want, tc.slice, tc.parent, err)
+		}
+	}
+	moved, err := srv.ReparentSlice(ctx, &slicev1.ReparentSliceRequest{SliceId: "util", ParentSliceId: "slice-1"})
+	if err != nil || moved.PreviousParentSliceId != "app" {
+		t.Fatalf("ReparentSlice failed: %v %+v", err, moved)
+	}
+	if _, err := srv.ReparentSlice(ctx, &slicev1.ReparentSliceRequest{SliceId: "util", ParentSliceId: "app"}); err != nil {
+		t.Fatalf("ReparentSlice back failed: %v", err)
+	}
+
+	// Land a change in app, then fold it back into slice-1
+	changesetID := createFilesWith(t, st, srv, &slicev1.CreateChangesetRequest{SliceId: "app", BaseCommitHash: app.CommitHash}, map[string]string{"app/main.go": "main v2\n"})
+	if merged, err := srv.MergeChangeset(ctx, &slicev1.MergeChangesetRequest{ChangesetId: changesetID}); err != nil || merged.Status != slicev1.MergeStatus_MERGE_STATUS_SUCCESS {
+		t.Fatalf("MergeChangeset failed: %v %+v", err, merged)
+	}
+	pending := createFilesWith(t, st, srv, &slicev1.CreateChangesetRequest{SliceId: "app"}, map[string]string{"app/main.go": "main v3\n"})
+	if _, err := srv.FoldSlice(ctx, &slicev1.FoldSliceRequest{SliceId: "app"}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected an open changeset to block folding, got %v", err)
+	}
+	if _, err := srv.AbandonChangeset(ctx, &slicev1.AbandonChangesetRequest{ChangesetId: pending}); err != nil {
+		t.Fatalf("AbandonChangeset failed: %v", err)
+	}
+
+	folded, err := srv.FoldSlice(ctx, &slicev1.FoldSliceRequest{SliceId: "app", Author: "bob"})
+	if err != nil {
+		t.Fatalf("FoldSlice failed: %v", err)
+	}
+	if folded.ParentSliceId != "slice-1" || strings.Join(folded.Files, ",") != "app/main.go" || folded.CommitsFolded != 2 {
+		t.Fatalf("expected app/main.go and two commits folded into slice-1, got %+v", folded)
+	}
+	if strings.Join(folded.ReparentedSlices, ",") != "util" {
+		t.Fatalf("expected util to move up to slice-1, got %v", folded.ReparentedSlices)
+	}
+	if util, _ := st.GetSlice(ctx, "util"); util.ParentSlice != "slice-1" {
+		t.Fatalf("expected util under slice-1, got %q", util.ParentSlice)
+	}
+	if owners, _ := st.GetActiveSlicesForFile(ctx, "app/main.go"); len(owners) != 1 || owners[0] != "slice-1" {
+		t.Fatalf("expected app/main.go back with slice-1, got %v", owners)
+	}
+	if owners, _ := st.GetActiveSlicesForFile(ctx, "app/util/strings.go"); len(owners) != 1 || owners[0] != "util" {
+		t.Fatalf("expected app/util/strings.go to stay with util, got %v", owners)
+	}
+
+	// app's commits stay out of slice-1's history; the fold commit links to them
+	appHistory, err := srv.GetSliceCommits(ctx, &slicev1.CommitHistoryRequest{SliceId: "app"})
+	if err != nil || len(appHistory.Commits) != 2 {
+		t.Fatalf("expected app to keep its two commits: %v %+v", err, appHistory)
+	}
+	history, err := srv.GetSliceCommits(ctx, &slicev1.CommitHistoryRequest{SliceId: "slice-1"})
+	if err != nil || len(history.Commits) != 2 || history.Commits[0].CommitHash != folded.CommitHash {
+		t.Fatalf("expected the fold commit on top of slice-1's own history: %v %+v", err, history)
+	}
+	if fold := history.Commits[0]; fold.ParentHash != history.Commits[1].CommitHash || fold.FoldedHash != appHistory.Commits[0].CommitHash {
+		t.Fatalf("expected the fold commit to follow slice-1's head and point at app's head, got %+v", fold)
+	}
+	checkout, err := srv.CheckoutSlice(ctx, &slicev1.CheckoutRequest{SliceId: "slice-1"})
+	if err != nil || checkout.Manifest.CommitHash != folded.CommitHash {
+		t.Fatalf("expected slice-1 to check out the fold commit: %v %+v", err, checkout)
+	}
+	for _, file := range checkout.Files {
+		if file.FileId == "app/main.go" && string(file.Content) != "main v2\n" {
+			t.Fatalf("expected app's version of app/main.go, got %q", file.Content)
+		}
+	}
+}
+
+func TestFoldSliceClearsPatterns(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1"}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+	mergeFiles(t, st, srv, map[string]string{"svc/a.go": "a\n", "svc/b.go": "b\n", "lib/c.go": "c\n"})
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "svc", Name: "svc", ParentSlice: "slice-1", Include: []string{"svc/**"}}); err != nil {
+		t.Fatalf("failed to create pattern slice: %v", err)
+	}
+
+	folded, err := srv.FoldSlice(ctx, &slicev1.FoldSliceRequest{SliceId: "svc", Author: "bob"})
+	if err != nil {
+		t.Fatalf("FoldSlice failed: %v", err)
+	}
+	if strings.Join(folded.Files, ",") != "svc/a.go,svc/b.go" {
+		t.Fatalf("expected the files svc's patterns match to move to slice-1, got %v", folded.Files)
+	}
+	if svc, _ := st.GetSlice(ctx, "svc"); len(svc.Include) != 0 || len(svc.Exclude) != 0 || len(svc.Files) != 0 {
+		t.Fatalf("expected the folded slice to keep no files or patterns, got %+v", svc)
+	}
+	for _, file := range []string{"svc/a.go", "svc/b.go"} {
+		if owners, _ := st.GetActiveSlicesForFile(ctx, file); len(owners) != 1 || owners[0] != "slice-1" {
+			t.Fatalf("expected %s to belong to slice-1 alone, got %v", file, owners)
+		}
+	}
+	if conflicts, _ := st.ListConflicts(ctx); len(conflicts) != 0 {
+		t.Fatalf("expected no conflicts after folding, got %+v", conflicts)
+	}
+}
+
+func TestCreateChangesetRejectsArchivedSlice(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewInMemoryStorage()
+	if err := st.CreateSlice(ctx, &models.Slice{ID: "slice-1", Name: "slice-1"}); err != nil {
+		t.Fatalf("failed to create slice: %v", err)
+	}
+	srv := sliceservice.NewService(st)
+	mergeFiles(t, st, srv, map[string]string{"main.go": "main\n"})
+
+	slice, _ := st.GetSlice(ctx, "slice-1")
+	slice.Archived = true
+	if err := st.UpdateSlice(ctx, slice); err != nil {
+		t.Fatalf("UpdateSlice failed: %v", err)
+	}
+
+	_, err := srv.CreateChangeset(ctx, &slicev1.CreateChangesetRequest{SliceId: "slice-1", ModifiedFiles: []string{"main.go"}})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected an archived slice to reject changesets, got %v", err)
+	}
+}
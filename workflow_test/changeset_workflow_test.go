@@ -1,6 +1,8 @@
 package workflow
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -42,3 +44,327 @@ func TestChangesetWorkflow(t *testing.T) {
 		t.Fatalf("expected merged changeset in list, got: %s", output)
 	}
 }
+
+// TestChangesetUploadsFileContents verifies that changesets carry local file
+// contents through merge and back out through checkout.
+func TestChangesetUploadsFileContents(t *testing.T) {
+	workdir := t.TempDir()
+	sliceID := "changeset-content"
+
+	_ = runCLIOrFail(t, workdir, "slice", "create", sliceID, "--description", "content slice")
+	_ = runCLIOrFail(t, workdir, "init", sliceID)
+
+	if err := os.MkdirAll(filepath.Join(workdir, "src"), 0o755); err != nil {
+		t.Fatalf("failed to create src dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(workdir, "src", "main.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	output := runCLIOrFail(t, workdir, "changeset", "create", "--message", "add main", "src/main.go")
+	changesetID := extractChangesetID(output)
+	if changesetID == "" {
+		t.Fatalf("expected changeset ID in output: %s", output)
+	}
+	_ = runCLIOrFail(t, workdir, "changeset", "merge", changesetID)
+
+	// Re-uploading identical content should reuse the blob already on the server
+	output = runCLIOrFail(t, workdir, "changeset", "create", "--message", "same content", "--stream", "src/main.go")
+	if !strings.Contains(output, "1 blobs already on server") {
+		t.Fatalf("expected existing blob to be skipped, got: %s", output)
+	}
+
+	checkoutDir := t.TempDir()
+	_ = runCLIOrFail(t, checkoutDir, "slice", "checkout", sliceID)
+	data, err := os.ReadFile(filepath.Join(checkoutDir, "src", "main.go"))
+	if err != nil {
+		t.Fatalf("expected checked out file: %v", err)
+	}
+	if string(data) != "package main\n" {
+		t.Fatalf("unexpected checked out content: %q", data)
+	}
+}
+
+// TestChangesetUploadsLargeFiles verifies that a file larger than gRPC's
+// default 4MB message limit can be uploaded, merged and checked out.
+func TestChangesetUploadsLargeFiles(t *testing.T) {
+	workdir := t.TempDir()
+	sliceID := "changeset-large"
+
+	_ = runCLIOrFail(t, workdir, "slice", "create", sliceID)
+	_ = runCLIOrFail(t, workdir, "init", sliceID)
+	large := strings.Repeat("0123456789abcdef", 5<<16)
+	writeWorkFile(t, workdir, "large.bin", large)
+
+	output := runCLIOrFail(t, workdir, "changeset", "create", "--message", "add large file", "large.bin")
+	changesetID := extractChangesetID(output)
+	if changesetID == "" {
+		t.Fatalf("expected changeset ID in output: %s", output)
+	}
+	output = runCLIOrFail(t, workdir, "changeset", "merge", changesetID)
+	if !strings.Contains(output, "SUCCESS") {
+		t.Fatalf("expected merge to succeed, got: %s", output)
+	}
+
+	checkoutDir := t.TempDir()
+	_ = runCLIOrFail(t, checkoutDir, "slice", "checkout", sliceID)
+	data, err := os.ReadFile(filepath.Join(checkoutDir, "large.bin"))
+	if err != nil {
+		t.Fatalf("expected checked out file: %v", err)
+	}
+	if string(data) != large {
+		t.Fatalf("checked out content does not match the upload")
+	}
+}
+
+// TestChangesetDeletesFiles verifies that a changeset which only deletes
+// files removes them from the slice once merged.
+func TestChangesetDeletesFiles(t *testing.T) {
+	workdir := t.TempDir()
+	sliceID := "changeset-delete"
+
+	_ = runCLIOrFail(t, workdir, "slice", "create", sliceID)
+	_ = runCLIOrFail(t, workdir, "init", sliceID)
+	writeWorkFile(t, workdir, "a.txt", "a\n")
+	writeWorkFile(t, workdir, "b.txt", "b\n")
+	writeWorkFile(t, workdir, "c.txt", "c\n")
+
+	output := runCLIOrFail(t, workdir, "changeset", "create", "--message", "add files", "a.txt", "b.txt", "c.txt")
+	_ = runCLIOrFail(t, workdir, "changeset", "merge", extractChangesetID(output))
+
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if err := os.Remove(filepath.Join(workdir, name)); err != nil {
+			t.Fatalf("failed to remove %s: %v", name, err)
+		}
+	}
+	output = runCLIOrFail(t, workdir, "changeset", "create", "--message", "drop files", "a.txt", "b.txt")
+	changesetID := extractChangesetID(output)
+	if changesetID == "" {
+		t.Fatalf("expected changeset ID in output: %s", output)
+	}
+
+	output = runCLIOrFail(t, workdir, "changeset", "review", changesetID, "--diff", "--color", "never")
+	for _, want := range []string{"Files changed: 2", "--- a/a.txt", "--- a/b.txt", "+++ /dev/null"} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected review output to contain %q, got: %s", want, output)
+		}
+	}
+	_ = runCLIOrFail(t, workdir, "changeset", "merge", changesetID)
+
+	checkoutDir := t.TempDir()
+	_ = runCLIOrFail(t, checkoutDir, "slice", "checkout", sliceID)
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if _, err := os.Stat(filepath.Join(checkoutDir, name)); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to be deleted after merge, got %v", name, err)
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(checkoutDir, "c.txt")); err != nil || string(data) != "c\n" {
+		t.Fatalf("expected c.txt to survive the deletion, got %q, %v", data, err)
+	}
+}
+
+func TestChangesetReviewShowsDiff(t *testing.T) {
+	workdir := t.TempDir()
+	sliceID := "changeset-review-diff"
+
+	_ = runCLIOrFail(t, workdir, "slice", "create", sliceID)
+	_ = runCLIOrFail(t, workdir, "init", sliceID)
+	if err := os.WriteFile(filepath.Join(workdir, "review-notes.txt"), []byte("first\nsecond\n"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	output := runCLIOrFail(t, workdir, "changeset", "create", "--message", "notes", "review-notes.txt")
+	changesetID := extractChangesetID(output)
+	if changesetID == "" {
+		t.Fatalf("expected changeset ID in output: %s", output)
+	}
+
+	output = runCLIOrFail(t, workdir, "changeset", "review", changesetID, "--diff", "--stat", "--color", "never")
+	for _, want := range []string{"+++ b/review-notes.txt", "+first", "+second", "1 files changed, 2 insertions(+)"} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected review output to contain %q, got: %s", want, output)
+		}
+	}
+
+	output = runCLIOrFail(t, workdir, "changeset", "review", changesetID, "--external", "--tool", "sed -n 's/^+/piped:/p'")
+	if !strings.Contains(output, "piped:first") {
+		t.Fatalf("expected difftool to receive the unified diff, got: %s", output)
+	}
+}
+
+// TestChangesetApprovalGatesMerge verifies that a slice's approval rule blocks
+// merges until enough reviewers approve, and that rejections are recorded.
+func TestChangesetApprovalGatesMerge(t *testing.T) {
+	workdir := t.TempDir()
+	sliceID := "changeset-approval"
+
+	_ = runCLIOrFail(t, workdir, "slice", "create", sliceID, "--required-approvals", "1", "--owners-only")
+	_ = runCLIOrFail(t, workdir, "init", sliceID)
+	writeWorkFile(t, workdir, "approval.txt", "needs review\n")
+
+	output := runCLIOrFail(t, workdir, "changeset", "create", "--message", "reviewed change", "approval.txt")
+	changesetID := extractChangesetID(output)
+	if changesetID == "" {
+		t.Fatalf("expected changeset ID in output: %s", output)
+	}
+
+	if output, err := runCLIWithDir(workdir, "changeset", "merge", changesetID); err == nil {
+		t.Fatalf("expected merge without approval to fail, got: %s", output)
+	}
+
+	output = runCLIOrFail(t, workdir, "changeset", "reject", changesetID, "--comment", "needs tests")
+	if !strings.Contains(output, "Status: REJECTED") || !strings.Contains(output, "user rejected: needs tests") {
+		t.Fatalf("expected rejection to be recorded, got: %s", output)
+	}
+
+	output = runCLIOrFail(t, workdir, "changeset", "approve", changesetID, "--comment", "tests added")
+	if !strings.Contains(output, "Status: APPROVED") || !strings.Contains(output, "Approvals: 1 of 1 required") {
+		t.Fatalf("expected approval to replace the rejection, got: %s", output)
+	}
+
+	output = runCLIOrFail(t, workdir, "changeset", "merge", changesetID)
+	if !strings.Contains(output, "SUCCESS") {
+		t.Fatalf("expected approved changeset to merge, got: %s", output)
+	}
+}
+
+func TestChangesetRequiredChecks(t *testing.T) {
+	workdir := t.TempDir()
+	sliceID := "changeset-checks"
+
+	_ = runCLIOrFail(t, workdir, "slice", "create", sliceID, "--required-checks", "ci")
+	_ = runCLIOrFail(t, workdir, "init", sliceID)
+	writeWorkFile(t, workdir, "checks.txt", "needs ci\n")
+	changesetID := extractChangesetID(runCLIOrFail(t, workdir, "changeset", "create", "--message", "checked change", "checks.txt"))
+	if changesetID == "" {
+		t.Fatalf("expected changeset ID")
+	}
+
+	output := runCLIOrFail(t, workdir, "changeset", "check", changesetID, "--name", "ci", "--state", "failure", "--description", "unit tests failed")
+	if !strings.Contains(output, "check ci: failure - unit tests failed") || !strings.Contains(output, "Waiting on required checks: ci") {
+		t.Fatalf("expected failed check to be recorded, got: %s", output)
+	}
+	output = runCLIOrFail(t, workdir, "changeset", "review", changesetID)
+	if !strings.Contains(output, "Warning: Required check ci failed: unit tests failed.") {
+		t.Fatalf("expected review to warn about the failed check, got: %s", output)
+	}
+	if output, err := runCLIWithDir(workdir, "changeset", "merge", changesetID); err == nil {
+		t.Fatalf("expected merge with a failed check to fail, got: %s", output)
+	}
+
+	output = runCLIOrFail(t, workdir, "changeset", "check", changesetID, "--name", "ci", "--state", "success")
+	if !strings.Contains(output, "All required checks passed") {
+		t.Fatalf("expected check to pass, got: %s", output)
+	}
+	output = runCLIOrFail(t, workdir, "changeset", "merge", changesetID)
+	if !strings.Contains(output, "SUCCESS") {
+		t.Fatalf("expected changeset with green checks to merge, got: %s", output)
+	}
+}
+
+// TestChangesetReviewComments leaves a line comment, checks it shows up inline
+// in the review diff and resolves it.
+func TestChangesetReviewComments(t *testing.T) {
+	workdir := t.TempDir()
+	sliceID := "changeset-comments"
+
+	_ = runCLIOrFail(t, workdir, "slice", "create", sliceID)
+	_ = runCLIOrFail(t, workdir, "init", sliceID)
+	writeWorkFile(t, workdir, "commented.txt", "first\nsecond\n")
+
+	output := runCLIOrFail(t, workdir, "changeset", "create", "--message", "commented change", "commented.txt")
+	changesetID := extractChangesetID(output)
+	if changesetID == "" {
+		t.Fatalf("expected changeset ID in output: %s", output)
+	}
+
+	output = runCLIOrFail(t, workdir, "changeset", "comment", changesetID, "--message", "rename this", "--file", "commented.txt", "--line", "2", "--author", "bob")
+	if !strings.Contains(output, "on commented.txt:2") {
+		t.Fatalf("expected comment confirmation, got: %s", output)
+	}
+	commentID := strings.Fields(strings.TrimPrefix(output, "Added comment "))[0]
+
+	output = runCLIOrFail(t, workdir, "changeset", "review", changesetID, "--diff", "--color", "never")
+	if !strings.Contains(output, "+second\n    > ["+commentID+"] bob: rename this\n") {
+		t.Fatalf("expected comment under its line in the review diff, got: %s", output)
+	}
+	if !strings.Contains(output, "1 unresolved review comment thread") {
+		t.Fatalf("expected unresolved comment warning, got: %s", output)
+	}
+
+	_ = runCLIOrFail(t, workdir, "changeset", "comment", changesetID, "--resolve", commentID)
+	output = runCLIOrFail(t, workdir, "changeset", "comment", changesetID)
+	if !strings.Contains(output, "rename this (resolved by user)") {
+		t.Fatalf("expected resolved thread in listing, got: %s", output)
+	}
+}
+
+// TestStackedChangesetWorkflow stacks one changeset on another and checks the
+// child only merges after its parent.
+func TestStackedChangesetWorkflow(t *testing.T) {
+	workdir := t.TempDir()
+	sliceID := "changeset-stack"
+
+	_ = runCLIOrFail(t, workdir, "slice", "create", sliceID)
+	_ = runCLIOrFail(t, workdir, "init", sliceID)
+	writeWorkFile(t, workdir, "stack-base.txt", "base\n")
+	output := runCLIOrFail(t, workdir, "changeset", "create", "--message", "stack base", "stack-base.txt")
+	parentID := extractChangesetID(output)
+
+	writeWorkFile(t, workdir, "stack-top.txt", "top\n")
+	output = runCLIOrFail(t, workdir, "changeset", "create", "--message", "stack top", "--parent", parentID, "stack-top.txt")
+	childID := extractChangesetID(output)
+	if parentID == "" || childID == "" {
+		t.Fatalf("expected changeset IDs, got %q and %q", parentID, childID)
+	}
+
+	output = runCLIOrFail(t, workdir, "changeset", "list", "--stack")
+	if !strings.Contains(output, parentID+" [PENDING] stack base\n└── "+childID+" [PENDING] stack top") {
+		t.Fatalf("expected child under its parent, got: %s", output)
+	}
+
+	if output, err := runCLIWithDir(workdir, "changeset", "merge", childID); err == nil {
+		t.Fatalf("expected child merge before its parent to fail, got: %s", output)
+	}
+
+	_ = runCLIOrFail(t, workdir, "changeset", "merge", parentID)
+	output = runCLIOrFail(t, workdir, "changeset", "rebase", childID)
+	if !strings.Contains(output, "REBASE_STATUS_SUCCESS") {
+		t.Fatalf("expected child to rebase onto the merged parent, got: %s", output)
+	}
+	output = runCLIOrFail(t, workdir, "changeset", "merge", childID)
+	if !strings.Contains(output, "SUCCESS") {
+		t.Fatalf("expected child to merge after its parent, got: %s", output)
+	}
+}
+
+func TestMergeQueueWorkflow(t *testing.T) {
+	workdir := t.TempDir()
+	sliceID := "changeset-queue"
+
+	_ = runCLIOrFail(t, workdir, "slice", "create", sliceID)
+	_ = runCLIOrFail(t, workdir, "init", sliceID)
+	writeWorkFile(t, workdir, "queue-one.txt", "one\n")
+	firstID := extractChangesetID(runCLIOrFail(t, workdir, "changeset", "create", "--message", "queue one", "queue-one.txt"))
+	writeWorkFile(t, workdir, "queue-two.txt", "two\n")
+	secondID := extractChangesetID(runCLIOrFail(t, workdir, "changeset", "create", "--message", "queue two", "queue-two.txt"))
+	if firstID == "" || secondID == "" {
+		t.Fatalf("expected changeset IDs, got %q and %q", firstID, secondID)
+	}
+
+	output := runCLIOrFail(t, workdir, "changeset", "enqueue", firstID)
+	if !strings.Contains(output, "Queued changeset "+firstID) {
+		t.Fatalf("expected changeset to be queued, got: %s", output)
+	}
+
+	// The second was created on the old head, so the queue rebases it before merging
+	output = runCLIOrFail(t, workdir, "changeset", "enqueue", secondID, "--watch")
+	if !strings.Contains(output, "Merge status: MERGED") || !strings.Contains(output, "New commit:") {
+		t.Fatalf("expected queued merge to land, got: %s", output)
+	}
+
+	output = runCLIOrFail(t, workdir, "changeset", "list", "--status", "merged")
+	if !strings.Contains(output, firstID) || !strings.Contains(output, secondID) {
+		t.Fatalf("expected both changesets merged, got: %s", output)
+	}
+}
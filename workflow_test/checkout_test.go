@@ -83,16 +83,13 @@ func TestCheckoutEmptySlice(t *testing.T) {
 }
 
 func TestStreamCheckoutSlice(t *testing.T) {
-	// Streaming checkout is not yet implemented in CLI
-	// This test verifies the command is recognized
 	workdir := t.TempDir()
 	sliceID := "stream-checkout"
 
 	_ = runCLIOrFail(t, workdir, "slice", "create", sliceID)
 
-	// The streaming version would be used for large slices
-	// For now, the regular checkout is used
-	output := runCLIOrFail(t, workdir, "slice", "checkout", sliceID)
+	// Large slices switch to the stream automatically; --stream forces it
+	output := runCLIOrFail(t, workdir, "slice", "checkout", sliceID, "--stream")
 
 	if !strings.Contains(output, sliceID) {
 		t.Fatalf("expected checkout to mention slice ID %s, got: %s", sliceID, output)
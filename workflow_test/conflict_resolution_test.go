@@ -215,17 +215,31 @@ func createConflictSetupWithSlices(t *testing.T) (string, string, string, string
 	sliceA := fmt.Sprintf("conflict-a-%s", strings.ToLower(t.Name()))
 	sliceB := fmt.Sprintf("conflict-b-%s", strings.ToLower(t.Name()))
 
-	if _, err := runCLI("slice", "create", sliceA, "--files", fileID); err != nil {
-		t.Fatalf("failed to create base slice: %v", err)
-	}
 	if _, err := runCLI("slice", "create", sliceB, "--files", fileID); err != nil {
 		t.Fatalf("failed to create conflicting slice: %v", err)
 	}
 
+	// Sharing the file is not enough: each slice has to change it differently
+	theirs := t.TempDir()
+	_ = runCLIOrFail(t, theirs, "init", sliceB)
+	writeWorkFile(t, theirs, fileID, "theirs\n")
+	changesetID := extractChangesetID(runCLIOrFail(t, theirs, "changeset", "create", "--message", "theirs", fileID))
+	if changesetID == "" {
+		t.Fatalf("expected changeset ID for %s", sliceB)
+	}
+	if output := runCLIOrFail(t, theirs, "changeset", "merge", changesetID); !strings.Contains(output, "MERGE_STATUS_SUCCESS") {
+		t.Fatalf("expected %s to merge its version, got: %s", sliceB, output)
+	}
+
+	if _, err := runCLI("slice", "create", sliceA, "--files", fileID); err != nil {
+		t.Fatalf("failed to create base slice: %v", err)
+	}
 	workdir := t.TempDir()
 	if _, err := runCLIWithDir(workdir, "init", sliceA); err != nil {
 		t.Fatalf("failed to init working dir: %v", err)
 	}
+	writeWorkFile(t, workdir, fileID, "ours\n")
+	_ = runCLIOrFail(t, workdir, "changeset", "create", "--message", "ours", fileID)
 
 	return workdir, sliceA, fileID, sliceB
 }
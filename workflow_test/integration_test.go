@@ -289,7 +289,13 @@ func TestRootSliceAndForkWorkflow(t *testing.T) {
 	}
 
 	srcFolder := fmt.Sprintf("src_%d", time.Now().UnixNano())
-	output = runCLIOrFail(t, workdir, "changeset", "create", "--message", "Create src folder", "--files", srcFolder)
+	if err := os.MkdirAll(filepath.Join(workdir, srcFolder, "util"), 0o755); err != nil {
+		t.Fatalf("failed to create src folder: %v", err)
+	}
+	mainFile, helperFile := srcFolder+"/main.go", srcFolder+"/util/helper.go"
+	writeWorkFile(t, workdir, mainFile, "package main\n")
+	writeWorkFile(t, workdir, helperFile, "package util\n")
+	output = runCLIOrFail(t, workdir, "changeset", "create", "--message", "Create src folder", mainFile, helperFile)
 	changesetID := extractChangesetID(output)
 	if changesetID == "" {
 		t.Fatalf("Failed to extract changeset ID from output: %s", output)
@@ -305,6 +311,18 @@ func TestRootSliceAndForkWorkflow(t *testing.T) {
 	if !strings.Contains(output, "Created slice: "+newSliceID) {
 		t.Fatalf("Expected slice creation output, got: %s", output)
 	}
+	if !strings.Contains(output, "Files moved from root_slice: 2") || !strings.Contains(output, mainFile) || !strings.Contains(output, helperFile) {
+		t.Fatalf("Expected the folder's files to move to the new slice, got: %s", output)
+	}
+
+	output = runCLIOrFail(t, workdir, "log", newSliceID)
+	if !strings.Contains(output, "1 commit(s)") {
+		t.Fatalf("Expected the new slice to start with one commit, got: %s", output)
+	}
+
+	if output, err := runCLIWithDir(workdir, "fork", newSliceID+"-empty", srcFolder+"-missing", "--parent", "root_slice"); err == nil {
+		t.Fatalf("Expected forking a missing folder to fail, got: %s", output)
+	}
 
 	output = runCLIOrFail(t, workdir, "slice", "info", newSliceID)
 	if !strings.Contains(output, "Slice: "+newSliceID) {
@@ -330,6 +348,144 @@ func TestRootSliceAndForkWorkflow(t *testing.T) {
 	}
 }
 
+func TestSliceTreeAndFoldWorkflow(t *testing.T) {
+	workdir := t.TempDir()
+	runCLIOrFail(t, workdir, "init", "root_slice")
+
+	appFolder := fmt.Sprintf("app_%d", time.Now().UnixNano())
+	if err := os.MkdirAll(filepath.Join(workdir, appFolder, "util"), 0o755); err != nil {
+		t.Fatalf("failed to create app folder: %v", err)
+	}
+	mainFile, helperFile := appFolder+"/main.go", appFolder+"/util/helper.go"
+	writeWorkFile(t, workdir, mainFile, "package main\n")
+	writeWorkFile(t, workdir, helperFile, "package util\n")
+	output := runCLIOrFail(t, workdir, "changeset", "create", "--message", "Create app folder", mainFile, helperFile)
+	changesetID := extractChangesetID(output)
+	if changesetID == "" {
+		t.Fatalf("Failed to extract changeset ID from output: %s", output)
+	}
+	runCLIOrFail(t, workdir, "changeset", "merge", changesetID)
+
+	appSlice := "slice-" + appFolder
+	utilSlice := appSlice + "-util"
+	runCLIOrFail(t, workdir, "fork", appSlice, appFolder, "--parent", "root_slice")
+	runCLIOrFail(t, workdir, "fork", utilSlice, appFolder+"/util", "--parent", appSlice)
+
+	output = runCLIOrFail(t, workdir, "slice", "tree", appSlice)
+	if !strings.Contains(output, "Path: root_slice / "+appSlice) || !strings.Contains(output, "└── "+utilSlice+" (1 file)") {
+		t.Fatalf("Expected the app slice's path and child, got: %s", output)
+	}
+
+	output = runCLIOrFail(t, workdir, "slice", "reparent", utilSlice, "root_slice")
+	if !strings.Contains(output, fmt.Sprintf("Moved slice %s from %s to root_slice", utilSlice, appSlice)) {
+		t.Fatalf("Expected reparent output, got: %s", output)
+	}
+	if output, err := runCLIWithDir(workdir, "slice", "reparent", "root_slice", utilSlice); err == nil {
+		t.Fatalf("Expected moving the root slice to fail, got: %s", output)
+	}
+	runCLIOrFail(t, workdir, "slice", "reparent", utilSlice, appSlice)
+
+	output = runCLIOrFail(t, workdir, "slice", "fold", appSlice)
+	if !strings.Contains(output, "Folded slice "+appSlice+" into root_slice") || !strings.Contains(output, "Files moved to root_slice: 1") {
+		t.Fatalf("Expected the app slice to fold into the root, got: %s", output)
+	}
+	if !strings.Contains(output, "Moved slice "+utilSlice+" under root_slice") {
+		t.Fatalf("Expected the util slice to move up, got: %s", output)
+	}
+
+	output = runCLIOrFail(t, workdir, "slice", "tree")
+	if !strings.Contains(output, "\n├── "+utilSlice+" (1 file)") && !strings.Contains(output, "\n└── "+utilSlice+" (1 file)") {
+		t.Fatalf("Expected the util slice directly under the root, got: %s", output)
+	}
+}
+
+func TestSliceUpdateArchiveAndDeleteWorkflow(t *testing.T) {
+	workdir := t.TempDir()
+	teamSlice := fmt.Sprintf("team-%d", time.Now().UnixNano())
+	subSlice := teamSlice + "-sub"
+	folder := teamSlice + "-files"
+
+	runCLIOrFail(t, workdir, "slice", "create", teamSlice, "--files", folder+"/a.txt,"+folder+"/b.txt")
+	output := runCLIOrFail(t, workdir, "slice", "update", teamSlice, "--name", "Team", "--add-files", folder+"/c.txt", "--remove-files", folder+"/a.txt", "--add-owners", "alice")
+	for _, want := range []string{
+		"Name: Team",
+		"Files: " + folder + "/b.txt, " + folder + "/c.txt",
+		"Owners: alice",
+		"Removed files passed to root_slice",
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("Expected %q in update output, got: %s", want, output)
+		}
+	}
+
+	runCLIOrFail(t, workdir, "slice", "create", subSlice, "--files", folder+"/sub/d.txt")
+	runCLIOrFail(t, workdir, "slice", "reparent", subSlice, teamSlice)
+
+	output = runCLIOrFail(t, workdir, "slice", "archive", teamSlice)
+	if !strings.Contains(output, "Files reassigned to root_slice: 2") || !strings.Contains(output, "Moved slice "+subSlice+" under root_slice") {
+		t.Fatalf("Expected the archived slice's files and child to pass to the root, got: %s", output)
+	}
+	output = runCLIOrFail(t, workdir, "slice", "list", "--limit", "10000")
+	listed := false
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "- "+teamSlice+" (") {
+			listed = strings.HasSuffix(line, "[archived]")
+		}
+	}
+	if !listed {
+		t.Fatalf("Expected the slice to be listed as archived, got: %s", output)
+	}
+	if output, err := runCLIWithDir(workdir, "slice", "update", teamSlice, "--name", "Renamed"); err == nil {
+		t.Fatalf("Expected updating an archived slice to fail, got: %s", output)
+	}
+
+	output = runCLIOrFail(t, workdir, "slice", "delete", subSlice)
+	if !strings.Contains(output, "Deleted slice: "+subSlice) || !strings.Contains(output, "Files reassigned to root_slice: 1") {
+		t.Fatalf("Expected the deleted slice's file to pass to the root, got: %s", output)
+	}
+	if output, err := runCLIWithDir(workdir, "slice", "delete", subSlice); err == nil {
+		t.Fatalf("Expected deleting a missing slice to fail, got: %s", output)
+	}
+	if output, err := runCLIWithDir(workdir, "slice", "delete", "root_slice"); err == nil {
+		t.Fatalf("Expected deleting the root slice to fail, got: %s", output)
+	}
+}
+
+func TestArchivePassesPatternFilesAndRejectsArchivedHeir(t *testing.T) {
+	workdir := t.TempDir()
+	runCLIOrFail(t, workdir, "init", "root_slice")
+
+	folder := fmt.Sprintf("patterned_%d", time.Now().UnixNano())
+	if err := os.MkdirAll(filepath.Join(workdir, folder), 0o755); err != nil {
+		t.Fatalf("failed to create folder: %v", err)
+	}
+	writeWorkFile(t, workdir, folder+"/a.go", "package a\n")
+	writeWorkFile(t, workdir, folder+"/b.go", "package a\n")
+	output := runCLIOrFail(t, workdir, "changeset", "create", "--message", "Add patterned files", folder+"/a.go", folder+"/b.go")
+	changesetID := extractChangesetID(output)
+	if changesetID == "" {
+		t.Fatalf("Failed to extract changeset ID from output: %s", output)
+	}
+	runCLIOrFail(t, workdir, "changeset", "merge", changesetID)
+
+	patternSlice := "slice-" + folder
+	runCLIOrFail(t, workdir, "slice", "create", patternSlice, "--include", folder+"/")
+	output = runCLIOrFail(t, workdir, "slice", "archive", patternSlice)
+	if !strings.Contains(output, "Files reassigned to root_slice: 2") {
+		t.Fatalf("Expected the files the patterns matched to pass to the root, got: %s", output)
+	}
+
+	childSlice := patternSlice + "-child"
+	runCLIOrFail(t, workdir, "slice", "create", childSlice, "--files", folder+"/c.go")
+	runCLIOrFail(t, workdir, "slice", "reparent", childSlice, patternSlice)
+	if output, err := runCLIWithDir(workdir, "slice", "delete", childSlice); err == nil || !strings.Contains(output, "is archived") {
+		t.Fatalf("Expected deleting a slice under an archived parent to fail, got: %v %s", err, output)
+	}
+	if output, err := runCLIWithDir(workdir, "slice", "update", childSlice, "--remove-files", folder+"/c.go"); err == nil || !strings.Contains(output, "is archived") {
+		t.Fatalf("Expected releasing files to an archived parent to fail, got: %v %s", err, output)
+	}
+}
+
 func TestBatchMergeClearsConflictsAndPromotesFiles(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping batch merge integration test in short mode")
@@ -571,6 +727,11 @@ func TestRedisRestartRebuildsEndToEnd(t *testing.T) {
 		t.Fatalf("merge failed: %v", err)
 	}
 
+	before, err := adminClient.GetGlobalState(ctx, &adminv1.GlobalStateRequest{IncludeHistory: true})
+	if err != nil {
+		t.Fatalf("failed to read global state: %v", err)
+	}
+
 	mr.FlushAll()
 	if err := st.RebuildIndexes(ctx); err != nil {
 		t.Fatalf("rebuild after flush failed: %v", err)
@@ -580,8 +741,8 @@ func TestRedisRestartRebuildsEndToEnd(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to read global state after rebuild: %v", err)
 	}
-	if globalState.GlobalCommitHash != mergeResp.NewCommitHash {
-		t.Fatalf("expected global commit %s after rebuild, got %s", mergeResp.NewCommitHash, globalState.GlobalCommitHash)
+	if globalState.GlobalCommitHash != before.GlobalCommitHash {
+		t.Fatalf("expected global commit %s after rebuild, got %s", before.GlobalCommitHash, globalState.GlobalCommitHash)
 	}
 
 	sliceState, err := sliceClient.GetSliceState(ctx, &slicev1.StateRequest{SliceId: sliceID})
@@ -641,8 +802,8 @@ func TestSlicePushLocksAndAutoPromotion(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to read global state: %v", err)
 	}
-	if stateResp.GlobalCommitHash != mergeResp.NewCommitHash {
-		t.Fatalf("expected global head %s to reflect slice promotion, got %s", mergeResp.NewCommitHash, stateResp.GlobalCommitHash)
+	if stateResp.GlobalCommitHash == "" || stateResp.GlobalCommitHash == mergeResp.NewCommitHash {
+		t.Fatalf("expected slice promotion to make a new root commit, got global head %s", stateResp.GlobalCommitHash)
 	}
 
 	promoted := false
@@ -662,8 +823,8 @@ func TestSlicePushLocksAndAutoPromotion(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to fetch root slice state: %v", err)
 	}
-	if rootState.LatestCommitHash != mergeResp.NewCommitHash {
-		t.Fatalf("expected root slice head %s, got %s", mergeResp.NewCommitHash, rootState.LatestCommitHash)
+	if rootState.LatestCommitHash != stateResp.GlobalCommitHash {
+		t.Fatalf("expected root slice head %s, got %s", stateResp.GlobalCommitHash, rootState.LatestCommitHash)
 	}
 
 	otherChange, err := sliceClient.CreateChangeset(ctx, &slicev1.CreateChangesetRequest{SliceId: sliceB, ModifiedFiles: []string{sharedFile}, Message: "should conflict"})
@@ -768,25 +929,17 @@ func TestConcurrentSlicePushesPromoteHistory(t *testing.T) {
 		t.Fatalf("expected root slice head %s to match global %s", rootState.LatestCommitHash, globalState.GlobalCommitHash)
 	}
 
-	historyCommits := make(map[string][]string)
+	// Each promotion is its own root commit, so every slice has one entry
+	promotions := make(map[string]int)
 	for _, entry := range globalState.History {
-		historyCommits[entry.CommitHash] = entry.MergedSliceIds
+		for _, id := range entry.MergedSliceIds {
+			promotions[id]++
+		}
 	}
 
-	for sliceID, commitHash := range commits {
-		mergedSlices, ok := historyCommits[commitHash]
-		if !ok {
-			t.Fatalf("expected commit %s for slice %s to appear in global history", commitHash, sliceID)
-		}
-		foundSlice := false
-		for _, id := range mergedSlices {
-			if id == sliceID {
-				foundSlice = true
-				break
-			}
-		}
-		if !foundSlice {
-			t.Fatalf("expected slice %s to be recorded in history entry %s", sliceID, commitHash)
+	for sliceID := range commits {
+		if promotions[sliceID] != 1 {
+			t.Fatalf("expected slice %s to be promoted once in global history, got %d", sliceID, promotions[sliceID])
 		}
 	}
 
@@ -33,6 +33,24 @@ func TestSliceCreateWithDescription(t *testing.T) {
 	}
 }
 
+// TestSliceCreateWithPatterns tests defining slices by path patterns
+// Command: gs slice create payments --include "services/payments/" --exclude "**/*_test.go"
+func TestSliceCreateWithPatterns(t *testing.T) {
+	output := runCLIOrFail(t, "", "slice", "create", "patterns-payments", "--include", "services/patterns-payments/", "--exclude", "**/*_test.go")
+	if !strings.Contains(output, "Include: services/patterns-payments/") || !strings.Contains(output, "Exclude: **/*_test.go") {
+		t.Fatalf("expected patterns in output, got: %s", output)
+	}
+
+	output = runCLIOrFail(t, "", "slice", "create", "patterns-payments-api", "--include", "services/patterns-payments/api/**")
+	if !strings.Contains(output, "Overlaps slice patterns-payments: services/patterns-payments/api/** with services/patterns-payments/") {
+		t.Fatalf("expected overlap with the enclosing slice, got: %s", output)
+	}
+
+	if output, err := runCLI("slice", "create", "patterns-invalid", "--include", "../outside"); err == nil {
+		t.Fatalf("expected invalid pattern to be rejected, got: %s", output)
+	}
+}
+
 // TestSliceList tests listing all available slices
 // Command: gs slice list
 func TestSliceList(t *testing.T) {
@@ -1,6 +1,8 @@
 package workflow
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -17,3 +19,109 @@ func TestStatusShowsSliceBinding(t *testing.T) {
 		t.Fatalf("expected status to mention slice binding, got: %s", output)
 	}
 }
+
+func TestStatusDetectsLocalModifications(t *testing.T) {
+	workdir := t.TempDir()
+	sliceID := "status-changes"
+
+	_ = runCLIOrFail(t, workdir, "slice", "create", sliceID)
+	_ = runCLIOrFail(t, workdir, "init", sliceID)
+	writeWorkFile(t, workdir, "status-a.txt", "a")
+	writeWorkFile(t, workdir, "status-b.txt", "b")
+
+	// With no file arguments, changeset create uses the detected changes
+	output := runCLIOrFail(t, workdir, "changeset", "create", "--message", "initial")
+	changesetID := extractChangesetID(output)
+	if changesetID == "" {
+		t.Fatalf("expected changeset ID in output: %s", output)
+	}
+	output = runCLIOrFail(t, workdir, "changeset", "merge", changesetID)
+	if !strings.Contains(output, "SUCCESS") {
+		t.Fatalf("expected merge to succeed, got: %s", output)
+	}
+
+	checkoutDir := t.TempDir()
+	_ = runCLIOrFail(t, checkoutDir, "init", sliceID)
+	_ = runCLIOrFail(t, checkoutDir, "slice", "checkout", sliceID)
+
+	output = runCLIOrFail(t, checkoutDir, "status")
+	if !strings.Contains(output, "Working directory: Clean") {
+		t.Fatalf("expected clean status after checkout, got: %s", output)
+	}
+
+	writeWorkFile(t, checkoutDir, "status-a.txt", "changed")
+	writeWorkFile(t, checkoutDir, "status-c.txt", "c")
+	if err := os.Remove(filepath.Join(checkoutDir, "status-b.txt")); err != nil {
+		t.Fatalf("failed to remove file: %v", err)
+	}
+
+	output = runCLIOrFail(t, checkoutDir, "status")
+	for _, want := range []string{"added:    status-c.txt", "modified: status-a.txt", "deleted:  status-b.txt"} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected status to contain %q, got: %s", want, output)
+		}
+	}
+}
+
+func TestCheckoutRemovesFilesMissingFromCommit(t *testing.T) {
+	workdir := t.TempDir()
+	sliceID := "checkout-history"
+
+	_ = runCLIOrFail(t, workdir, "slice", "create", sliceID)
+	_ = runCLIOrFail(t, workdir, "init", sliceID)
+
+	merge := func(name, content string) {
+		t.Helper()
+		writeWorkFile(t, workdir, name, content)
+		output := runCLIOrFail(t, workdir, "changeset", "create", name, "--message", "add "+name)
+		changesetID := extractChangesetID(output)
+		if changesetID == "" {
+			t.Fatalf("expected changeset ID in output: %s", output)
+		}
+		output = runCLIOrFail(t, workdir, "changeset", "merge", changesetID)
+		if !strings.Contains(output, "SUCCESS") {
+			t.Fatalf("expected merge to succeed, got: %s", output)
+		}
+	}
+
+	merge("first.txt", "first")
+	checkoutDir := t.TempDir()
+	_ = runCLIOrFail(t, checkoutDir, "init", sliceID)
+	output := runCLIOrFail(t, checkoutDir, "slice", "checkout", sliceID)
+	firstCommit := ""
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "Commit: ") {
+			firstCommit = strings.TrimSpace(strings.TrimPrefix(line, "Commit: "))
+		}
+	}
+	if firstCommit == "" {
+		t.Fatalf("expected commit hash in checkout output: %s", output)
+	}
+
+	merge("second.txt", "second")
+	_ = runCLIOrFail(t, checkoutDir, "slice", "checkout", sliceID)
+	if _, err := os.Stat(filepath.Join(checkoutDir, "second.txt")); err != nil {
+		t.Fatalf("expected second.txt after checking out HEAD: %v", err)
+	}
+
+	// Going back to the first commit drops the file it did not have
+	_ = runCLIOrFail(t, checkoutDir, "slice", "checkout", sliceID, "--commit", firstCommit)
+	if _, err := os.Stat(filepath.Join(checkoutDir, "second.txt")); !os.IsNotExist(err) {
+		t.Fatalf("expected second.txt to be removed, stat returned: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(checkoutDir, "first.txt")); err != nil {
+		t.Fatalf("expected first.txt to remain: %v", err)
+	}
+
+	output = runCLIOrFail(t, checkoutDir, "status")
+	if !strings.Contains(output, "Working directory: Clean") {
+		t.Fatalf("expected clean status after checkout, got: %s", output)
+	}
+}
+
+func writeWorkFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+}